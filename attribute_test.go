@@ -0,0 +1,69 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadWriteAttribute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	attrs["vendor_attr"] = "initial"
+	attrs[holdPIDkp] = "0"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): attrs,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	for _, test := range []struct {
+		name, value string
+	}{
+		{name: "vendor_attr", value: "changed"},
+		{name: "hold_pid/Kp", value: "4000"},
+	} {
+		err = WriteAttribute(m, test.name, test.value)
+		if err != nil {
+			t.Errorf("unexpected error writing %s: %v", test.name, err)
+		}
+		got, err := ReadAttribute(m, test.name)
+		if err != nil {
+			t.Errorf("unexpected error reading %s: %v", test.name, err)
+		}
+		if got != test.value {
+			t.Errorf("unexpected value for %s: got:%q want:%q", test.name, got, test.value)
+		}
+	}
+
+	for _, name := range []string{"", ".", "..", "../motor1/command", "/etc/passwd", "hold_pid/../../motor1/command"} {
+		_, err = ReadAttribute(m, name)
+		if err == nil {
+			t.Errorf("expected error reading %q", name)
+		}
+		err = WriteAttribute(m, name, "x")
+		if err == nil {
+			t.Errorf("expected error writing %q", name)
+		}
+	}
+
+	m.err = ErrTornRead
+	_, err = ReadAttribute(m, "vendor_attr")
+	if err != ErrTornRead {
+		t.Errorf("unexpected error: got:%v want:%v", err, ErrTornRead)
+	}
+	if m.err != nil {
+		t.Errorf("sticky error not cleared: %v", m.err)
+	}
+}
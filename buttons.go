@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"reflect"
 	"sync"
@@ -66,30 +67,40 @@ func NewButtonWaiter() (*ButtonWaiter, error) {
 	if err != nil {
 		return nil, fmt.Errorf("ev3dev: failed to open button event device: %v", err)
 	}
+	return newButtonWaiter(ev), nil
+}
 
+func newButtonWaiter(ev *os.File) *ButtonWaiter {
 	c := make(chan ButtonEvent)
 	b := &ButtonWaiter{Events: c, f: ev, done: make(chan struct{})}
 
 	b.wg.Add(1)
 	go func() {
 		defer b.wg.Done()
+		defer close(c)
 		var buf [16]byte
 		for {
+			var e ButtonEvent
+			_, err := io.ReadFull(ev, buf[:])
+			if err != nil {
+				select {
+				case <-b.done:
+					// The read was interrupted by Close.
+					return
+				default:
+				}
+				e = ButtonEvent{Err: err}
+			} else {
+				e = getEvent(buf[:])
+			}
 			select {
+			case c <- e:
 			case <-b.done:
-				close(c)
 				return
-			default:
-				_, err := io.ReadFull(ev, buf[:])
-				if err != nil {
-					c <- ButtonEvent{Err: err}
-					continue
-				}
-				c <- getEvent(buf[:])
 			}
 		}
 	}()
-	return b, nil
+	return b
 }
 
 func getEvent(buf []byte) ButtonEvent {
@@ -104,17 +115,136 @@ func getEvent(buf []byte) ButtonEvent {
 }
 
 // Close closes the backing events source file and the Events channel.
+// A pending read of the events source is interrupted by Close.
 func (b *ButtonWaiter) Close() error {
 	select {
 	case <-b.done:
 		return nil
 	default:
 		close(b.done)
+		err := b.f.Close()
 		b.wg.Wait()
-		return b.f.Close()
+		return err
 	}
 }
 
+// ButtonBinder runs actions bound to button presses. Actions are run
+// sequentially on a single dedicated goroutine, so an action that blocks
+// delays subsequent actions. If an action panics, all motors are stopped
+// and LEDs turned off as described for RunProtected, and the panic is
+// logged; the ButtonBinder continues to run later actions.
+type ButtonBinder struct {
+	debounce time.Duration
+
+	mu      sync.Mutex
+	actions map[Button]func()
+	last    map[Button]time.Duration
+
+	closer io.Closer
+	run    chan func()
+	wg     sync.WaitGroup
+}
+
+// NewButtonBinder returns a new ButtonBinder. Presses of a button that
+// occur within the debounce duration of the previous accepted press of
+// the same button are ignored.
+func NewButtonBinder(debounce time.Duration) (*ButtonBinder, error) {
+	w, err := NewButtonWaiter()
+	if err != nil {
+		return nil, err
+	}
+	return newButtonBinder(w.Events, w, debounce), nil
+}
+
+func newButtonBinder(events <-chan ButtonEvent, closer io.Closer, debounce time.Duration) *ButtonBinder {
+	b := &ButtonBinder{
+		debounce: debounce,
+		actions:  make(map[Button]func()),
+		last:     make(map[Button]time.Duration),
+		closer:   closer,
+		run:      make(chan func(), 16),
+	}
+	b.wg.Add(2)
+	go func() {
+		defer b.wg.Done()
+		defer close(b.run)
+		for e := range events {
+			if e.Err != nil || e.Type != evKey || e.Value != keyPress {
+				continue
+			}
+			action := b.actionFor(e)
+			if action == nil {
+				continue
+			}
+			select {
+			case b.run <- action:
+			default:
+				log.Printf("ev3dev: dropped button action for %v: action queue full", e.Button)
+			}
+		}
+	}()
+	go func() {
+		defer b.wg.Done()
+		for action := range b.run {
+			err := RunProtected(func() error {
+				action()
+				return nil
+			})
+			if err != nil {
+				log.Printf("ev3dev: button action failed: %v", err)
+			}
+		}
+	}()
+	return b
+}
+
+const (
+	// evKey is the linux input event type for key events.
+	evKey = 1
+
+	// keyPress is the linux input event value for a key press.
+	keyPress = 1
+)
+
+// actionFor returns the action bound to the button of the press event e,
+// or nil if there is no bound action or the press is within the debounce
+// interval.
+func (b *ButtonBinder) actionFor(e ButtonEvent) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	action, ok := b.actions[e.Button]
+	if !ok {
+		return nil
+	}
+	last, ok := b.last[e.Button]
+	if ok && e.TimeStamp-last < b.debounce {
+		return nil
+	}
+	b.last[e.Button] = e.TimeStamp
+	return action
+}
+
+// Bind binds action to presses of the given button, replacing any
+// previously bound action. If action is nil the button is unbound.
+func (b *ButtonBinder) Bind(button Button, action func()) {
+	b.mu.Lock()
+	if action == nil {
+		delete(b.actions, button)
+	} else {
+		b.actions[button] = action
+	}
+	b.mu.Unlock()
+}
+
+// Close stops the ButtonBinder and closes the button event source
+// without waiting for a further button event. Close waits for any
+// queued actions to complete.
+func (b *ButtonBinder) Close() error {
+	err := b.closer.Close()
+	b.wg.Wait()
+	return err
+}
+
 // ButtonEvent is a button event, including the time of the event. The Err
 // value reflects any error state arising from detecting the event.
 type ButtonEvent struct {
@@ -5,6 +5,8 @@
 package ev3dev
 
 import (
+	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -313,3 +315,71 @@ func TestButtonEvents(t *testing.T) {
 		}
 	}
 }
+
+// chanCloser closes a ButtonEvent channel.
+type chanCloser chan ButtonEvent
+
+func (c chanCloser) Close() error {
+	close(c)
+	return nil
+}
+
+func TestButtonBinder(t *testing.T) {
+	events := make(chan ButtonEvent)
+	b := newButtonBinder(events, chanCloser(events), 50*time.Millisecond)
+
+	var got []string
+	b.Bind(Middle, func() { got = append(got, "middle") })
+	b.Bind(Back, func() { got = append(got, "back") })
+	b.Bind(Up, func() { panic("up") })
+	b.Bind(Back, nil)
+
+	for _, e := range []ButtonEvent{
+		{Button: Middle, TimeStamp: 0, Type: evKey, Value: keyPress},
+		{Button: Middle, TimeStamp: 10 * time.Millisecond, Type: evKey, Value: 0},
+		// Bounce.
+		{Button: Middle, TimeStamp: 20 * time.Millisecond, Type: evKey, Value: keyPress},
+		{Button: Back, TimeStamp: 30 * time.Millisecond, Type: evKey, Value: keyPress},
+		// Panicking action does not stop the binder.
+		{Button: Up, TimeStamp: 40 * time.Millisecond, Type: evKey, Value: keyPress},
+		{Button: Middle, TimeStamp: 100 * time.Millisecond, Type: evKey, Value: keyPress},
+		// Unbound button.
+		{Button: Down, TimeStamp: 110 * time.Millisecond, Type: evKey, Value: keyPress},
+		// Auto-repeat is not a press.
+		{Button: Middle, TimeStamp: 200 * time.Millisecond, Type: evKey, Value: 2},
+	} {
+		events <- e
+	}
+	err := b.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing binder: %v", err)
+	}
+
+	want := []string{"middle", "middle"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected actions: got:%v want:%v", got, want)
+	}
+}
+
+func TestButtonBinderClose(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer w.Close()
+	waiter := newButtonWaiter(r)
+	b := newButtonBinder(waiter.Events, waiter, 0)
+
+	// No button events are written, so the waiter
+	// is blocked reading when Close is called.
+	closed := make(chan error)
+	go func() { closed <- b.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Errorf("unexpected error closing binder: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked waiting for a button event")
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package calibration provides a versioned file format for persisting
+// robot calibration data.
+//
+// Calibration data is stored as a single JSON document holding a version
+// number. Documents written by earlier versions of the package are
+// migrated to the current version when they are loaded.
+package calibration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Version is the current calibration file format version.
+const Version = 1
+
+// File is the calibration data for a robot. Maps of per-device data
+// are keyed by the device's LEGO port address, for example "ev3-ports:in2".
+type File struct {
+	// Version is the format version of
+	// the calibration data. It is set to
+	// Version by Encode and Save.
+	Version int `json:"version"`
+
+	// Gyro holds gyro sensor calibrations.
+	Gyro map[string]Gyro `json:"gyro,omitempty"`
+
+	// Color holds color sensor calibrations.
+	Color map[string]Color `json:"color,omitempty"`
+
+	// Wheels holds the drive wheel geometry.
+	Wheels *Wheels `json:"wheels,omitempty"`
+
+	// PID holds named PID controller profiles.
+	PID map[string]PID `json:"pid,omitempty"`
+}
+
+// Gyro is a gyro sensor calibration.
+type Gyro struct {
+	// Offset is the rate reported by the
+	// sensor at rest in degrees per second.
+	Offset float64 `json:"offset"`
+
+	// Drift is the change in reported angle
+	// at rest in degrees per second.
+	Drift float64 `json:"drift,omitempty"`
+}
+
+// Color is a color sensor calibration.
+type Color struct {
+	// Black and White are the raw readings
+	// for black and white reference surfaces.
+	Black RGB `json:"black"`
+	White RGB `json:"white"`
+
+	// BlackReflect and WhiteReflect are the
+	// reflected light intensity readings for
+	// black and white reference surfaces.
+	BlackReflect float64 `json:"black_reflect,omitempty"`
+	WhiteReflect float64 `json:"white_reflect,omitempty"`
+
+	// References holds raw readings for
+	// named reference colors.
+	References map[string]RGB `json:"references,omitempty"`
+}
+
+// RGB is a raw color sensor reading.
+type RGB struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// Wheels is a drive wheel geometry.
+type Wheels struct {
+	// Diameter is the wheel diameter in mm.
+	Diameter float64 `json:"diameter"`
+
+	// AxleTrack is the distance between the
+	// wheel contact points in mm.
+	AxleTrack float64 `json:"axle_track"`
+}
+
+// PID is a PID controller profile.
+type PID struct {
+	Kp float64 `json:"kp"`
+	Ki float64 `json:"ki"`
+	Kd float64 `json:"kd"`
+}
+
+// migrations holds the functions that migrate raw calibration documents
+// between versions. The function at index i migrates a document from
+// version i+1 to version i+2 and must update the version field. When
+// the format changes, Version is incremented and a migration is added.
+var migrations []func(doc map[string]json.RawMessage) error
+
+// Decode reads a calibration document from r, migrating it to the current
+// version if necessary. Decode returns an error if the document has no
+// version or has a version newer than Version.
+func Decode(r io.Reader) (*File, error) {
+	return decode(r, Version, migrations)
+}
+
+// decode decodes a calibration document, migrating it to the current
+// version using the provided migrations.
+func decode(r io.Reader, current int, migrations []func(map[string]json.RawMessage) error) (*File, error) {
+	var doc map[string]json.RawMessage
+	err := json.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("calibration: failed to decode: %w", err)
+	}
+	for {
+		v, err := versionOf(doc)
+		if err != nil {
+			return nil, err
+		}
+		if v == current {
+			break
+		}
+		if v < 1 || current < v || len(migrations) < v {
+			return nil, fmt.Errorf("calibration: unsupported version: %d (must be in 1-%d)", v, current)
+		}
+		err = migrations[v-1](doc)
+		if err != nil {
+			return nil, fmt.Errorf("calibration: failed to migrate from version %d: %w", v, err)
+		}
+		if next, err := versionOf(doc); err != nil || next != v+1 {
+			return nil, fmt.Errorf("calibration: migration from version %d did not update version", v)
+		}
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	err = json.Unmarshal(b, &f)
+	f.Version = current
+	if err != nil {
+		return nil, fmt.Errorf("calibration: failed to decode: %w", err)
+	}
+	return &f, nil
+}
+
+// versionOf returns the version of the raw document.
+func versionOf(doc map[string]json.RawMessage) (int, error) {
+	raw, ok := doc["version"]
+	if !ok {
+		return 0, errors.New("calibration: missing version")
+	}
+	var v int
+	err := json.Unmarshal(raw, &v)
+	if err != nil {
+		return 0, fmt.Errorf("calibration: invalid version: %w", err)
+	}
+	return v, nil
+}
+
+// Encode writes f to w as an indented JSON document with the current
+// version.
+func Encode(w io.Writer, f *File) error {
+	c := *f
+	c.Version = Version
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(c)
+}
+
+// Load reads the calibration file at path.
+func Load(path string) (*File, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return Decode(r)
+}
+
+// Save writes f to the file at path. The file is replaced atomically so
+// an interrupted Save does not leave a corrupted calibration file.
+func Save(path string, f *File) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	err = tmp.Chmod(0644)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = Encode(tmp, f)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,133 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package calibration
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "calibration")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	want := &File{
+		Gyro: map[string]Gyro{"ev3-ports:in2": {Offset: -0.5, Drift: 0.01}},
+		Color: map[string]Color{"ev3-ports:in3": {
+			Black:        RGB{R: 10, G: 12, B: 9},
+			White:        RGB{R: 280, G: 300, B: 250},
+			BlackReflect: 6,
+			WhiteReflect: 88,
+			References:   map[string]RGB{"red": {R: 200, G: 30, B: 20}},
+		}},
+		Wheels: &Wheels{Diameter: 56, AxleTrack: 114},
+		PID:    map[string]PID{"line": {Kp: 1.2, Ki: 0.01, Kd: 4}},
+	}
+	path := filepath.Join(dir, "calibration.json")
+	err = Save(path, want)
+	if err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	if want.Version != 0 {
+		t.Errorf("unexpected mutation of saved file version: %d", want.Version)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	want.Version = Version
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected round trip result:\ngot: %+v\nwant:%+v", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		doc  string
+		want string
+	}{
+		{doc: `{"gyro":{}}`, want: "missing version"},
+		{doc: `{"version":"one"}`, want: "invalid version"},
+		{doc: `{"version":0}`, want: "unsupported version"},
+		{doc: `{"version":99}`, want: "unsupported version"},
+		{doc: `{"version":1`, want: "failed to decode"},
+		{doc: `{"version":1,"wheels":[]}`, want: "failed to decode"},
+	} {
+		_, err := Decode(strings.NewReader(test.doc))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("unexpected error for %s: got:%v want:%q", test.doc, err, test.want)
+		}
+	}
+}
+
+func TestMigration(t *testing.T) {
+	// Simulate format changes where version 1 stored
+	// wheel geometry as a top-level diameter field and
+	// version 2 stored it in mm rather than cm.
+	migrations := []func(map[string]json.RawMessage) error{
+		func(doc map[string]json.RawMessage) error {
+			var d float64
+			err := json.Unmarshal(doc["wheel_diameter"], &d)
+			if err != nil {
+				return err
+			}
+			delete(doc, "wheel_diameter")
+			doc["wheels"], _ = json.Marshal(Wheels{Diameter: d})
+			doc["version"] = json.RawMessage("2")
+			return nil
+		},
+		func(doc map[string]json.RawMessage) error {
+			var w Wheels
+			err := json.Unmarshal(doc["wheels"], &w)
+			if err != nil {
+				return err
+			}
+			w.Diameter *= 10
+			doc["wheels"], _ = json.Marshal(w)
+			doc["version"] = json.RawMessage("3")
+			return nil
+		},
+	}
+
+	for _, test := range []struct {
+		doc  string
+		want float64
+	}{
+		{doc: `{"version":1,"wheel_diameter":5.6}`, want: 56},
+		{doc: `{"version":2,"wheels":{"diameter":4.32}}`, want: 43.2},
+		{doc: `{"version":3,"wheels":{"diameter":81.6}}`, want: 81.6},
+	} {
+		f, err := decode(strings.NewReader(test.doc), 3, migrations)
+		if err != nil {
+			t.Errorf("unexpected error decoding %s: %v", test.doc, err)
+			continue
+		}
+		if f.Version != 3 {
+			t.Errorf("unexpected version for %s: got:%d want:3", test.doc, f.Version)
+		}
+		if f.Wheels == nil || f.Wheels.Diameter != test.want {
+			t.Errorf("unexpected wheels for %s: got:%+v want diameter:%v", test.doc, f.Wheels, test.want)
+		}
+	}
+
+	_, err := decode(strings.NewReader(`{"version":1,"wheel_diameter":"big"}`), 3, migrations)
+	if err == nil || !strings.Contains(err.Error(), "failed to migrate from version 1") {
+		t.Errorf("unexpected error for failed migration: %v", err)
+	}
+
+	broken := append(migrations[:1:1], func(map[string]json.RawMessage) error { return nil })
+	_, err = decode(strings.NewReader(`{"version":2,"wheels":{}}`), 3, broken)
+	if err == nil || !strings.Contains(err.Error(), "did not update version") {
+		t.Errorf("unexpected error for migration without version update: %v", err)
+	}
+}
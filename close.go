@@ -0,0 +1,180 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// closeHook is a function to be called when a handle is closed.
+// Hooks are held by pointer so that they can be identified for removal.
+type closeHook struct {
+	fn func()
+}
+
+// hookLock guards the close hooks of all handles. Hooks
+// may be added and removed by goroutines other than the
+// goroutine using the handle, for example by Watch.
+var hookLock sync.Mutex
+
+// addCloseHook adds fn to hooks, returning a function that removes it.
+func addCloseHook(hooks *[]*closeHook, fn func()) (remove func()) {
+	h := &closeHook{fn: fn}
+	hookLock.Lock()
+	*hooks = append(*hooks, h)
+	hookLock.Unlock()
+	return func() {
+		hookLock.Lock()
+		defer hookLock.Unlock()
+		for i, e := range *hooks {
+			if e == h {
+				// Copy so that a slice held by a
+				// running close is not altered.
+				rest := make([]*closeHook, 0, len(*hooks)-1)
+				rest = append(rest, (*hooks)[:i]...)
+				*hooks = append(rest, (*hooks)[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// runCloseHooks removes the hooks in hooks and calls
+// them in reverse order.
+func runCloseHooks(hooks *[]*closeHook) {
+	hookLock.Lock()
+	fns := *hooks
+	*hooks = nil
+	hookLock.Unlock()
+	for i := len(fns) - 1; i >= 0; i-- {
+		fns[i].fn()
+	}
+}
+
+// OnClose registers fn to be called when the TachoMotor is closed.
+// Functions are called in the reverse order of registration, so that
+// later wrappers are torn down before the wrappers they depend on.
+// OnClose is intended to allow higher level types to release background
+// resources, such as goroutines, that use the TachoMotor.
+func (m *TachoMotor) OnClose(fn func()) {
+	addCloseHook(&m.onClose, fn)
+}
+
+// Close releases the TachoMotor, calling the functions registered with
+// OnClose. If the TachoMotor was claimed by FindAfter, the claim is
+// released so that the motor can be found again, and the attribute files
+// held open for the motor are closed. The TachoMotor should not be used
+// after it has been closed. Calling Close more than once has no further
+// effect unless more functions have been registered.
+func (m *TachoMotor) Close() error {
+	runCloseHooks(&m.onClose)
+	release(m)
+	return nil
+}
+
+// OnClose registers fn to be called when the DCMotor is closed.
+// See TachoMotor.OnClose for details.
+func (m *DCMotor) OnClose(fn func()) {
+	addCloseHook(&m.onClose, fn)
+}
+
+// Close releases the DCMotor, calling the functions registered with
+// OnClose. See TachoMotor.Close for details.
+func (m *DCMotor) Close() error {
+	runCloseHooks(&m.onClose)
+	release(m)
+	return nil
+}
+
+// OnClose registers fn to be called when the ServoMotor is closed.
+// See TachoMotor.OnClose for details.
+func (m *ServoMotor) OnClose(fn func()) {
+	addCloseHook(&m.onClose, fn)
+}
+
+// Close releases the ServoMotor, calling the functions registered with
+// OnClose. See TachoMotor.Close for details.
+func (m *ServoMotor) Close() error {
+	runCloseHooks(&m.onClose)
+	release(m)
+	return nil
+}
+
+// OnClose registers fn to be called when the LinearActuator is closed.
+// See TachoMotor.OnClose for details.
+func (m *LinearActuator) OnClose(fn func()) {
+	addCloseHook(&m.onClose, fn)
+}
+
+// Close releases the LinearActuator, calling the functions registered
+// with OnClose. See TachoMotor.Close for details.
+func (m *LinearActuator) Close() error {
+	runCloseHooks(&m.onClose)
+	release(m)
+	return nil
+}
+
+// OnClose registers fn to be called when the Sensor is closed.
+// See TachoMotor.OnClose for details.
+func (s *Sensor) OnClose(fn func()) {
+	addCloseHook(&s.onClose, fn)
+}
+
+// Close releases the Sensor, calling the functions registered with
+// OnClose. See TachoMotor.Close for details.
+func (s *Sensor) Close() error {
+	runCloseHooks(&s.onClose)
+	release(s)
+	return nil
+}
+
+// OnClose registers fn to be called when the LegoPort is closed.
+// See TachoMotor.OnClose for details.
+func (p *LegoPort) OnClose(fn func()) {
+	addCloseHook(&p.onClose, fn)
+}
+
+// Close releases the LegoPort, calling the functions registered with
+// OnClose. See TachoMotor.Close for details.
+func (p *LegoPort) Close() error {
+	runCloseHooks(&p.onClose)
+	release(p)
+	return nil
+}
+
+// release removes d from the registry of claimed devices and closes
+// the attribute files held open for the device d refers to.
+func release(d Device) {
+	resLock.Lock()
+	for _, claimed := range resources {
+		for addr, c := range claimed {
+			if c == d {
+				delete(claimed, addr)
+			}
+		}
+	}
+	resLock.Unlock()
+
+	if d.(idSetter).idInt() < 0 {
+		// The device was not found.
+		return
+	}
+	forgetName(d)
+	UnwatchMotor(d)
+	dir := filepath.Join(d.Path(), d.String()) + string(filepath.Separator)
+	fileRegLock.Lock()
+	defer fileRegLock.Unlock()
+	for path, f := range files {
+		if !strings.HasPrefix(path, dir) {
+			continue
+		}
+		if f != nil {
+			f.Close()
+		}
+		delete(files, path)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnClose(t *testing.T) {
+	type onCloser interface {
+		io.Closer
+		OnClose(func())
+	}
+	for _, d := range []onCloser{
+		&TachoMotor{},
+		&DCMotor{},
+		&ServoMotor{},
+		&LinearActuator{},
+		&Sensor{},
+		&LegoPort{},
+	} {
+		var got []int
+		d.OnClose(func() { got = append(got, 1) })
+		d.OnClose(func() { got = append(got, 2) })
+		err := d.Close()
+		if err != nil {
+			t.Errorf("unexpected error closing %T: %v", d, err)
+		}
+		err = d.Close()
+		if err != nil {
+			t.Errorf("unexpected error closing %T again: %v", d, err)
+		}
+		if want := []int{2, 1}; !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected close hook calls for %T: got:%v want:%v", d, got, want)
+		}
+	}
+}
+
+func TestCloseStopsWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, TachoMotorPath, "motor0", state)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	err = ioutil.WriteFile(path, []byte(running+"\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	c := m.Watch(context.Background(), time.Millisecond)
+	<-c
+	m.Close()
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Errorf("unexpected state after close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for channel close")
+	}
+}
+
+func TestOnCloseConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {state: running},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	var wg sync.WaitGroup
+	var calls int32
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.OnClose(func() { atomic.AddInt32(&calls, 1) })
+		}()
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			c := m.Watch(ctx, time.Millisecond)
+			<-c
+			cancel()
+			for range c {
+			}
+		}()
+	}
+	wg.Wait()
+	m.Close()
+	if calls != 10 {
+		t.Errorf("unexpected number of close hook calls: got:%d want:10", calls)
+	}
+}
+
+func TestCloseReleases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	resLock.Lock()
+	saved := resources["out"]
+	resources["out"] = make(map[string]Device)
+	resLock.Unlock()
+	defer func() {
+		resLock.Lock()
+		resources["out"] = saved
+		resLock.Unlock()
+	}()
+
+	var m TachoMotor
+	err = FindAfter(nil, &m, "lego-ev3-l-motor")
+	if err != nil {
+		t.Fatalf("unexpected error finding motor: %v", err)
+	}
+	// The motor is claimed.
+	var other TachoMotor
+	err = FindAfter(nil, &other, "lego-ev3-l-motor")
+	if err == nil {
+		t.Fatal("expected error finding claimed motor")
+	}
+
+	path := filepath.Join(m.Path(), m.String(), state)
+	f, err := fileFor(path)
+	if err != nil {
+		t.Fatalf("failed to open attribute file: %v", err)
+	}
+	defer func() {
+		fileRegLock.Lock()
+		delete(files, path)
+		fileRegLock.Unlock()
+	}()
+
+	err = m.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+	fileRegLock.Lock()
+	_, cached := files[path]
+	fileRegLock.Unlock()
+	if cached {
+		t.Error("attribute file not evicted on close")
+	}
+	if _, err := f.Stat(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("attribute file not closed: %v", err)
+	}
+
+	err = FindAfter(nil, &other, "lego-ev3-l-motor")
+	if err != nil {
+		t.Errorf("unexpected error finding released motor: %v", err)
+	}
+	resLock.Lock()
+	claimed := resources["out"]["ev3-ports:outA"]
+	resLock.Unlock()
+	if claimed != &other {
+		t.Errorf("unexpected claim after release: got:%v want:%v", claimed, &other)
+	}
+}
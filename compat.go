@@ -0,0 +1,154 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+var (
+	// osReleasePath and kernelReleasePath are the paths to
+	// the operating system and kernel release information.
+	// They are altered during testing.
+	osReleasePath     = "/etc/os-release"
+	kernelReleasePath = "/proc/sys/kernel/osrelease"
+)
+
+// testedImages holds the Debian release code names and ev3dev
+// kernel series that the ev3dev package has been tested with.
+var testedImages = []struct {
+	codename string
+	kernel   string
+}{
+	// Tested on kernel 4.14.61-ev3dev-2.2.2-ev3.
+	{codename: "stretch", kernel: "4.14"},
+}
+
+// CheckImageCompatibility reads the operating system release information
+// from /etc/os-release and the running kernel's release and compares them
+// against the ev3dev images the ev3dev package has been tested with. It
+// returns a warning for each untested or known problematic aspect of the
+// running image. No warnings are returned for a tested image.
+//
+// CheckImageCompatibility is intended to be called at program start so
+// that users are alerted to likely causes of unexpected behaviour before
+// they occur.
+func CheckImageCompatibility() ([]string, error) {
+	b, err := ioutil.ReadFile(osReleasePath)
+	if err != nil {
+		return nil, err
+	}
+	codename := osReleaseCodename(parseOSRelease(string(b)))
+	b, err = ioutil.ReadFile(kernelReleasePath)
+	if err != nil {
+		return nil, err
+	}
+	return imageWarnings(codename, strings.TrimSpace(string(b))), nil
+}
+
+// imageWarnings returns the compatibility warnings for the
+// given Debian release code name and kernel release.
+func imageWarnings(codename, kernel string) []string {
+	var warnings []string
+	switch {
+	case codename == "jessie":
+		warnings = append(warnings, "ev3dev jessie is not supported: use the ev3dev-jessie branch of github.com/ev3go/ev3dev")
+	case codename == "":
+		warnings = append(warnings, "unknown operating system release")
+	}
+
+	series, ok := kernelSeries(kernel)
+	if !strings.Contains(kernel, "-ev3dev") {
+		// The package tests emulate the ev3dev sysfs tree using
+		// FUSE, where the fast attribute read path has been seen
+		// to hang the kernel, so warn about that here since a
+		// non-ev3dev kernel is most likely a development host.
+		warnings = append(warnings, fmt.Sprintf("kernel %q is not an ev3dev kernel: device drivers will be missing, and sysfs trees emulated with FUSE are known to hang on the fast attribute read path", kernel))
+	} else if !ok {
+		warnings = append(warnings, fmt.Sprintf("unrecognized kernel release %q", kernel))
+	}
+
+	if codename == "jessie" || codename == "" || !ok {
+		return warnings
+	}
+	var tested, kernelTested bool
+	for _, img := range testedImages {
+		if img.kernel == series {
+			kernelTested = true
+			if img.codename == codename {
+				tested = true
+			}
+		}
+	}
+	if !tested {
+		switch {
+		case kernelTested:
+			warnings = append(warnings, fmt.Sprintf("untested operating system release %q", codename))
+		default:
+			warnings = append(warnings, fmt.Sprintf("untested combination of operating system release %q and kernel %s", codename, series))
+		}
+	}
+	return warnings
+}
+
+// parseOSRelease returns the variables defined in an os-release file.
+func parseOSRelease(data string) map[string]string {
+	vars := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		v := kv[1]
+		if uq, err := strconv.Unquote(v); err == nil && v[0] == '"' {
+			v = uq
+		} else if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			v = v[1 : len(v)-1]
+		}
+		vars[kv[0]] = v
+	}
+	return vars
+}
+
+// osReleaseCodename returns the release code name described by
+// the os-release variables in vars. Older releases do not define
+// VERSION_CODENAME, so the code name is taken from the
+// parenthesized suffix of VERSION if it is missing.
+func osReleaseCodename(vars map[string]string) string {
+	if c := vars["VERSION_CODENAME"]; c != "" {
+		return c
+	}
+	v := vars["VERSION"]
+	open := strings.LastIndex(v, "(")
+	if open < 0 || !strings.HasSuffix(v, ")") {
+		return ""
+	}
+	return v[open+1 : len(v)-1]
+}
+
+// kernelSeries returns the major.minor series of the kernel release.
+func kernelSeries(release string) (string, bool) {
+	f := strings.SplitN(release, ".", 3)
+	if len(f) < 2 {
+		return "", false
+	}
+	minor := f[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || '9' < r }); i >= 0 {
+		minor = minor[:i]
+	}
+	_, errMajor := strconv.Atoi(f[0])
+	_, errMinor := strconv.Atoi(minor)
+	if errMajor != nil || errMinor != nil {
+		return "", false
+	}
+	return f[0] + "." + minor, true
+}
@@ -0,0 +1,123 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const stretchOSRelease = `PRETTY_NAME="Debian GNU/Linux 9 (stretch)"
+NAME="Debian GNU/Linux"
+VERSION_ID="9"
+VERSION="9 (stretch)"
+VERSION_CODENAME=stretch
+ID=debian
+HOME_URL="https://www.debian.org/"
+`
+
+const jessieOSRelease = `PRETTY_NAME="Debian GNU/Linux 8 (jessie)"
+NAME="Debian GNU/Linux"
+VERSION_ID="8"
+VERSION="8 (jessie)"
+ID=debian
+`
+
+var checkImageCompatibilityTests = []struct {
+	name      string
+	osRelease string
+	kernel    string
+	want      []string
+}{
+	{
+		name:      "tested",
+		osRelease: stretchOSRelease,
+		kernel:    "4.14.61-ev3dev-2.2.2-ev3\n",
+		want:      nil,
+	},
+	{
+		name:      "jessie",
+		osRelease: jessieOSRelease,
+		kernel:    "4.4.87-22-ev3dev-ev3\n",
+		want:      []string{"ev3dev jessie is not supported: use the ev3dev-jessie branch of github.com/ev3go/ev3dev"},
+	},
+	{
+		name:      "untested kernel",
+		osRelease: stretchOSRelease,
+		kernel:    "4.19.25-ev3dev-2.3.2-ev3\n",
+		want:      []string{`untested combination of operating system release "stretch" and kernel 4.19`},
+	},
+	{
+		name:      "untested release",
+		osRelease: strings.Replace(stretchOSRelease, "VERSION_CODENAME=stretch", "VERSION_CODENAME=buster", 1),
+		kernel:    "4.14.117-ev3dev-2.3.5-ev3\n",
+		want:      []string{`untested operating system release "buster"`},
+	},
+	{
+		name:      "development host",
+		osRelease: stretchOSRelease,
+		kernel:    "6.1.0-18-amd64\n",
+		want: []string{
+			`kernel "6.1.0-18-amd64" is not an ev3dev kernel: device drivers will be missing, and sysfs trees emulated with FUSE are known to hang on the fast attribute read path`,
+			`untested combination of operating system release "stretch" and kernel 6.1`,
+		},
+	},
+	{
+		name:      "unknown",
+		osRelease: "NAME=Linux\n",
+		kernel:    "ev3dev\n",
+		want: []string{
+			"unknown operating system release",
+			`kernel "ev3dev" is not an ev3dev kernel: device drivers will be missing, and sysfs trees emulated with FUSE are known to hang on the fast attribute read path`,
+		},
+	},
+}
+
+func TestCheckImageCompatibility(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(o, k string) { osReleasePath, kernelReleasePath = o, k }(osReleasePath, kernelReleasePath)
+	osReleasePath = filepath.Join(dir, "os-release")
+	kernelReleasePath = filepath.Join(dir, "osrelease")
+
+	_, err = CheckImageCompatibility()
+	if err == nil {
+		t.Error("expected error for missing os-release")
+	}
+
+	for _, test := range checkImageCompatibilityTests {
+		err = ioutil.WriteFile(osReleasePath, []byte(test.osRelease), 0644)
+		if err != nil {
+			t.Fatalf("failed to write os-release: %v", err)
+		}
+		err = ioutil.WriteFile(kernelReleasePath, []byte(test.kernel), 0644)
+		if err != nil {
+			t.Fatalf("failed to write kernel release: %v", err)
+		}
+		got, err := CheckImageCompatibility()
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected warnings for %s:\ngot: %q\nwant:%q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestParseOSRelease(t *testing.T) {
+	got := parseOSRelease("# comment\nA=plain\nB=\"double \\\"quoted\\\"\"\nC='single'\n\nbad line\nD=\n")
+	want := map[string]string{"A": "plain", "B": `double "quoted"`, "C": "single", "D": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected os-release variables: got:%q want:%q", got, want)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// MotorConfig is the persistent configuration of a motor as written by
+// SaveConfig and read by LoadConfig. Fields that are not supported by a
+// motor type are nil. When a configuration is loaded, only the non-nil
+// fields are applied.
+type MotorConfig struct {
+	Polarity   *Polarity   `json:"polarity,omitempty"`
+	StopAction *StopAction `json:"stop_action,omitempty"`
+
+	// RampUpSetpoint and RampDownSetpoint
+	// are encoded as nanoseconds.
+	RampUpSetpoint   *time.Duration `json:"ramp_up_sp,omitempty"`
+	RampDownSetpoint *time.Duration `json:"ramp_down_sp,omitempty"`
+
+	HoldPID  *PID `json:"hold_pid,omitempty"`
+	SpeedPID *PID `json:"speed_pid,omitempty"`
+}
+
+// PID holds the constants of a motor's PID controller.
+type PID struct {
+	Kp int `json:"kp"`
+	Ki int `json:"ki"`
+	Kd int `json:"kd"`
+}
+
+// SaveConfig writes the polarity, stop action, ramp setpoints and PID
+// constants of the TachoMotor to w as JSON.
+func (m *TachoMotor) SaveConfig(w io.Writer) error {
+	var (
+		c   MotorConfig
+		err error
+	)
+	c.Polarity, c.StopAction, err = polarityAndStopActionOf(m)
+	if err != nil {
+		return err
+	}
+	c.RampUpSetpoint, c.RampDownSetpoint, err = rampsOf(m)
+	if err != nil {
+		return err
+	}
+	holdPID, err := m.HoldPID()
+	if err != nil {
+		return err
+	}
+	c.HoldPID = &holdPID
+	speedPID, err := m.SpeedPID()
+	if err != nil {
+		return err
+	}
+	c.SpeedPID = &speedPID
+	return writeConfig(w, c)
+}
+
+// LoadConfig reads a JSON configuration written by SaveConfig from r and
+// applies it to the TachoMotor.
+func (m *TachoMotor) LoadConfig(r io.Reader) error {
+	c, err := readConfig(r)
+	if err != nil {
+		return err
+	}
+	if c.Polarity != nil {
+		m.SetPolarity(*c.Polarity)
+	}
+	if c.StopAction != nil {
+		m.SetStopAction(*c.StopAction)
+	}
+	if c.RampUpSetpoint != nil {
+		m.SetRampUpSetpoint(*c.RampUpSetpoint)
+	}
+	if c.RampDownSetpoint != nil {
+		m.SetRampDownSetpoint(*c.RampDownSetpoint)
+	}
+	if c.HoldPID != nil {
+		m.SetHoldPID(*c.HoldPID)
+	}
+	if c.SpeedPID != nil {
+		m.SetSpeedPID(*c.SpeedPID)
+	}
+	return m.Err()
+}
+
+// SaveConfig writes the polarity, stop action, ramp setpoints and PID
+// constants of the LinearActuator to w as JSON.
+func (m *LinearActuator) SaveConfig(w io.Writer) error {
+	var (
+		c   MotorConfig
+		err error
+	)
+	c.Polarity, c.StopAction, err = polarityAndStopActionOf(m)
+	if err != nil {
+		return err
+	}
+	c.RampUpSetpoint, c.RampDownSetpoint, err = rampsOf(m)
+	if err != nil {
+		return err
+	}
+	holdPID, err := m.HoldPID()
+	if err != nil {
+		return err
+	}
+	c.HoldPID = &holdPID
+	speedPID, err := m.SpeedPID()
+	if err != nil {
+		return err
+	}
+	c.SpeedPID = &speedPID
+	return writeConfig(w, c)
+}
+
+// LoadConfig reads a JSON configuration written by SaveConfig from r and
+// applies it to the LinearActuator.
+func (m *LinearActuator) LoadConfig(r io.Reader) error {
+	c, err := readConfig(r)
+	if err != nil {
+		return err
+	}
+	if c.Polarity != nil {
+		m.SetPolarity(*c.Polarity)
+	}
+	if c.StopAction != nil {
+		m.SetStopAction(*c.StopAction)
+	}
+	if c.RampUpSetpoint != nil {
+		m.SetRampUpSetpoint(*c.RampUpSetpoint)
+	}
+	if c.RampDownSetpoint != nil {
+		m.SetRampDownSetpoint(*c.RampDownSetpoint)
+	}
+	if c.HoldPID != nil {
+		m.SetHoldPID(*c.HoldPID)
+	}
+	if c.SpeedPID != nil {
+		m.SetSpeedPID(*c.SpeedPID)
+	}
+	return m.Err()
+}
+
+// SaveConfig writes the polarity, stop action and ramp setpoints of the
+// DCMotor to w as JSON.
+func (m *DCMotor) SaveConfig(w io.Writer) error {
+	var (
+		c   MotorConfig
+		err error
+	)
+	c.Polarity, c.StopAction, err = polarityAndStopActionOf(m)
+	if err != nil {
+		return err
+	}
+	c.RampUpSetpoint, c.RampDownSetpoint, err = rampsOf(m)
+	if err != nil {
+		return err
+	}
+	return writeConfig(w, c)
+}
+
+// LoadConfig reads a JSON configuration written by SaveConfig from r and
+// applies it to the DCMotor. It is an error for the configuration to hold
+// PID constants.
+func (m *DCMotor) LoadConfig(r io.Reader) error {
+	c, err := readConfig(r)
+	if err != nil {
+		return err
+	}
+	if c.HoldPID != nil || c.SpeedPID != nil {
+		return fmt.Errorf("ev3dev: PID configuration not supported by %s", deviceName(m))
+	}
+	if c.Polarity != nil {
+		m.SetPolarity(*c.Polarity)
+	}
+	if c.StopAction != nil {
+		m.SetStopAction(*c.StopAction)
+	}
+	if c.RampUpSetpoint != nil {
+		m.SetRampUpSetpoint(*c.RampUpSetpoint)
+	}
+	if c.RampDownSetpoint != nil {
+		m.SetRampDownSetpoint(*c.RampDownSetpoint)
+	}
+	return m.Err()
+}
+
+// SaveConfig writes the polarity of the ServoMotor to w as JSON.
+func (m *ServoMotor) SaveConfig(w io.Writer) error {
+	p, err := m.Polarity()
+	if err != nil {
+		return err
+	}
+	return writeConfig(w, MotorConfig{Polarity: &p})
+}
+
+// LoadConfig reads a JSON configuration written by SaveConfig from r and
+// applies it to the ServoMotor. It is an error for the configuration to
+// hold fields other than the polarity.
+func (m *ServoMotor) LoadConfig(r io.Reader) error {
+	c, err := readConfig(r)
+	if err != nil {
+		return err
+	}
+	if c != (MotorConfig{Polarity: c.Polarity}) {
+		return fmt.Errorf("ev3dev: configuration other than polarity not supported by %s", deviceName(m))
+	}
+	if c.Polarity != nil {
+		m.SetPolarity(*c.Polarity)
+	}
+	return m.Err()
+}
+
+// polarityAndStopActionOf returns the polarity and stop action of d.
+func polarityAndStopActionOf(d Device) (*Polarity, *StopAction, error) {
+	p, err := stringFrom(attributeOf(d, polarity))
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := stringFrom(attributeOf(d, stopAction))
+	if err != nil {
+		return nil, nil, err
+	}
+	pol := Polarity(p)
+	action := StopAction(a)
+	return &pol, &action, nil
+}
+
+// rampsOf returns the ramp up and ramp down setpoints of d.
+func rampsOf(d Device) (up, down *time.Duration, err error) {
+	u, err := durationFrom(attributeOf(d, rampUpSetpoint))
+	if err != nil {
+		return nil, nil, err
+	}
+	dn, err := durationFrom(attributeOf(d, rampDownSetpoint))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &u, &dn, nil
+}
+
+// pidOf returns the PID constants of d held in the attributes kp, ki and kd.
+func pidOf(d Device, kp, ki, kd string) (PID, error) {
+	var (
+		pid PID
+		err error
+	)
+	pid.Kp, err = intFrom(attributeOf(d, kp))
+	if err != nil {
+		return PID{}, err
+	}
+	pid.Ki, err = intFrom(attributeOf(d, ki))
+	if err != nil {
+		return PID{}, err
+	}
+	pid.Kd, err = intFrom(attributeOf(d, kd))
+	if err != nil {
+		return PID{}, err
+	}
+	return pid, nil
+}
+
+// writeConfig writes c to w as indented JSON.
+func writeConfig(w io.Writer, c MotorConfig) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(c)
+}
+
+// readConfig reads a JSON MotorConfig from r. Unknown fields are an error.
+func readConfig(r io.Reader) (MotorConfig, error) {
+	var c MotorConfig
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&c)
+	if err != nil {
+		return MotorConfig{}, fmt.Errorf("ev3dev: invalid motor configuration: %v", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,171 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTachoMotorConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	tuned := map[string]string{
+		polarity:         "inversed",
+		stopAction:       "hold",
+		rampUpSetpoint:   "200",
+		rampDownSetpoint: "300",
+		holdPIDkp:        "4000",
+		holdPIDki:        "0",
+		holdPIDkd:        "10",
+		speedPIDkp:       "1000",
+		speedPIDki:       "60",
+		speedPIDkd:       "5",
+	}
+	src := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	dst := tachoMotorAttrs("ev3-ports:outB", "lego-ev3-l-motor")
+	for attr, data := range tuned {
+		src[attr] = data
+		dst[attr] = "0"
+	}
+	dst[polarity] = "normal"
+	dst[stopAction] = "coast"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): src,
+		filepath.Join(TachoMotorPath, "motor1"): dst,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	var (
+		m0, m1 TachoMotor
+		buf    bytes.Buffer
+	)
+	if err := m0.setID(0); err != nil {
+		t.Fatalf("failed to set id: %v", err)
+	}
+	if err := m1.setID(1); err != nil {
+		t.Fatalf("failed to set id: %v", err)
+	}
+	err = m0.SaveConfig(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error saving config: %v", err)
+	}
+	err = m1.LoadConfig(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	for attr, want := range tuned {
+		b, err := ioutil.ReadFile(filepath.Join(dir, TachoMotorPath, "motor1", attr))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", attr, err)
+			continue
+		}
+		if got := strings.TrimSpace(string(b)); got != want {
+			t.Errorf("unexpected value for %s: got:%q want:%q", attr, got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		config string
+		want   string
+	}{
+		{config: `{"stop_action":"brake","spin":true}`, want: "unknown field"},
+		{config: `{"stop_action":"fly"}`, want: "invalid value"},
+		{config: `{"ramp_up_sp":-1}`, want: "must be positive"},
+	} {
+		err := m1.LoadConfig(strings.NewReader(test.config))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("unexpected error for %s: got:%v want:%s", test.config, err, test.want)
+		}
+	}
+}
+
+func TestServoMotorConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(ServoMotorPath, "motor0"): {polarity: "inversed"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &ServoMotor{id: 0}
+	var buf bytes.Buffer
+	err = m.SaveConfig(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error saving config: %v", err)
+	}
+	if got, want := strings.Join(strings.Fields(buf.String()), ""), `{"polarity":"inversed"}`; got != want {
+		t.Errorf("unexpected config: got:%s want:%s", got, want)
+	}
+	err = m.LoadConfig(strings.NewReader(`{"polarity":"normal","stop_action":"brake"}`))
+	if err == nil {
+		t.Error("expected error loading unsupported configuration")
+	}
+	err = m.LoadConfig(strings.NewReader(`{"polarity":"normal"}`))
+	if err != nil {
+		t.Errorf("unexpected error loading config: %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, ServoMotorPath, "motor0", polarity))
+	if err != nil {
+		t.Fatalf("failed to read polarity: %v", err)
+	}
+	if got := strings.TrimSpace(string(b)); got != "normal" {
+		t.Errorf("unexpected polarity: got:%q want:normal", got)
+	}
+}
+
+func TestTachoMotorPID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	for _, attr := range []string{holdPIDkp, holdPIDki, holdPIDkd, speedPIDkp, speedPIDki, speedPIDkd} {
+		attrs[attr] = "0"
+	}
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): attrs,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	hold := PID{Kp: 4000, Ki: 0, Kd: 10}
+	speed := PID{Kp: 1000, Ki: 60, Kd: 5}
+	err = m.SetHoldPID(hold).SetSpeedPID(speed).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := m.HoldPID()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got != hold {
+		t.Errorf("unexpected hold PID: got:%+v want:%+v", got, hold)
+	}
+	got, err = m.SpeedPID()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got != speed {
+		t.Errorf("unexpected speed PID: got:%+v want:%+v", got, speed)
+	}
+}
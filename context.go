@@ -0,0 +1,105 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "context"
+
+// contexter is a device handle that may hold a default context
+// for attribute IO.
+type contexter interface {
+	context() context.Context
+}
+
+// ctxErr returns the error of the default context of d if d has
+// a default context that is done, and nil otherwise.
+func ctxErr(d Device) error {
+	c, ok := d.(contexter)
+	if !ok {
+		return nil
+	}
+	ctx := c.context()
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// contextOf returns the default context of d, or a background
+// context if d has none.
+func contextOf(d Device) context.Context {
+	if c, ok := d.(contexter); ok {
+		if ctx := c.context(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// WithContext sets the default context for attribute IO on the
+// TachoMotor and returns the receiver. Once ctx is done, all attribute
+// reads and writes on the TachoMotor fail with an error wrapping
+// ctx.Err(). Attribute IO that has already started is not interrupted,
+// but IO waiting for its turn in the scheduler set by SetIOWeights is
+// abandoned. A nil ctx removes the default context.
+func (m *TachoMotor) WithContext(ctx context.Context) *TachoMotor {
+	m.ctx = ctx
+	return m
+}
+
+// WithContext sets the default context for attribute IO on the
+// DCMotor and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (m *DCMotor) WithContext(ctx context.Context) *DCMotor {
+	m.ctx = ctx
+	return m
+}
+
+// WithContext sets the default context for attribute IO on the
+// ServoMotor and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (m *ServoMotor) WithContext(ctx context.Context) *ServoMotor {
+	m.ctx = ctx
+	return m
+}
+
+// WithContext sets the default context for attribute IO on the
+// LinearActuator and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (m *LinearActuator) WithContext(ctx context.Context) *LinearActuator {
+	m.ctx = ctx
+	return m
+}
+
+// WithContext sets the default context for attribute IO on the
+// Sensor and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (s *Sensor) WithContext(ctx context.Context) *Sensor {
+	s.ctx = ctx
+	return s
+}
+
+// WithContext sets the default context for attribute IO on the
+// LegoPort and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (p *LegoPort) WithContext(ctx context.Context) *LegoPort {
+	p.ctx = ctx
+	return p
+}
+
+// WithContext sets the default context for attribute IO on the
+// LED and returns the receiver. See TachoMotor.WithContext
+// for details.
+func (l *LED) WithContext(ctx context.Context) *LED {
+	l.ctx = ctx
+	return l
+}
+
+func (m *TachoMotor) context() context.Context     { return m.ctx }
+func (m *DCMotor) context() context.Context        { return m.ctx }
+func (m *ServoMotor) context() context.Context     { return m.ctx }
+func (m *LinearActuator) context() context.Context { return m.ctx }
+func (s *Sensor) context() context.Context         { return s.ctx }
+func (p *LegoPort) context() context.Context       { return p.ctx }
+func (l *LED) context() context.Context            { return l.ctx }
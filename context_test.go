@@ -0,0 +1,51 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, d := range []Device{
+		(&TachoMotor{}).WithContext(ctx),
+		(&DCMotor{}).WithContext(ctx),
+		(&ServoMotor{}).WithContext(ctx),
+		(&LinearActuator{}).WithContext(ctx),
+		(&Sensor{}).WithContext(ctx),
+		(&LegoPort{}).WithContext(ctx),
+		ledDevice{(&LED{Name: ledName("led0:red:brick-status")}).WithContext(ctx)},
+	} {
+		_, _, _, err := attributeOf(d, uevent)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected read error for %T: got:%v want:%v", d, err, context.Canceled)
+		}
+		err = setAttributeOf(d, command, "stop")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected set error for %T: got:%v want:%v", d, err, context.Canceled)
+		}
+	}
+
+	s := (&Sensor{}).WithContext(ctx)
+	_, err := s.BinData()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected bin data error: got:%v want:%v", err, context.Canceled)
+	}
+	_, err = s.Direct(os.O_RDONLY)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected direct error: got:%v want:%v", err, context.Canceled)
+	}
+
+	m := (&TachoMotor{}).WithContext(ctx).WithContext(nil)
+	if err := ctxErr(m); err != nil {
+		t.Errorf("unexpected error after removing context: %v", err)
+	}
+}
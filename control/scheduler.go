@@ -0,0 +1,264 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Scheduler runs periodic control callbacks at independent rates from
+// a single goroutine. Running all callbacks from one goroutine avoids
+// the scheduling overhead of a goroutine and ticker per control loop,
+// which is significant on the single core of the EV3.
+//
+// Tasks must be added before Run is called.
+type Scheduler struct {
+	// WarnOverruns is the number of overruns
+	// of a task after which a warning is
+	// logged. A warning is logged each time
+	// the task accumulates another WarnOverruns
+	// overruns. If WarnOverruns is zero, no
+	// warnings are logged.
+	WarnOverruns int64
+
+	tasks   []*Task
+	running int32
+
+	// now and sleep are the clock
+	// functions used by the Scheduler.
+	// They are replaced during testing.
+	now   func() time.Time
+	sleep func(d time.Duration, done <-chan struct{}) bool
+}
+
+// Task is a periodic callback run by a Scheduler.
+type Task struct {
+	name     string
+	period   time.Duration
+	priority int
+	fn       func(now time.Time) error
+
+	next time.Time
+
+	mu        sync.Mutex
+	first     time.Time
+	last      time.Time
+	runs      int64
+	overruns  int64
+	maxJitter time.Duration
+}
+
+// Name returns the name of the task.
+func (t *Task) Name() string { return t.name }
+
+// Period returns the period of the task.
+func (t *Task) Period() time.Duration { return t.period }
+
+// Runs returns the number of times the task's callback has been called.
+// It is safe to call Runs concurrently with Scheduler.Run.
+func (t *Task) Runs() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.runs
+}
+
+// Overruns returns the number of periods that the task has missed
+// because the scheduler was unable to call the task's callback on time.
+// It is safe to call Overruns concurrently with Scheduler.Run.
+func (t *Task) Overruns() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.overruns
+}
+
+// Stats holds timing statistics for a periodic task.
+type Stats struct {
+	// Name is the name of the task.
+	Name string
+
+	// Period is the requested period
+	// of the task.
+	Period time.Duration
+
+	// Runs is the number of times the
+	// task has been run.
+	Runs int64
+
+	// Overruns is the number of periods
+	// the task has missed.
+	Overruns int64
+
+	// Frequency is the achieved frequency
+	// of the task in Hz. Frequency is zero
+	// if the task has run fewer than two
+	// times.
+	Frequency float64
+
+	// MaxJitter is the maximum delay between
+	// the scheduled time of a run and the
+	// time the task was called.
+	MaxJitter time.Duration
+}
+
+// Stats returns the timing statistics for the task.
+// It is safe to call Stats concurrently with Scheduler.Run.
+func (t *Task) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	st := Stats{
+		Name:      t.name,
+		Period:    t.period,
+		Runs:      t.runs,
+		Overruns:  t.overruns,
+		MaxJitter: t.maxJitter,
+	}
+	if elapsed := t.last.Sub(t.first); t.runs > 1 && elapsed > 0 {
+		st.Frequency = float64(t.runs-1) / elapsed.Seconds()
+	}
+	return st
+}
+
+// record records a run of the task that was scheduled for the
+// given time and called at now, with the given number of missed
+// periods. It returns the total number of overruns.
+func (t *Task) record(scheduled, now time.Time, missed int64) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.runs == 0 {
+		t.first = now
+	}
+	t.last = now
+	t.runs++
+	t.overruns += missed
+	if jitter := now.Sub(scheduled); jitter > t.maxJitter {
+		t.maxJitter = jitter
+	}
+	return t.overruns
+}
+
+// Add adds a task with the given name to the scheduler, calling fn every
+// period with the time of the call. When more than one task is due at the
+// same time, tasks are run in order of decreasing priority, and tasks with
+// equal priority are run in the order they were added. If fn returns a
+// non-nil error, Run returns that error.
+//
+// Add returns an error if period is not positive or if it is called while
+// the Scheduler is running.
+func (s *Scheduler) Add(name string, period time.Duration, priority int, fn func(now time.Time) error) (*Task, error) {
+	if atomic.LoadInt32(&s.running) != 0 {
+		return nil, errors.New("control: cannot add task to running scheduler")
+	}
+	if period <= 0 {
+		return nil, fmt.Errorf("control: invalid period for %s: %v (must be positive)", name, period)
+	}
+	t := &Task{name: name, period: period, priority: priority, fn: fn}
+	s.tasks = append(s.tasks, t)
+	sort.SliceStable(s.tasks, func(i, j int) bool {
+		return s.tasks[i].priority > s.tasks[j].priority
+	})
+	return t, nil
+}
+
+// Run runs the scheduled tasks until done is closed or a task returns an
+// error. All tasks are first run immediately when Run is called. Run
+// returns an error if it is called while the Scheduler is already running.
+func (s *Scheduler) Run(done <-chan struct{}) error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return errors.New("control: scheduler already running")
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	now := s.now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := s.sleep
+	if sleep == nil {
+		sleep = sleepUntilDone
+	}
+
+	start := now()
+	for _, t := range s.tasks {
+		t.next = start
+	}
+	for {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		if len(s.tasks) == 0 {
+			<-done
+			return nil
+		}
+		next := s.tasks[0].next
+		for _, t := range s.tasks[1:] {
+			if t.next.Before(next) {
+				next = t.next
+			}
+		}
+		if d := next.Sub(now()); d > 0 {
+			if !sleep(d, done) {
+				return nil
+			}
+		}
+
+		for _, t := range s.tasks {
+			if now().Before(t.next) {
+				continue
+			}
+			scheduled := t.next
+			called := now()
+			err := t.fn(called)
+			t.next = t.next.Add(t.period)
+			var missed int64
+			if late := now().Sub(t.next); late > 0 {
+				// Skip missed periods rather than
+				// running the task repeatedly to
+				// catch up.
+				missed = int64(late/t.period) + 1
+				t.next = t.next.Add(time.Duration(missed) * t.period)
+			}
+			overruns := t.record(scheduled, called, missed)
+			if w := s.WarnOverruns; w > 0 && missed > 0 && overruns/w > (overruns-missed)/w {
+				log.Printf("control: task %s has overrun %d times", t.name, overruns)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Stats returns the timing statistics for all the tasks in the
+// Scheduler in the order they are run. It is safe to call Stats
+// concurrently with Run.
+func (s *Scheduler) Stats() []Stats {
+	st := make([]Stats, len(s.tasks))
+	for i, t := range s.tasks {
+		st[i] = t.Stats()
+	}
+	return st
+}
+
+// sleepUntilDone sleeps for d, returning false if done is closed before
+// d has elapsed.
+func sleepUntilDone(d time.Duration, done <-chan struct{}) bool {
+	timer := time.NewTimer(d)
+	select {
+	case <-timer.C:
+		return true
+	case <-done:
+		timer.Stop()
+		return false
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package control
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock that only advances when
+// sleeping or when explicitly advanced.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(d time.Duration, done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	default:
+	}
+	c.t = c.t.Add(d)
+	return true
+}
+
+func newTestScheduler() (*Scheduler, *fakeClock) {
+	c := &fakeClock{t: time.Unix(0, 0)}
+	return &Scheduler{now: c.now, sleep: c.sleep}, c
+}
+
+func TestSchedulerRates(t *testing.T) {
+	s, c := newTestScheduler()
+	start := c.t
+	done := make(chan struct{})
+
+	var order []string
+	record := func(name string, cost time.Duration) func(time.Time) error {
+		return func(now time.Time) error {
+			if now.Sub(start) == 0 {
+				order = append(order, name)
+			}
+			c.t = c.t.Add(cost)
+			if c.t.Sub(start) >= 100*time.Millisecond {
+				select {
+				case <-done:
+				default:
+					close(done)
+				}
+			}
+			return nil
+		}
+	}
+	fast, err := s.Add("fast", 5*time.Millisecond, 0, record("fast", 0))
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	slow, err := s.Add("slow", 20*time.Millisecond, 1, record("slow", 0))
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	err = s.Run(done)
+	if err != nil {
+		t.Errorf("unexpected error from Run: %v", err)
+	}
+
+	if want := []string{"slow", "fast"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("unexpected initial run order: got:%v want:%v", order, want)
+	}
+	if got, want := fast.Runs(), int64(21); got != want {
+		t.Errorf("unexpected number of fast runs: got:%d want:%d", got, want)
+	}
+	if got, want := slow.Runs(), int64(6); got != want {
+		t.Errorf("unexpected number of slow runs: got:%d want:%d", got, want)
+	}
+	if fast.Overruns() != 0 || slow.Overruns() != 0 {
+		t.Errorf("unexpected overruns: fast:%d slow:%d", fast.Overruns(), slow.Overruns())
+	}
+}
+
+func TestSchedulerOverruns(t *testing.T) {
+	s, c := newTestScheduler()
+	done := make(chan struct{})
+	errStop := errors.New("stop")
+
+	var n int
+	task, err := s.Add("slow", 5*time.Millisecond, 0, func(time.Time) error {
+		// Take 12ms, missing two periods each call.
+		c.t = c.t.Add(12 * time.Millisecond)
+		n++
+		if n == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	err = s.Run(done)
+	if err != errStop {
+		t.Errorf("unexpected error from Run: got:%v want:%v", err, errStop)
+	}
+	if got, want := task.Runs(), int64(3); got != want {
+		t.Errorf("unexpected number of runs: got:%d want:%d", got, want)
+	}
+	if got, want := task.Overruns(), int64(6); got != want {
+		t.Errorf("unexpected number of overruns: got:%d want:%d", got, want)
+	}
+}
+
+func TestSchedulerAdd(t *testing.T) {
+	var s Scheduler
+	_, err := s.Add("zero", 0, 0, func(time.Time) error { return nil })
+	if err == nil {
+		t.Error("expected error for zero period")
+	}
+
+	done := make(chan struct{})
+	running := make(chan struct{})
+	_, err = s.Add("task", time.Millisecond, 0, func(time.Time) error {
+		select {
+		case <-running:
+		default:
+			close(running)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	errc := make(chan error)
+	go func() { errc <- s.Run(done) }()
+	<-running
+	_, err = s.Add("late", time.Millisecond, 0, func(time.Time) error { return nil })
+	if err == nil {
+		t.Error("expected error adding task to running scheduler")
+	}
+	err = s.Run(done)
+	if err == nil {
+		t.Error("expected error running running scheduler")
+	}
+	close(done)
+	err = <-errc
+	if err != nil {
+		t.Errorf("unexpected error from Run: %v", err)
+	}
+}
+
+func TestSchedulerStats(t *testing.T) {
+	s, c := newTestScheduler()
+	s.WarnOverruns = 2
+	var logBuf bytes.Buffer
+	log.SetOutput(&logBuf)
+	defer log.SetOutput(os.Stderr)
+
+	start := c.t
+	done := make(chan struct{})
+	_, err := s.Add("busy", 10*time.Millisecond, 1, func(time.Time) error {
+		c.t = c.t.Add(3 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	_, err = s.Add("delayed", 10*time.Millisecond, 0, func(time.Time) error {
+		if c.t.Sub(start) >= 50*time.Millisecond {
+			close(done)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	_, err = s.Add("slow", 2*time.Millisecond, -1, func(time.Time) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding task: %v", err)
+	}
+	err = s.Run(done)
+	if err != nil {
+		t.Errorf("unexpected error from Run: %v", err)
+	}
+
+	want := []Stats{
+		{Name: "busy", Period: 10 * time.Millisecond, Runs: 6, Frequency: 100},
+		{Name: "delayed", Period: 10 * time.Millisecond, Runs: 6, Frequency: 100, MaxJitter: 3 * time.Millisecond},
+		{Name: "slow", Period: 2 * time.Millisecond, Runs: 21, Overruns: 6, Frequency: 400, MaxJitter: 3 * time.Millisecond},
+	}
+	got := s.Stats()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected stats:\ngot: %+v\nwant:%+v", got, want)
+	}
+	if n := strings.Count(logBuf.String(), "control: task slow has overrun"); n != 3 {
+		t.Errorf("unexpected number of overrun warnings: got:%d want:3\n%s", n, &logBuf)
+	}
+}
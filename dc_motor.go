@@ -5,6 +5,8 @@
 package ev3dev
 
 import (
+	"context"
+	"math"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -20,6 +22,20 @@ type DCMotor struct {
 	driver                string
 	commands, stopActions []string
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
+	// slew is the maximum rate of change
+	// of the duty cycle setpoint in percent
+	// per second. If slew is zero the rate
+	// is not limited.
+	slew float64
+
 	err error
 }
 
@@ -39,14 +55,13 @@ func (m *DCMotor) String() string {
 
 // Err returns the error state of the DCMotor and clears it.
 func (m *DCMotor) Err() error {
-	err := m.err
-	m.err = nil
-	return err
+	return takeErr(&m.err)
 }
 
 // idInt and setID satisfy the idSetter interface.
 func (m *DCMotor) setID(id int) error {
 	t := DCMotor{id: id}
+	forgetName(&t)
 	var err error
 	t.commands, err = stringSliceFrom(attributeOf(&t, commands))
 	if err != nil {
@@ -88,6 +103,7 @@ func DCMotorFor(port, driver string) (*DCMotor, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&m)
 	return &m, err
 }
 
@@ -102,7 +118,13 @@ func (m *DCMotor) Next() (*DCMotor, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &DCMotor{id: id}, err
+	var n DCMotor
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
 // Driver returns the driver used by the DCMotor.
@@ -122,23 +144,24 @@ func (m *DCMotor) Commands() []string {
 	return avail
 }
 
-// Command issues a command to the DCMotor.
-func (m *DCMotor) Command(comm string) *DCMotor {
-	if m.err != nil {
+// Command issues a command to the DCMotor. The command must be one of the
+// commands listed by Commands.
+func (m *DCMotor) Command(comm MotorCommand) *DCMotor {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, c := range m.commands {
-		if c == comm {
+		if c == string(comm) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, command, "", comm, m.Commands())
+		setErr(&m.err, newInvalidValueError(m, command, "", string(comm), m.Commands()))
 		return m
 	}
-	m.err = setAttributeOf(m, command, comm)
+	setErr(&m.err, setAttributeOf(m, command, string(comm)))
 	return m
 }
 
@@ -152,16 +175,58 @@ func (m *DCMotor) DutyCycleSetpoint() (int, error) {
 	return intFrom(attributeOf(m, dutyCycleSetpoint))
 }
 
-// SetDutyCycleSetpoint sets the duty cycle setpoint value for the DCMotor
+// SetDutyCycleSetpoint sets the duty cycle setpoint value for the DCMotor.
+// If a slew rate has been set with SetDutySlewRate, the setpoint is moved
+// from its current value towards sp in steps written every 10ms, each
+// changing the setpoint by at most the slew rate multiplied by 10ms, and
+// SetDutyCycleSetpoint returns when sp has been written.
 func (m *DCMotor) SetDutyCycleSetpoint(sp int) *DCMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < -100 || 100 < sp {
-		m.err = newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100)
+		setErr(&m.err, newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100))
+		return m
+	}
+	if m.slew == 0 {
+		setErr(&m.err, setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp)))
+		return m
+	}
+	cur, err := m.DutyCycleSetpoint()
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	out := float64(cur)
+	for out != float64(sp) {
+		out = slewStep(out, float64(sp), m.slew, slewTick)
+		err = setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(int(math.Round(out))))
+		if err != nil {
+			setErr(&m.err, err)
+			return m
+		}
+		if out != float64(sp) {
+			time.Sleep(slewTick)
+		}
+	}
+	return m
+}
+
+// SetDutySlewRate sets the maximum rate of change of the duty cycle
+// setpoint written by SetDutyCycleSetpoint to rate percent per second.
+// Slew limiting smooths setpoint changes and protects gearboxes, since
+// DC motor drivers may not support ramping. If rate is zero, the rate
+// of change is not limited.
+func (m *DCMotor) SetDutySlewRate(rate float64) *DCMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	err := checkSlewRate(rate)
+	if err != nil {
+		setErr(&m.err, err)
 		return m
 	}
-	m.err = setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp))
+	m.slew = rate
 	return m
 }
 
@@ -173,32 +238,45 @@ func (m *DCMotor) Polarity() (Polarity, error) {
 
 // SetPolarity sets the polarity of the DCMotor
 func (m *DCMotor) SetPolarity(p Polarity) *DCMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if p != Normal && p != Inversed {
-		m.err = newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)})
+		setErr(&m.err, newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)}))
 		return m
 	}
-	m.err = setAttributeOf(m, polarity, string(p))
+	setErr(&m.err, setAttributeOf(m, polarity, string(p)))
 	return m
 }
 
+// maxDCRampSetpoint is the maximum ramp setpoint accepted by the dc-motor
+// class. The limit is imposed by the class rather than by the individual
+// drivers, so it applies to all DC motors including rcx-motor.
+const maxDCRampSetpoint = 10 * time.Second
+
+// MaxRampSetpoint returns the maximum ramp up and ramp down setpoint
+// accepted by the DCMotor. The limit is 10s, imposed by the dc-motor
+// class for all drivers.
+func (m *DCMotor) MaxRampSetpoint() time.Duration {
+	return maxDCRampSetpoint
+}
+
 // RampUpSetpoint returns the current ramp up setpoint value for the DCMotor.
 func (m *DCMotor) RampUpSetpoint() (time.Duration, error) {
 	return durationFrom(attributeOf(m, rampUpSetpoint))
 }
 
 // SetRampUpSetpoint sets the ramp up setpoint value for the DCMotor.
+// The setpoint must not exceed the limit returned by MaxRampSetpoint.
 func (m *DCMotor) SetRampUpSetpoint(sp time.Duration) *DCMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	if sp < 0 || 10*time.Second < sp {
-		m.err = newDurationOutOfRangeError(m, rampUpSetpoint, sp, 0, 10*time.Second)
+	if max := m.MaxRampSetpoint(); sp < 0 || max < sp {
+		setErr(&m.err, newDurationOutOfRangeError(m, rampUpSetpoint, sp, 0, max))
 		return m
 	}
-	m.err = setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -208,50 +286,60 @@ func (m *DCMotor) RampDownSetpoint() (time.Duration, error) {
 }
 
 // SetRampDownSetpoint sets the ramp down setpoint value for the DCMotor.
+// The setpoint must not exceed the limit returned by MaxRampSetpoint.
 func (m *DCMotor) SetRampDownSetpoint(sp time.Duration) *DCMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	if sp < 0 || 10*time.Second < sp {
-		m.err = newDurationOutOfRangeError(m, rampDownSetpoint, sp, 0, 10*time.Second)
+	if max := m.MaxRampSetpoint(); sp < 0 || max < sp {
+		setErr(&m.err, newDurationOutOfRangeError(m, rampDownSetpoint, sp, 0, max))
 		return m
 	}
-	m.err = setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
 // State returns the current state of the DCMotor.
 func (m *DCMotor) State() (MotorState, error) {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return 0, m.Err()
 	}
 	return stateFrom(attributeOf(m, state))
 }
 
+// WaitContext waits until the DCMotor reaches the wanted motor state under
+// the motor state mask, or ctx is done. It is equivalent to
+// WaitContext(ctx, m, mask, want, not, any).
+func (m *DCMotor) WaitContext(ctx context.Context, mask, want, not MotorState, any bool) (stat MotorState, ok bool, err error) {
+	return WaitContext(ctx, m, mask, want, not, any)
+}
+
 // StopAction returns the stop action used when a stop command is issued
 // to the DCMotor.
-func (m *DCMotor) StopAction() (string, error) {
-	return stringFrom(attributeOf(m, stopAction))
+func (m *DCMotor) StopAction() (StopAction, error) {
+	action, err := stringFrom(attributeOf(m, stopAction))
+	return StopAction(action), err
 }
 
 // SetStopAction sets the stop action to be used when a stop command is
-// issued to the DCMotor.
-func (m *DCMotor) SetStopAction(action string) *DCMotor {
-	if m.err != nil {
+// issued to the DCMotor. The action must be one
+// of the stop actions listed by StopActions.
+func (m *DCMotor) SetStopAction(action StopAction) *DCMotor {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, a := range m.stopActions {
-		if a == action {
+		if a == string(action) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, stopAction, "", action, m.StopActions())
+		setErr(&m.err, newInvalidValueError(m, stopAction, "", string(action), m.StopActions()))
 		return m
 	}
-	m.err = setAttributeOf(m, stopAction, action)
+	setErr(&m.err, setAttributeOf(m, stopAction, string(action)))
 	return m
 }
 
@@ -274,14 +362,14 @@ func (m *DCMotor) TimeSetpoint() (time.Duration, error) {
 
 // SetTimeSetpoint sets the time setpoint value for the DCMotor.
 func (m *DCMotor) SetTimeSetpoint(sp time.Duration) *DCMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, timeSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, timeSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
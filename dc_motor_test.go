@@ -726,7 +726,7 @@ func TestDCMotor(t *testing.T) {
 				t.Errorf("unexpected commands value: got:%q want:%q", commands, want)
 			}
 			for _, command := range commands {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err != nil {
 					t.Errorf("unexpected error for command %q: %v", command, err)
 				}
@@ -738,7 +738,7 @@ func TestDCMotor(t *testing.T) {
 				}
 			}
 			for _, command := range []string{"invalid", "another"} {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err == nil {
 					t.Errorf("expected error for command %q", command)
 				}
@@ -941,7 +941,7 @@ func TestDCMotor(t *testing.T) {
 				t.Errorf("unexpected stop actions value: got:%q want:%q", stopActions, want)
 			}
 			for _, stopAction := range stopActions {
-				err := m.SetStopAction(stopAction).Err()
+				err := m.SetStopAction(StopAction(stopAction)).Err()
 				if err != nil {
 					t.Errorf("unexpected error for set stop action %q: %v", stopAction, err)
 				}
@@ -952,16 +952,16 @@ func TestDCMotor(t *testing.T) {
 					t.Errorf("unexpected stop action value: got:%q want:%q", got, want)
 				}
 
-				got, err = m.StopAction()
+				gotAction, err := m.StopAction()
 				if err != nil {
 					t.Errorf("unexpected error for stop action %q: %v", stopAction, err)
 				}
-				if got != want {
+				if got := string(gotAction); got != want {
 					t.Errorf("unexpected stop action value: got:%q want:%q", got, want)
 				}
 			}
 			for _, stopAction := range []string{"invalid", "another"} {
-				err := m.SetStopAction(stopAction).Err()
+				err := m.SetStopAction(StopAction(stopAction)).Err()
 				if err == nil {
 					t.Errorf("expected error for set stop action %q", stopAction)
 				}
@@ -0,0 +1,29 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"time"
+)
+
+// RunTimed sets the time and duty cycle setpoints of the DCMotor and issues
+// the run-timed command. If block is true, RunTimed waits until the motor is
+// no longer running and returns the final motor state. Otherwise the
+// returned motor state is zero.
+//
+// The wait is cancelled if the DCMotor's default context is done.
+func (m *DCMotor) RunTimed(d time.Duration, dutyCycle int, block bool) (MotorState, error) {
+	err := m.SetTimeSetpoint(d).SetDutyCycleSetpoint(dutyCycle).Command(CommandRunTimed).Err()
+	if err != nil || !block {
+		return 0, err
+	}
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stat, _, err := WaitContext(ctx, m, Running, 0, 0, false)
+	return stat, err
+}
@@ -0,0 +1,63 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDCMotorRunTimed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(DCMotorPath, "motor0"): {
+			dutyCycleSetpoint: "",
+			timeSetpoint:      "",
+			command:           "",
+			state:             running,
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &DCMotor{id: 0, commands: []string{"run-forever", "run-timed", "run-direct", "stop"}}
+	_, err = m.RunTimed(time.Second, 150, false)
+	if err == nil {
+		t.Error("expected error for out of range duty cycle")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ioutil.WriteFile(filepath.Join(dir, DCMotorPath, "motor0", state), []byte("\n"), 0644)
+	}()
+	stat, err := m.RunTimed(1500*time.Millisecond, -60, true)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if stat != 0 {
+		t.Errorf("unexpected state: got:%v want:0", stat)
+	}
+	for attr, want := range map[string]string{timeSetpoint: "1500", dutyCycleSetpoint: "-60", command: "run-timed"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, DCMotorPath, "motor0", attr))
+		if err != nil {
+			t.Fatalf("failed to read attribute: %v", err)
+		}
+		if got := strings.TrimSpace(string(b)); got != want {
+			t.Errorf("unexpected %s value: got:%q want:%q", attr, got, want)
+		}
+	}
+}
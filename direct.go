@@ -0,0 +1,215 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DirectFile is a sensor direct file that supports deadlines and
+// cancellation. The sysfs direct attribute does not support non-blocking
+// IO, so each operation is performed by a helper goroutine; an operation
+// that is abandoned due to a deadline or cancellation completes in the
+// background without modifying the caller's buffer. Data read by an
+// abandoned read is discarded.
+//
+// Operations are performed one at a time in the order they are started.
+// An operation does not start until any abandoned operation has completed,
+// so an abandoned write never lands after a later operation. An operation
+// whose deadline passes or whose context is cancelled while waiting for an
+// abandoned operation fails without being performed.
+//
+// Operations that time out return an error for which errors.Is reports
+// true for context.DeadlineExceeded.
+type DirectFile struct {
+	f *os.File
+
+	// inflight holds a token while an
+	// operation is being performed.
+	inflight chan struct{}
+
+	mu            sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+// DirectFile returns a DirectFile that can be used to directly communicate
+// with the sensor as described for Direct. It is the responsibility of the
+// user to provide the correct file operation flags, and to close the file
+// after use.
+func (s *Sensor) DirectFile(flag int) (*DirectFile, error) {
+	if s.err != nil {
+		return nil, s.Err()
+	}
+	f, err := os.OpenFile(filepath.Join(s.Path(), s.String(), direct), flag, 0)
+	if err != nil {
+		return nil, err
+	}
+	return newDirectFile(f), nil
+}
+
+func newDirectFile(f *os.File) *DirectFile {
+	return &DirectFile{f: f, inflight: make(chan struct{}, 1)}
+}
+
+// File returns the underlying file.
+func (f *DirectFile) File() *os.File { return f.f }
+
+// Close closes the DirectFile.
+func (f *DirectFile) Close() error { return f.f.Close() }
+
+// SetDeadline sets the read and write deadlines for the DirectFile. A zero
+// value for t means operations will not time out.
+func (f *DirectFile) SetDeadline(t time.Time) error {
+	f.mu.Lock()
+	f.readDeadline = t
+	f.writeDeadline = t
+	f.mu.Unlock()
+	return nil
+}
+
+// SetReadDeadline sets the deadline for future reads. A zero value
+// for t means reads will not time out.
+func (f *DirectFile) SetReadDeadline(t time.Time) error {
+	f.mu.Lock()
+	f.readDeadline = t
+	f.mu.Unlock()
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for future writes. A zero value
+// for t means writes will not time out.
+func (f *DirectFile) SetWriteDeadline(t time.Time) error {
+	f.mu.Lock()
+	f.writeDeadline = t
+	f.mu.Unlock()
+	return nil
+}
+
+// Read reads from the DirectFile, subject to the read deadline.
+func (f *DirectFile) Read(p []byte) (int, error) {
+	ctx, cancel := f.deadlineContext(&f.readDeadline)
+	defer cancel()
+	return f.read(ctx, p, func(b []byte) (int, error) { return f.f.Read(b) })
+}
+
+// ReadAt reads from the DirectFile at offset off, subject to the read
+// deadline.
+func (f *DirectFile) ReadAt(p []byte, off int64) (int, error) {
+	ctx, cancel := f.deadlineContext(&f.readDeadline)
+	defer cancel()
+	return f.ReadAtContext(ctx, p, off)
+}
+
+// ReadAtContext reads from the DirectFile at offset off, returning
+// ctx.Err() if ctx is done before the read completes. The read deadline
+// is not used.
+func (f *DirectFile) ReadAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	return f.read(ctx, p, func(b []byte) (int, error) { return f.f.ReadAt(b, off) })
+}
+
+// Write writes to the DirectFile, subject to the write deadline.
+func (f *DirectFile) Write(p []byte) (int, error) {
+	ctx, cancel := f.deadlineContext(&f.writeDeadline)
+	defer cancel()
+	return f.write(ctx, p, func(b []byte) (int, error) { return f.f.Write(b) })
+}
+
+// WriteAt writes to the DirectFile at offset off, subject to the write
+// deadline.
+func (f *DirectFile) WriteAt(p []byte, off int64) (int, error) {
+	ctx, cancel := f.deadlineContext(&f.writeDeadline)
+	defer cancel()
+	return f.WriteAtContext(ctx, p, off)
+}
+
+// WriteAtContext writes to the DirectFile at offset off, returning
+// ctx.Err() if ctx is done before the write completes. The write
+// deadline is not used.
+func (f *DirectFile) WriteAtContext(ctx context.Context, p []byte, off int64) (int, error) {
+	return f.write(ctx, p, func(b []byte) (int, error) { return f.f.WriteAt(b, off) })
+}
+
+// deadlineContext returns a context that is done at the
+// deadline held in *d, or a background context if the
+// deadline is zero.
+func (f *DirectFile) deadlineContext(d *time.Time) (context.Context, context.CancelFunc) {
+	f.mu.Lock()
+	t := *d
+	f.mu.Unlock()
+	if t.IsZero() {
+		return context.Background(), func() {}
+	}
+	return context.WithDeadline(context.Background(), t)
+}
+
+// ioResult is the result of a DirectFile operation.
+type ioResult struct {
+	n   int
+	err error
+}
+
+// read performs op into a private buffer, copying the result
+// into p if op completes before ctx is done.
+func (f *DirectFile) read(ctx context.Context, p []byte, op func([]byte) (int, error)) (int, error) {
+	buf := make([]byte, len(p))
+	r, err := f.do(ctx, func() ioResult {
+		n, err := op(buf)
+		return ioResult{n: n, err: err}
+	})
+	if err != nil {
+		return 0, err
+	}
+	copy(p, buf[:r.n])
+	return r.n, r.err
+}
+
+// write performs op with a private copy of p, returning early
+// if ctx is done before op completes.
+func (f *DirectFile) write(ctx context.Context, p []byte, op func([]byte) (int, error)) (int, error) {
+	buf := append([]byte(nil), p...)
+	r, err := f.do(ctx, func() ioResult {
+		n, err := op(buf)
+		return ioResult{n: n, err: err}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return r.n, r.err
+}
+
+// do waits for any in-flight operation to complete and then calls op
+// in a helper goroutine, returning its result. If ctx is done before op
+// is called, op is not called. If ctx is done before op completes, do
+// returns ctx.Err() and op completes in the background, holding off
+// later operations until it returns.
+func (f *DirectFile) do(ctx context.Context, op func() ioResult) (ioResult, error) {
+	select {
+	case f.inflight <- struct{}{}:
+	case <-ctx.Done():
+		return ioResult{}, ctx.Err()
+	}
+	err := ctx.Err()
+	if err != nil {
+		<-f.inflight
+		return ioResult{}, err
+	}
+	done := make(chan ioResult, 1)
+	go func() {
+		r := op()
+		<-f.inflight
+		done <- r
+	}()
+	select {
+	case r := <-done:
+		return r, nil
+	case <-ctx.Done():
+		return ioResult{}, ctx.Err()
+	}
+}
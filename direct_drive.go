@@ -0,0 +1,312 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DirectDrive drives a motor in run-direct mode, where changes to the duty
+// cycle setpoint take effect immediately. It is intended for closed-loop
+// controllers that update the duty cycle of a motor hundreds of times per
+// second.
+//
+// Duty cycle setpoints are written by a helper goroutine through a file
+// that is held open for the life of the DirectDrive. Writes are limited to
+// one per interval passed to NewDirectDrive, and setpoints that are set
+// while a write is pending are coalesced so that only the most recent
+// setpoint is written.
+//
+// The rate of change of the duty cycle may be limited with SetSlewRate to
+// smooth setpoints from sources such as joysticks and to protect gearboxes,
+// particularly for DC motors whose drivers do not support ramping.
+type DirectDrive struct {
+	dev   Device
+	f     *os.File
+	write func([]byte) error
+
+	interval time.Duration
+	wake     chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+
+	mu      sync.Mutex
+	duty    int
+	pending bool
+	closed  bool
+	err     error
+
+	// slew is the maximum rate of change of
+	// the duty cycle in percent per second.
+	// If slew is zero the rate is not limited.
+	slew float64
+
+	// out is the duty cycle most recently
+	// written, before rounding, and written
+	// is the time it was written.
+	out     float64
+	written time.Time
+}
+
+// NewDirectDrive returns a DirectDrive for the motor m, which must be a
+// *TachoMotor, *DCMotor or *LinearActuator. The motor's duty cycle setpoint
+// is set to zero and the motor is sent the run-direct command. Duty cycle
+// setpoints are written at most once per minInterval. If minInterval is
+// zero, setpoints are written as quickly as possible. If m is a *DCMotor
+// with a slew rate set by SetDutySlewRate, the DirectDrive's slew rate is
+// initially set to that rate.
+func NewDirectDrive(m Device, minInterval time.Duration) (*DirectDrive, error) {
+	switch m.(type) {
+	case *TachoMotor, *DCMotor, *LinearActuator:
+	default:
+		return nil, fmt.Errorf("ev3dev: device type %T not supported by direct drive", m)
+	}
+	if minInterval < 0 {
+		return nil, fmt.Errorf("ev3dev: invalid direct drive interval: %v (must not be negative)", minInterval)
+	}
+	err := m.Err()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(m.Path(), m.String(), dutyCycleSetpoint), os.O_WRONLY, 0)
+	if err != nil {
+		return nil, newAttrOpError(m, dutyCycleSetpoint, "", "open", err)
+	}
+	err = setAttributeOf(m, dutyCycleSetpoint, "0")
+	if err == nil {
+		err = setAttributeOf(m, command, string(CommandRunDirect))
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	d := newDirectDrive(m, func(b []byte) error {
+		// Sysfs attributes are written in
+		// a single write at offset zero.
+		_, err := f.WriteAt(b, 0)
+		return err
+	}, minInterval)
+	d.f = f
+	return d, nil
+}
+
+func newDirectDrive(m Device, write func([]byte) error, minInterval time.Duration) *DirectDrive {
+	d := &DirectDrive{
+		dev:      m,
+		write:    write,
+		interval: minInterval,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		written:  time.Now(),
+	}
+	if dc, ok := m.(*DCMotor); ok {
+		d.slew = dc.slew
+	}
+	go d.run()
+	return d
+}
+
+// errDirectDriveClosed is returned when a closed DirectDrive is used.
+var errDirectDriveClosed = errors.New("ev3dev: direct drive closed")
+
+// SetDuty sets the duty cycle setpoint of the motor to duty, clamped to
+// the range [-100, 100]. SetDuty does not wait for the setpoint to be
+// written. It returns the first error from writing an earlier setpoint,
+// after which the DirectDrive no longer writes setpoints.
+func (d *DirectDrive) SetDuty(duty int) error {
+	switch {
+	case duty < -100:
+		duty = -100
+	case duty > 100:
+		duty = 100
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return d.err
+	}
+	if d.closed {
+		return errDirectDriveClosed
+	}
+	d.duty = duty
+	d.pending = true
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// slewTick is the minimum interval between writes
+// while the duty cycle is being slew limited.
+const slewTick = 10 * time.Millisecond
+
+// checkSlewRate returns an error if rate is not a valid duty cycle
+// slew rate.
+func checkSlewRate(rate float64) error {
+	if rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 1) {
+		return fmt.Errorf("ev3dev: invalid slew rate: %v (must be non-negative and finite)", rate)
+	}
+	return nil
+}
+
+// slewStep returns the duty cycle reached from the duty cycle from
+// towards to when changing at most at rate percent per second for
+// the duration elapsed.
+func slewStep(from, to, rate float64, elapsed time.Duration) float64 {
+	step := rate * elapsed.Seconds()
+	if diff := to - from; math.Abs(diff) > step {
+		return from + math.Copysign(step, diff)
+	}
+	return to
+}
+
+// SetSlewRate sets the maximum rate of change of the duty cycle to rate
+// percent per second. Setpoints that would change the duty cycle faster
+// are approached in steps written at most once per interval passed to
+// NewDirectDrive, or once every 10ms if that is shorter. Each step changes
+// the duty cycle by at most rate multiplied by the interval. If rate is
+// zero, the rate of change is not limited. When the DirectDrive is closed,
+// a pending step is written before the motor is stopped.
+func (d *DirectDrive) SetSlewRate(rate float64) error {
+	err := checkSlewRate(rate)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return errDirectDriveClosed
+	}
+	d.slew = rate
+	return nil
+}
+
+// Close writes any pending duty cycle setpoint, sends the motor the stop
+// command and closes the DirectDrive's file. It returns the first error
+// from writing a setpoint, stopping the motor or closing the file.
+func (d *DirectDrive) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return errDirectDriveClosed
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	close(d.done)
+	<-d.stopped
+
+	d.mu.Lock()
+	err := d.err
+	d.mu.Unlock()
+	if serr := setAttributeOf(d.dev, command, string(CommandStop)); err == nil {
+		err = serr
+	}
+	if d.f != nil {
+		if cerr := d.f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// run writes pending duty cycle setpoints until the DirectDrive is
+// closed or a write fails.
+func (d *DirectDrive) run() {
+	defer close(d.stopped)
+	var last time.Time
+	for {
+		select {
+		case <-d.wake:
+		case <-d.done:
+			d.flush()
+			return
+		}
+		interval := d.interval
+		d.mu.Lock()
+		if d.slew != 0 {
+			interval = d.slewInterval()
+		}
+		d.mu.Unlock()
+		if wait := interval - time.Since(last); !last.IsZero() && wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-d.done:
+				timer.Stop()
+				d.flush()
+				return
+			}
+		}
+		last = time.Now()
+		if !d.flush() {
+			return
+		}
+	}
+}
+
+// slewInterval returns the interval between
+// writes while the duty cycle is slew limited.
+func (d *DirectDrive) slewInterval() time.Duration {
+	if d.interval < slewTick {
+		return slewTick
+	}
+	return d.interval
+}
+
+// flush writes the pending duty cycle setpoint if there is one,
+// returning false if the write failed. If the slew rate prevents
+// the setpoint being reached, flush writes a step towards it and
+// leaves the setpoint pending.
+func (d *DirectDrive) flush() bool {
+	d.mu.Lock()
+	duty, pending := d.duty, d.pending
+	d.pending = false
+	if pending {
+		now := time.Now()
+		out := float64(duty)
+		if d.slew != 0 {
+			// Time spent at the previous setpoint
+			// is not counted, so a change after a
+			// pause is limited to a single step.
+			elapsed := now.Sub(d.written)
+			if tick := d.slewInterval(); elapsed > tick {
+				elapsed = tick
+			}
+			if out = slewStep(d.out, out, d.slew, elapsed); out != float64(duty) {
+				duty = int(math.Round(out))
+				d.pending = true
+				select {
+				case d.wake <- struct{}{}:
+				default:
+				}
+			}
+		}
+		d.out = out
+		d.written = now
+	}
+	d.mu.Unlock()
+	if !pending {
+		return true
+	}
+	data := strconv.Itoa(duty)
+	err := d.write([]byte(data))
+	if err != nil {
+		d.mu.Lock()
+		d.err = newAttrOpError(d.dev, dutyCycleSetpoint, data, "set", err)
+		d.mu.Unlock()
+		return false
+	}
+	return true
+}
@@ -0,0 +1,313 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDirectDrive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	attrs[dutyCycleSetpoint] = "50"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): attrs,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	read := func(attr string) string {
+		t.Helper()
+		b, err := ioutil.ReadFile(filepath.Join(dir, TachoMotorPath, "motor0", attr))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", attr, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	_, err = NewDirectDrive(&Sensor{id: 0}, 0)
+	if err == nil {
+		t.Error("expected error for sensor")
+	}
+
+	d, err := NewDirectDrive(&TachoMotor{id: 0}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := read(command); got != string(CommandRunDirect) {
+		t.Errorf("unexpected command: got:%q want:%q", got, CommandRunDirect)
+	}
+	if got := read(dutyCycleSetpoint); got != "0" {
+		t.Errorf("unexpected initial duty cycle: got:%q want:0", got)
+	}
+	err = d.SetDuty(150)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = d.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+	if got := read(dutyCycleSetpoint); got != "100" {
+		t.Errorf("unexpected duty cycle: got:%q want:100", got)
+	}
+	if got := read(command); got != string(CommandStop) {
+		t.Errorf("unexpected command after close: got:%q want:%q", got, CommandStop)
+	}
+	if err := d.SetDuty(0); err != errDirectDriveClosed {
+		t.Errorf("unexpected error after close: got:%v want:%v", err, errDirectDriveClosed)
+	}
+}
+
+func TestDirectDriveCoalesce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(DCMotorPath, "motor0"): {command: ""},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	const interval = 20 * time.Millisecond
+	var (
+		mu     sync.Mutex
+		writes []string
+		times  []time.Time
+	)
+	d := newDirectDrive(&DCMotor{id: 0}, func(b []byte) error {
+		mu.Lock()
+		writes = append(writes, string(b))
+		times = append(times, time.Now())
+		mu.Unlock()
+		return nil
+	}, interval)
+	for duty := -150; duty <= 50; duty++ {
+		d.SetDuty(duty)
+	}
+	time.Sleep(3 * interval)
+	d.SetDuty(10)
+	d.SetDuty(20)
+	err = d.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(writes) < 2 || len(writes) > 5 {
+		t.Errorf("unexpected number of writes: got:%d %q", len(writes), writes)
+	}
+	if writes[len(writes)-1] != "20" {
+		t.Errorf("unexpected final write: got:%q want:20", writes[len(writes)-1])
+	}
+	for _, w := range writes {
+		if w == "-150" {
+			t.Error("duty cycle not clamped")
+		}
+	}
+	for i := 1; i < len(times)-1; i++ {
+		// The final write is made on close
+		// without waiting for the interval.
+		if gap := times[i].Sub(times[i-1]); gap < interval {
+			t.Errorf("writes %d and %d closer than interval: %v", i-1, i, gap)
+		}
+	}
+}
+
+func TestDirectDriveWriteError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(DCMotorPath, "motor0"): {command: ""},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	errWrite := errors.New("write failed")
+	written := make(chan struct{})
+	d := newDirectDrive(&DCMotor{id: 0}, func([]byte) error {
+		close(written)
+		return errWrite
+	}, 0)
+	err = d.SetDuty(10)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	<-written
+	for i := 0; ; i++ {
+		err = d.SetDuty(20)
+		if err != nil {
+			break
+		}
+		if i > 100 {
+			t.Fatal("write error not reported")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !errors.Is(err, errWrite) {
+		t.Errorf("unexpected error: got:%v want:%v", err, errWrite)
+	}
+	if err := d.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("unexpected error closing: got:%v want:%v", err, errWrite)
+	}
+}
+
+func TestDirectDriveSlew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(DCMotorPath, "motor0"): {command: ""},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	const rate = 1000 // Percent per second.
+	var (
+		mu     sync.Mutex
+		writes []int
+		done   = make(chan struct{})
+	)
+	d := newDirectDrive(&DCMotor{id: 0}, func(b []byte) error {
+		v, err := strconv.Atoi(string(b))
+		if err != nil {
+			t.Errorf("unexpected duty cycle: %q", b)
+		}
+		mu.Lock()
+		writes = append(writes, v)
+		if v == 100 {
+			close(done)
+		}
+		mu.Unlock()
+		return nil
+	}, 0)
+	for _, r := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := d.SetSlewRate(r); err == nil {
+			t.Errorf("expected error for slew rate %v", r)
+		}
+	}
+	err = d.SetSlewRate(rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = d.SetDuty(100)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for setpoint")
+	}
+	err = d.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Reaching 100% at 1000%/s in 10ms
+	// steps takes at least ten writes.
+	if len(writes) < 10 {
+		t.Errorf("too few writes for slew limited change: %v", writes)
+	}
+	// Each step is at most one slew tick.
+	maxStep := int(math.Ceil(rate * slewTick.Seconds()))
+	last := 0
+	for _, v := range writes {
+		if v-last > maxStep || v < last {
+			t.Errorf("slew limit exceeded: %v", writes)
+			break
+		}
+		last = v
+	}
+}
+
+func TestSlewStep(t *testing.T) {
+	for _, test := range []struct {
+		from, to, rate float64
+		elapsed        time.Duration
+		want           float64
+	}{
+		{from: 0, to: 100, rate: 1000, elapsed: 10 * time.Millisecond, want: 10},
+		{from: 0, to: -100, rate: 1000, elapsed: 10 * time.Millisecond, want: -10},
+		{from: 95, to: 100, rate: 1000, elapsed: 10 * time.Millisecond, want: 100},
+		{from: 50, to: 50, rate: 1000, elapsed: 10 * time.Millisecond, want: 50},
+	} {
+		got := slewStep(test.from, test.to, test.rate, test.elapsed)
+		if math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("unexpected step from %v to %v at %v%%/s: got:%v want:%v",
+				test.from, test.to, test.rate, got, test.want)
+		}
+	}
+}
+
+func TestDCMotorDutySlew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(DCMotorPath, "motor0"): {dutyCycleSetpoint: "0", command: ""},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &DCMotor{id: 0}
+	for _, r := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := m.SetDutySlewRate(r).Err(); err == nil {
+			t.Errorf("expected error for slew rate %v", r)
+		}
+	}
+
+	// Reaching 100% at 2000%/s in 10ms steps
+	// takes five writes and four pauses.
+	const rate = 2000
+	start := time.Now()
+	err = m.SetDutySlewRate(rate).SetDutyCycleSetpoint(100).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 4*slewTick {
+		t.Errorf("slew limited setpoint reached too quickly: %v", elapsed)
+	}
+	sp, err := m.DutyCycleSetpoint()
+	if err != nil || sp != 100 {
+		t.Errorf("unexpected duty cycle setpoint: got:(%d, %v) want:(100, nil)", sp, err)
+	}
+
+	d := newDirectDrive(m, func([]byte) error { return nil }, 0)
+	defer d.Close()
+	if d.slew != rate {
+		t.Errorf("unexpected direct drive slew rate: got:%v want:%v", d.slew, float64(rate))
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDirectFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, SensorPath, "sensor0", direct)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	err = ioutil.WriteFile(path, []byte("0123456789"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write direct file: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	f, err := (&Sensor{id: 0}).DirectFile(os.O_RDWR)
+	if err != nil {
+		t.Fatalf("unexpected error opening direct file: %v", err)
+	}
+	defer f.Close()
+
+	_, err = f.WriteAt([]byte("ab"), 4)
+	if err != nil {
+		t.Errorf("unexpected error writing: %v", err)
+	}
+	buf := make([]byte, 4)
+	n, err := f.ReadAt(buf, 2)
+	if err != nil {
+		t.Errorf("unexpected error reading: %v", err)
+	}
+	if got := string(buf[:n]); got != "23ab" {
+		t.Errorf("unexpected read result: got:%q want:%q", got, "23ab")
+	}
+
+	f.SetDeadline(time.Now().Add(-time.Second))
+	_, err = f.ReadAt(buf, 0)
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error after deadline: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+	_, err = f.WriteAt(buf, 0)
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error after deadline: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+	f.SetDeadline(time.Time{})
+	_, err = f.ReadAt(buf, 0)
+	if err != nil {
+		t.Errorf("unexpected error after clearing deadline: %v", err)
+	}
+}
+
+func TestDirectFileBlocking(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer w.Close()
+	f := newDirectFile(r)
+	defer f.Close()
+
+	f.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	buf := make([]byte, 4)
+	_, err = f.Read(buf)
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error for blocked read: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+
+	// An operation started while the abandoned
+	// read is in flight waits for it to complete.
+	f.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	_, err = f.Read(buf)
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error for read behind blocked read: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+
+	// The abandoned read consumes the next write
+	// without modifying the caller's buffer.
+	w.Write([]byte("lost"))
+	if string(buf) != "\x00\x00\x00\x00" {
+		t.Errorf("buffer modified by abandoned read: %q", buf)
+	}
+
+	// Later reads are not overtaken by
+	// the abandoned read.
+	f.SetReadDeadline(time.Now().Add(5 * time.Second))
+	w.Write([]byte("next"))
+	n, err := f.Read(buf)
+	if err != nil {
+		t.Errorf("unexpected error for read after abandoned read: %v", err)
+	}
+	if got := string(buf[:n]); got != "next" {
+		t.Errorf("unexpected data after abandoned read: got:%q want:%q", got, "next")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f.ReadAtContext(ctx, buf, 0)
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled read: got:%v want:%v", err, context.Canceled)
+	}
+}
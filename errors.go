@@ -7,9 +7,12 @@ package ev3dev
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -36,6 +39,7 @@ type ValidDurationRanger interface {
 
 type invalidValueError struct {
 	dev   Device
+	name  string
 	attr  string
 	mesg  string
 	value string
@@ -56,6 +60,7 @@ func newInvalidValueError(dev Device, attr, message, value string, valid []strin
 	}
 	return invalidValueError{
 		dev:   dev,
+		name:  deviceName(dev),
 		attr:  attr,
 		mesg:  message,
 		value: value,
@@ -67,10 +72,10 @@ func newInvalidValueError(dev Device, attr, message, value string, valid []strin
 func (e invalidValueError) Error() string {
 	if e.mesg != "" {
 		return fmt.Sprintf("ev3dev: %s for %s %s: %q (valid:%q) at %s",
-			e.mesg, e.dev, e.attr, e.value, e.valid, e.caller(0))
+			e.mesg, e.name, e.attr, e.value, e.valid, e.caller(0))
 	}
 	return fmt.Sprintf("ev3dev: invalid value for %s %s: %q (valid:%q) at %s",
-		e.dev, e.attr, e.value, e.valid, e.caller(0))
+		e.name, e.attr, e.value, e.valid, e.caller(0))
 }
 
 func (e invalidValueError) Format(fs fmt.State, c rune) {
@@ -103,6 +108,7 @@ func (e invalidValueError) Values() (value string, valid []string) {
 
 type valueOutOfRangeError struct {
 	dev      Device
+	name     string
 	attr     string
 	value    int
 	min, max int
@@ -120,6 +126,7 @@ func newValueOutOfRangeError(dev Device, attr string, v, min, max int) valueOutO
 	}
 	return valueOutOfRangeError{
 		dev:   dev,
+		name:  deviceName(dev),
 		attr:  attr,
 		value: v,
 		min:   min,
@@ -130,7 +137,7 @@ func newValueOutOfRangeError(dev Device, attr string, v, min, max int) valueOutO
 
 func (e valueOutOfRangeError) Error() string {
 	return fmt.Sprintf("ev3dev: invalid value for %s %s: %d (must be in %d-%d) at %s",
-		e.dev, e.attr, e.value, e.min, e.max, e.caller(0))
+		e.name, e.attr, e.value, e.min, e.max, e.caller(0))
 }
 
 func (e valueOutOfRangeError) Format(fs fmt.State, c rune) {
@@ -163,6 +170,7 @@ func (e valueOutOfRangeError) Range() (value, min, max int) {
 
 type idError struct {
 	dev  Device
+	name string
 	attr string
 	id   int
 
@@ -179,6 +187,7 @@ func newIDErrorFor(dev Device, id int) idError {
 	}
 	return idError{
 		dev:   dev,
+		name:  deviceName(dev),
 		id:    id,
 		stack: callers(),
 	}
@@ -186,7 +195,7 @@ func newIDErrorFor(dev Device, id int) idError {
 
 func (e idError) Error() string {
 	return fmt.Sprintf("ev3dev: invalid id for %s: %v (must be positive) at %s",
-		e.dev, e.id, e.caller(0))
+		e.name, e.id, e.caller(0))
 }
 
 func (e idError) Format(fs fmt.State, c rune) {
@@ -219,6 +228,7 @@ func (e idError) Range() (value, min, max int) {
 
 type negativeDurationError struct {
 	dev      Device
+	name     string
 	attr     string
 	duration time.Duration
 
@@ -235,6 +245,7 @@ func newNegativeDurationError(dev Device, attr string, d time.Duration) negative
 	}
 	return negativeDurationError{
 		dev:      dev,
+		name:     deviceName(dev),
 		attr:     attr,
 		duration: d,
 		stack:    callers(),
@@ -243,7 +254,7 @@ func newNegativeDurationError(dev Device, attr string, d time.Duration) negative
 
 func (e negativeDurationError) Error() string {
 	return fmt.Sprintf("ev3dev: invalid duration for %s %s: %v (must be positive) at %s",
-		e.dev, e.attr, e.duration, e.caller(0))
+		e.name, e.attr, e.duration, e.caller(0))
 }
 
 func (e negativeDurationError) Format(fs fmt.State, c rune) {
@@ -276,6 +287,7 @@ func (e negativeDurationError) DurationRange() (value, min, max time.Duration) {
 
 type durationOutOfRangeError struct {
 	dev      Device
+	name     string
 	attr     string
 	duration time.Duration
 	min, max time.Duration
@@ -293,6 +305,7 @@ func newDurationOutOfRangeError(dev Device, attr string, d, min, max time.Durati
 	}
 	return durationOutOfRangeError{
 		dev:      dev,
+		name:     deviceName(dev),
 		attr:     attr,
 		duration: d,
 		min:      min,
@@ -303,7 +316,7 @@ func newDurationOutOfRangeError(dev Device, attr string, d, min, max time.Durati
 
 func (e durationOutOfRangeError) Error() string {
 	return fmt.Sprintf("ev3dev: invalid duration for %s %s: %v (must be in %v-%v) at %s",
-		e.dev, e.attr, e.duration, e.min, e.max, e.caller(0))
+		e.name, e.attr, e.duration, e.min, e.max, e.caller(0))
 }
 
 func (e durationOutOfRangeError) Format(fs fmt.State, c rune) {
@@ -336,6 +349,7 @@ func (e durationOutOfRangeError) DurationRange() (value, min, max time.Duration)
 
 type attrOpError struct {
 	dev  Device
+	name string
 	attr string
 	data string
 	op   string
@@ -347,6 +361,7 @@ type attrOpError struct {
 func newAttrOpError(dev Device, attr, data, op string, err error) attrOpError {
 	return attrOpError{
 		dev:   dev,
+		name:  deviceName(dev),
 		attr:  attr,
 		data:  data,
 		op:    op,
@@ -357,7 +372,7 @@ func newAttrOpError(dev Device, attr, data, op string, err error) attrOpError {
 
 func (e attrOpError) Error() string {
 	return fmt.Sprintf("ev3dev: failed to %s %s %s attribute %s: %v at %s",
-		e.op, e.dev, e.attr, filepath.Join(e.dev.Path(), e.dev.String(), e.attr), e.err, e.caller(0))
+		e.op, e.name, e.attr, filepath.Join(e.dev.Path(), e.dev.String(), e.attr), e.err, e.caller(0))
 }
 
 func (e attrOpError) Format(fs fmt.State, c rune) {
@@ -389,6 +404,7 @@ func (e attrOpError) Unwrap() error { return e.err }
 
 type parseError struct {
 	dev  Device
+	name string
 	attr string
 	err  error
 
@@ -398,6 +414,7 @@ type parseError struct {
 func newParseError(dev Device, attr string, err error) parseError {
 	return parseError{
 		dev:   dev,
+		name:  deviceName(dev),
 		attr:  attr,
 		err:   err,
 		stack: callers(),
@@ -406,7 +423,7 @@ func newParseError(dev Device, attr string, err error) parseError {
 
 func (e parseError) Error() string {
 	return fmt.Sprintf("ev3dev: failed to parse %s %s attribute %s: %v at %s",
-		e.dev, e.attr, filepath.Join(e.dev.Path(), e.dev.String(), e.attr), e.err, e.caller(1))
+		e.name, e.attr, filepath.Join(e.dev.Path(), e.dev.String(), e.attr), e.err, e.caller(1))
 }
 
 func (e parseError) Format(fs fmt.State, c rune) {
@@ -436,10 +453,75 @@ func (e parseError) Format(fs fmt.State, c rune) {
 func (e parseError) Cause() error  { return e.err }
 func (e parseError) Unwrap() error { return e.err }
 
+var (
+	// addrLock and addrs cache device LEGO port addresses
+	// used for naming devices in error messages. Entries
+	// are removed by forgetName.
+	addrLock sync.Mutex
+	addrs    = make(map[string]string)
+)
+
+// deviceName returns a deterministic name for dev used in error messages.
+// The name is the device's class directory name followed by its LEGO port
+// address, for example "motor5(outA)". The address is read once for each
+// device and cached until the device is closed or a handle is set to its
+// ID. If the device has no address, only the class directory name is
+// returned.
+func deviceName(dev Device) string {
+	name := dev.String()
+	path := filepath.Join(dev.Path(), name)
+	addrLock.Lock()
+	defer addrLock.Unlock()
+	addr, ok := addrs[path]
+	if !ok {
+		b, err := ioutil.ReadFile(filepath.Join(path, address))
+		if err != nil {
+			// Don't cache failed reads since the
+			// device may not yet be fully registered
+			// or may have been removed.
+			return name
+		}
+		addr = strings.TrimPrefix(strings.TrimSpace(string(b)), "ev3-ports:")
+		addrs[path] = addr
+	}
+	if addr == "" {
+		return name
+	}
+	return name + "(" + addr + ")"
+}
+
+// forgetName removes the cached address of dev used by deviceName.
+// It is called when a handle is closed or its ID is set, since the
+// device's class directory may then refer to a different port.
+func forgetName(dev Device) {
+	path := filepath.Join(dev.Path(), dev.String())
+	addrLock.Lock()
+	delete(addrs, path)
+	addrLock.Unlock()
+}
+
 type syntaxError string
 
 func (e syntaxError) Error() string { return fmt.Sprintf("unexpected line: %q", string(e)) }
 
+// MalformedError is the cause of an attribute parse error for attribute
+// data that cannot be a complete value, for example data truncated or
+// corrupted by a device being unplugged during a read. It can be obtained
+// from a returned error using errors.As.
+type MalformedError struct {
+	// Data is the attribute
+	// data that was read.
+	Data string
+
+	// Reason describes why
+	// Data is malformed.
+	Reason string
+}
+
+func (e MalformedError) Error() string {
+	return fmt.Sprintf("malformed data %q: %s", e.Data, e.Reason)
+}
+
 type stack []uintptr
 
 func callers() stack {
@@ -7,6 +7,9 @@ package ev3dev
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -35,14 +38,14 @@ var errorTests = []struct {
 			return newInvalidValueError(mockDevice{}, "attr", "", "invalid", []string{"ok", "valid"})
 		},
 		wantErrorPrefix: `ev3dev: invalid value for mock attr: "invalid" (valid:["ok" "valid"]) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, attr:"attr", mesg:"", value:"invalid", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", mesg:"", value:"invalid", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 	{
 		fn: func() error {
 			return newInvalidValueError(mockDevice{}, "attr", "unexpected value", "surprise", []string{"ok", "valid"})
 		},
 		wantErrorPrefix: `ev3dev: unexpected value for mock attr: "surprise" (valid:["ok" "valid"]) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, attr:"attr", mesg:"unexpected value", value:"surprise", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", mesg:"unexpected value", value:"surprise", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 
 	{
@@ -62,7 +65,7 @@ var errorTests = []struct {
 			return newValueOutOfRangeError(mockDevice{}, "attr", 0, 1, 2)
 		},
 		wantErrorPrefix: `ev3dev: invalid value for mock attr: 0 (must be in 1-2) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.valueOutOfRangeError{dev:ev3dev.mockDevice{}, attr:"attr", value:0, min:1, max:2, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.valueOutOfRangeError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", value:0, min:1, max:2, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 
 	{
@@ -82,7 +85,7 @@ var errorTests = []struct {
 			return newIDErrorFor(mockDevice{}, -1)
 		},
 		wantErrorPrefix: `ev3dev: invalid id for mock: -1 (must be positive) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.idError{dev:ev3dev.mockDevice{}, attr:"", id:-1, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.idError{dev:ev3dev.mockDevice{}, name:"mock", attr:"", id:-1, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 
 	{
@@ -102,7 +105,7 @@ var errorTests = []struct {
 			return newNegativeDurationError(mockDevice{}, "attr", -1)
 		},
 		wantErrorPrefix: `ev3dev: invalid duration for mock attr: -1ns (must be positive) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.negativeDurationError{dev:ev3dev.mockDevice{}, attr:"attr", duration:-1, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.negativeDurationError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", duration:-1, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 
 	{
@@ -122,7 +125,7 @@ var errorTests = []struct {
 			return newDurationOutOfRangeError(mockDevice{}, "attr", 0, 1, 2)
 		},
 		wantErrorPrefix: `ev3dev: invalid duration for mock attr: 0s (must be in 1ns-2ns) at errors_test.go:`,
-		wantGoSyntax:    `ev3dev.durationOutOfRangeError{dev:ev3dev.mockDevice{}, attr:"attr", duration:0, min:1, max:2, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
+		wantGoSyntax:    `ev3dev.durationOutOfRangeError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", duration:0, min:1, max:2, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`,
 	},
 }
 
@@ -208,7 +211,7 @@ func TestStack(t *testing.T) {
 
 const (
 	// Expected output for go1.13 runtime.
-	wantGoSyntax113         = `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, attr:"attr", mesg:"", value:"invalid", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`
+	wantGoSyntax113         = `ev3dev.invalidValueError{dev:ev3dev.mockDevice{}, name:"mock", attr:"attr", mesg:"", value:"invalid", valid:[]string{"ok", "valid"}, stack:ev3dev.stack{0x0, 0x0, 0x0, 0x0, 0x0}}`
 	wantErrorTracePrefix113 = `ev3dev: invalid value for mock attr: "invalid" (valid:["ok" "valid"]) at stack_test.go:16 github.com/ev3go/ev3dev.init
 github.com/ev3go/ev3dev.init
 	stack_test.go:16
@@ -229,6 +232,110 @@ func TestPrintTrace(t *testing.T) {
 	}
 }
 
+type addressedDevice struct {
+	path string
+	id   string
+}
+
+func (d addressedDevice) Path() string   { return d.path }
+func (d addressedDevice) Type() string   { return "addressed" }
+func (d addressedDevice) Err() error     { return nil }
+func (d addressedDevice) String() string { return d.id }
+
+func TestDeviceName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, d := range []struct {
+		id, addr string
+	}{
+		{id: "motor5", addr: "ev3-ports:outA\n"},
+		{id: "sensor2", addr: "ev3-ports:in1:i2c1\n"},
+		{id: "sensor3", addr: ""},
+	} {
+		err = os.Mkdir(filepath.Join(dir, d.id), 0755)
+		if err != nil {
+			t.Fatalf("failed to create device directory: %v", err)
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, d.id, address), []byte(d.addr), 0644)
+		if err != nil {
+			t.Fatalf("failed to create address attribute: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		dev  Device
+		want string
+	}{
+		{dev: addressedDevice{path: dir, id: "motor5"}, want: "motor5(outA)"},
+		{dev: addressedDevice{path: dir, id: "sensor2"}, want: "sensor2(in1:i2c1)"},
+		{dev: addressedDevice{path: dir, id: "sensor3"}, want: "sensor3"},
+		{dev: addressedDevice{path: dir, id: "motor6"}, want: "motor6"},
+		{dev: mockDevice{}, want: "mock"},
+	} {
+		got := deviceName(test.dev)
+		if got != test.want {
+			t.Errorf("unexpected device name: got:%q want:%q", got, test.want)
+		}
+	}
+
+	// Check that the address is cached until the
+	// cached name is invalidated, and that the new
+	// address is then reported.
+	err = ioutil.WriteFile(filepath.Join(dir, "motor5", address), []byte("ev3-ports:outB\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to update address attribute: %v", err)
+	}
+	got := deviceName(addressedDevice{path: dir, id: "motor5"})
+	if got != "motor5(outA)" {
+		t.Errorf("unexpected cached device name: got:%q want:%q", got, "motor5(outA)")
+	}
+	forgetName(addressedDevice{path: dir, id: "motor5"})
+	got = deviceName(addressedDevice{path: dir, id: "motor5"})
+	if got != "motor5(outB)" {
+		t.Errorf("unexpected device name after invalidation: got:%q want:%q", got, "motor5(outB)")
+	}
+
+	err = newValueOutOfRangeError(addressedDevice{path: dir, id: "motor5"}, "speed_sp", 2000, -1050, 1050)
+	wantPrefix := "ev3dev: invalid value for motor5(outB) speed_sp: 2000 (must be in -1050-1050) at errors_test.go:"
+	if errStr := err.Error(); !strings.HasPrefix(errStr, wantPrefix) {
+		t.Errorf("unexpected error string:\ngot:\n\t%s\nwant prefix:\n\t%s", errStr, wantPrefix)
+	}
+}
+
+func TestDeviceNameClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m, err := TachoMotorFor("ev3-ports:outA", "lego-ev3-l-motor")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := deviceName(m); got != "motor0(outA)" {
+		t.Errorf("unexpected device name: got:%q want:%q", got, "motor0(outA)")
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, TachoMotorPath, "motor0", address), []byte("ev3-ports:outB\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to update address attribute: %v", err)
+	}
+	m.Close()
+	if got := deviceName(m); got != "motor0(outB)" {
+		t.Errorf("unexpected device name after close: got:%q want:%q", got, "motor0(outB)")
+	}
+}
+
 func hasAnyPrefix(q string, prefixes ...string) bool {
 	for _, pre := range prefixes {
 		if strings.HasPrefix(q, pre) {
@@ -0,0 +1,45 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "sync"
+
+// errLock guards the sticky error fields of motor handles so
+// that a handle may be shared between goroutines. A single lock
+// is used rather than a lock per handle since handles are copied
+// by value when they are found and snapshotted.
+var errLock sync.Mutex
+
+// loadErr returns the sticky error held in *p.
+func loadErr(p *error) error {
+	errLock.Lock()
+	err := *p
+	errLock.Unlock()
+	return err
+}
+
+// setErr records err in *p if err is not nil and no error is
+// already held, so that the first error in a call chain is the
+// one returned even when calls are made from more than one
+// goroutine.
+func setErr(p *error, err error) {
+	if err == nil {
+		return
+	}
+	errLock.Lock()
+	if *p == nil {
+		*p = err
+	}
+	errLock.Unlock()
+}
+
+// takeErr returns the sticky error held in *p and clears it.
+func takeErr(p *error) error {
+	errLock.Lock()
+	err := *p
+	*p = nil
+	errLock.Unlock()
+	return err
+}
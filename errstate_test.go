@@ -0,0 +1,67 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSharedTachoMotor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	attrs[speed] = "100"
+	attrs[dutyCycleSetpoint] = "0"
+	attrs[polarity] = "normal"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): attrs,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	const n = 200
+	m := &TachoMotor{id: 0}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs int
+	)
+	count := func(err error) {
+		if err != nil {
+			mu.Lock()
+			errs++
+			mu.Unlock()
+		}
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			// The duty cycle is out of range on odd
+			// iterations, setting the sticky error.
+			count(m.SetDutyCycleSetpoint(50 + 100*(i%2)).SetPolarity(Normal).Err())
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			_, err := m.Speed()
+			count(err)
+		}
+	}()
+	wg.Wait()
+	count(m.Err())
+	if errs != n/2 {
+		t.Errorf("unexpected number of errors: got:%d want:%d", errs, n/2)
+	}
+}
@@ -23,13 +23,28 @@
 // To avoid confusion caused by multiple writes to the same underlying device by
 // different handles, only one handle is allowed per physical device.
 //
+// Motor handles may be shared between goroutines, so a telemetry goroutine
+// may read the speed of a motor while a control goroutine issues commands to
+// it. The sticky error of a shared handle is shared by its users: an error
+// set by an action method called in one goroutine is returned by the next
+// result method called on the handle in any goroutine. Configuration methods
+// that do not touch the device, such as WithContext and SetGearRatio, should
+// be called before the handle is shared.
+//
 // In most cases, errors returned by functions in the ev3dev package implement
 // the Causer error interface and will be able to print a stack trace if printed
 // with the "+v" fmt verb.
+//
+// Attribute values are read from sysfs with a single read where possible, so
+// single value attributes are read atomically. The values of the uevent and
+// modes attributes may be regenerated by the kernel part way through a read
+// while devices are changing, so these are re-read until two consecutive
+// reads agree.
 package ev3dev
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -37,6 +52,7 @@ import (
 	"log"
 	"math"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -81,6 +97,9 @@ const (
 
 	// PowerSupplyPath is the path to the ev3 power supply file system.
 	PowerSupplyPath = "/sys/class/power_supply"
+
+	// InputPath is the path to the input device file system.
+	InputPath = "/sys/class/input"
 )
 
 // These are the subsystem path definitions for all device classes.
@@ -352,7 +371,7 @@ func IsConnected(d Device) (ok bool, err error) {
 // AddressOf returns the port address of the Device.
 func AddressOf(d Device) (string, error) {
 	path := filepath.Join(d.Path(), d.String(), address)
-	b, err := readFile(path)
+	b, err := readAttr(contextOf(d), path)
 	if err != nil {
 		return "", fmt.Errorf("ev3dev: failed to read %s address: %w", d.Type(), err)
 	}
@@ -362,7 +381,7 @@ func AddressOf(d Device) (string, error) {
 // DriverFor returns the driver name for the Device.
 func DriverFor(d Device) (string, error) {
 	path := filepath.Join(d.Path(), d.String(), driverName)
-	b, err := readFile(path)
+	b, err := readAttr(contextOf(d), path)
 	if err != nil {
 		return "", fmt.Errorf("ev3dev: failed to read %s driver name: %w", d.Type(), err)
 	}
@@ -375,6 +394,12 @@ func DriverFor(d Device) (string, error) {
 // If port is empty, the first device satisfying the driver name with an id after the
 // specified after parameter is returned.
 func deviceIDFor(port, driver string, d Device, after int) (int, error) {
+	return deviceIDMatching(port, driver, false, d, after)
+}
+
+// deviceIDMatching is deviceIDFor with driver interpreted as a path.Match
+// pattern if glob is true.
+func deviceIDMatching(port, driver string, glob bool, d Device, after int) (int, error) {
 	devNames, err := devicesIn(d.Path())
 	if err != nil {
 		return -1, fmt.Errorf("ev3dev: could not get devices for %s: %w", d.Path(), err)
@@ -386,6 +411,13 @@ func deviceIDFor(port, driver string, d Device, after int) (int, error) {
 
 	portBytes := []byte(port)
 	driverBytes := []byte(driver)
+	matches := func(drvr []byte) bool {
+		if glob {
+			ok, _ := path.Match(driver, string(drvr))
+			return ok
+		}
+		return bytes.Equal(driverBytes, drvr)
+	}
 	for _, device := range devices {
 		if port == "" {
 			if device.id <= after {
@@ -400,7 +432,7 @@ func deviceIDFor(port, driver string, d Device, after int) (int, error) {
 			if err != nil {
 				return -1, err
 			}
-			if !bytes.Equal(driverBytes, chomp(drvr)) {
+			if !matches(chomp(drvr)) {
 				continue
 			}
 			addr, err := probeAttributeFor(d, device.name, address)
@@ -417,7 +449,7 @@ func deviceIDFor(port, driver string, d Device, after int) (int, error) {
 		if err != nil {
 			return -1, err
 		}
-		if !bytes.Equal(portBytes, chomp(addr)) {
+		if !addressMatches(portBytes, chomp(addr)) {
 			continue
 		}
 		if inUse(d, addr) {
@@ -427,7 +459,7 @@ func deviceIDFor(port, driver string, d Device, after int) (int, error) {
 		if err != nil {
 			return -1, err
 		}
-		if !bytes.Equal(driverBytes, chomp(drvr)) {
+		if !matches(chomp(drvr)) {
 			err = DriverMismatch{Want: driver, Have: string(chomp(drvr))}
 		}
 		return device.id, err
@@ -444,7 +476,10 @@ func deviceIDFor(port, driver string, d Device, after int) (int, error) {
 
 func probeAttributeFor(d Device, name, attr string) ([]byte, error) {
 	path := filepath.Join(d.Path(), name, attr)
-	b, err := readFile(path)
+	// d may be a nil handle used only to
+	// identify the device type, so it has
+	// no default context.
+	b, err := readAttr(context.Background(), path)
 	if err != nil {
 		return nil, newAttrOpError(d, attr, string(b), "read", err)
 	}
@@ -469,6 +504,7 @@ func inUse(d Device, address []byte) bool {
 		typ = "in"
 	}
 	id := d.String()
+	address = chomp(address)
 
 	resLock.Lock()
 	defer resLock.Unlock()
@@ -526,13 +562,53 @@ func (d byID) Len() int           { return len(d) }
 func (d byID) Less(i, j int) bool { return d[i].id < d[j].id }
 func (d byID) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
 
+// ReadAttribute returns the value of the sysfs attribute name of the device
+// d with any trailing newline removed. It allows access to attributes that
+// are not wrapped by the device's methods, for example driver-specific
+// attributes. As with other result methods, the sticky error of d is
+// returned and cleared if it is set. Attributes in subdirectories of the
+// device's directory are named with a slash-separated path, for example
+// "hold_pid/Kp".
+func ReadAttribute(d Device, name string) (string, error) {
+	err := checkAttrName(d, name, "", "read")
+	if err != nil {
+		return "", err
+	}
+	return stringFrom(attributeOf(d, name))
+}
+
+// WriteAttribute writes value to the sysfs attribute name of the device d.
+// It allows access to attributes that are not wrapped by the device's
+// methods. WriteAttribute does not check or set the sticky error of d.
+func WriteAttribute(d Device, name, value string) error {
+	err := checkAttrName(d, name, value, "set")
+	if err != nil {
+		return err
+	}
+	return setAttributeOf(d, name, value)
+}
+
+// checkAttrName returns an error if name is not the name of a
+// file within the sysfs directory of a device.
+func checkAttrName(d Device, name, value, op string) error {
+	clean := filepath.Clean(name)
+	if name == "" || filepath.IsAbs(name) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return newAttrOpError(d, name, value, op, errors.New("invalid attribute name"))
+	}
+	return nil
+}
+
 func attributeOf(d Device, attr string) (dev Device, data string, _attr string, err error) {
 	err = d.Err()
 	if err != nil {
 		return d, "", "", err
 	}
+	err = ctxErr(d)
+	if err != nil {
+		return d, "", "", newAttrOpError(d, attr, "", "read", err)
+	}
 	path := filepath.Join(d.Path(), d.String(), attr)
-	b, err := readFile(path)
+	b, err := readAttr(contextOf(d), path)
 	if err != nil {
 		return d, "", "", newAttrOpError(d, attr, string(b), "read", err)
 	}
@@ -540,7 +616,7 @@ func attributeOf(d Device, attr string) (dev Device, data string, _attr string,
 }
 
 func chomp(b []byte) []byte {
-	if b[len(b)-1] == '\n' {
+	if len(b) != 0 && b[len(b)-1] == '\n' {
 		return b[:len(b)-1]
 	}
 	return b
@@ -550,6 +626,10 @@ func intFrom(d Device, data, attr string, err error) (int, error) {
 	if err != nil {
 		return -1, err
 	}
+	err = checkNumeric(data)
+	if err != nil {
+		return -1, newParseError(d, attr, err)
+	}
 	i, err := strconv.Atoi(data)
 	if err != nil {
 		return -1, newParseError(d, attr, err)
@@ -561,6 +641,10 @@ func float64From(d Device, data, attr string, err error) (float64, error) {
 	if err != nil {
 		return math.NaN(), err
 	}
+	err = checkNumeric(data)
+	if err != nil {
+		return math.NaN(), newParseError(d, attr, err)
+	}
 	f, err := strconv.ParseFloat(data, 64)
 	if err != nil {
 		return math.NaN(), newParseError(d, attr, err)
@@ -572,13 +656,36 @@ func durationFrom(dev Device, data, attr string, err error) (time.Duration, erro
 	if err != nil {
 		return -1, err
 	}
-	d, err := strconv.Atoi(data)
+	err = checkNumeric(data)
+	if err != nil {
+		return -1, newParseError(dev, attr, err)
+	}
+	d, err := strconv.ParseInt(data, 10, 64)
+	if err == nil && (d > math.MaxInt64/int64(time.Millisecond) || d < math.MinInt64/int64(time.Millisecond)) {
+		err = &strconv.NumError{Func: "ParseInt", Num: data, Err: strconv.ErrRange}
+	}
 	if err != nil {
 		return -1, newParseError(dev, attr, err)
 	}
 	return time.Duration(d) * time.Millisecond, nil
 }
 
+// checkNumeric returns a MalformedError if data cannot be a
+// complete numeric attribute value. This distinguishes empty
+// and truncated reads, for example during hot-unplug, from
+// values that are merely invalid.
+func checkNumeric(data string) error {
+	switch {
+	case data == "":
+		return MalformedError{Data: data, Reason: "empty value"}
+	case strings.IndexByte(data, 0) >= 0:
+		return MalformedError{Data: data, Reason: "embedded NUL"}
+	case strings.IndexByte(data, '\n') >= 0:
+		return MalformedError{Data: data, Reason: "multiple lines"}
+	}
+	return nil
+}
+
 func stringFrom(_ Device, data, _ string, err error) (string, error) {
 	return data, err
 }
@@ -618,23 +725,39 @@ func ueventFrom(d Device, data, attr string, err error) (map[string]string, erro
 	if len(data) == 0 {
 		return nil, nil
 	}
+	if strings.IndexByte(data, 0) >= 0 {
+		return nil, newParseError(d, attr, MalformedError{Data: data, Reason: "embedded NUL"})
+	}
 	uevent := make(map[string]string)
 	for _, l := range strings.Split(data, "\n") {
-		parts := strings.Split(l, "=")
-		if len(parts) != 2 {
+		if l == "" {
+			continue
+		}
+		// Values may legitimately contain '=',
+		// but keys may not.
+		parts := strings.SplitN(l, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
 			return nil, newParseError(d, attr, syntaxError(l))
 		}
 		uevent[parts[0]] = parts[1]
 	}
+	if len(uevent) == 0 {
+		return nil, nil
+	}
 	return uevent, nil
 }
 
 func setAttributeOf(d Device, attr, data string) error {
+	err := ctxErr(d)
+	if err != nil {
+		return newAttrOpError(d, attr, data, "set", err)
+	}
 	path := filepath.Join(d.Path(), d.String(), attr)
-	err := ioutil.WriteFile(path, []byte(data), 0)
+	err = writeAttr(contextOf(d), path, data)
 	if err != nil {
 		return newAttrOpError(d, attr, data, "set", err)
 	}
+	noteActivity(d, attr)
 	return nil
 }
 
@@ -642,16 +765,61 @@ var (
 	isTesting bool
 
 	// files and fileRegLock record files that have been opened
-	// during the life of the program. There is currently no
-	// mechanism to remove a file from the registry, but this is
-	// probably not a problem given that attached devices are
-	// extremely likely to remain attached for the life of the
-	// program.
+	// during the life of the program. Files are removed from the
+	// registry when a handle for their device is closed.
 	fileRegLock sync.Mutex
 	files       = make(map[string]*os.File)
 )
 
+// stableAttrs holds the attributes whose values must be
+// read until two consecutive reads agree to avoid torn reads.
+var stableAttrs = map[string]bool{
+	uevent: true,
+	modes:  true,
+}
+
+// maxTornReads is the maximum number of reads attempted
+// by readStable before giving up.
+const maxTornReads = 5
+
+// ErrTornRead is the cause of the error returned when a multi-line
+// attribute cannot be read consistently because its value keeps changing
+// during the read.
+var ErrTornRead = errors.New("value changed during read")
+
 func readFile(path string) ([]byte, error) {
+	attr := filepath.Base(path)
+	if !stableAttrs[attr] {
+		return readFileOnce(path)
+	}
+	return readStable(path, BufferSize(attr), readFileOnce)
+}
+
+// readStable reads the file at path using read. A value shorter than
+// the fast path buffer size was read by a single read from the start of
+// the file, which sysfs generates in one step, so it is returned without
+// being read again. Longer values may have been read in parts by the
+// ioutil.ReadFile fallback, so they are read until two consecutive reads
+// return the same content.
+func readStable(path string, size int, read func(string) ([]byte, error)) ([]byte, error) {
+	prev, err := read(path)
+	if err != nil || len(prev) < size {
+		return prev, err
+	}
+	for i := 1; i < maxTornReads; i++ {
+		b, err := read(path)
+		if err != nil {
+			return b, err
+		}
+		if bytes.Equal(b, prev) {
+			return b, nil
+		}
+		prev = b
+	}
+	return prev, ErrTornRead
+}
+
+func readFileOnce(path string) ([]byte, error) {
 	if isTesting {
 		// FIXME(kortschak): Make this work always.
 		//
@@ -681,8 +849,20 @@ func readFile(path string) ([]byte, error) {
 		// failed to read into short buffer.
 		return ioutil.ReadFile(path)
 	}
-	var buf [256]byte
-	n, err := f.ReadAt(buf[:], 0)
+	var buf []byte
+	size := BufferSize(filepath.Base(path))
+	if size <= defaultBufferSize {
+		var b [defaultBufferSize]byte
+		buf = b[:size]
+	} else {
+		buf = make([]byte, size)
+	}
+	n, err := f.ReadAt(buf, 0)
+	if errors.Is(err, os.ErrClosed) {
+		// The file was closed by the release of
+		// another handle for the same device.
+		return ioutil.ReadFile(path)
+	}
 	if err == nil {
 		// EV3 sysfs files are maximally 4096 byte
 		// (memory page size), but files are likely
@@ -694,8 +874,9 @@ func readFile(path string) ([]byte, error) {
 		// than len(buf). So we catch all the cases
 		// where the file is longer, with a small number
 		// of false positives where the file is exactly
-		// the length of the buffer. Bump the length
-		// of the buffer when that happens.
+		// the length of the buffer. Add an entry to
+		// defaultBufferSizes or call SetBufferSize when that
+		// happens.
 		log.Printf("ev3dev: buffer too short for %s: falling back to ioutil.ReadFile", path)
 		fileRegLock.Lock()
 		f.Close()
@@ -709,6 +890,74 @@ func readFile(path string) ([]byte, error) {
 	return buf[:n], err
 }
 
+// defaultBufferSize is the fast path read buffer size for attributes
+// without an entry in defaultBufferSizes.
+const defaultBufferSize = 256
+
+// maxBufferSize is the maximum size of a sysfs attribute.
+const maxBufferSize = 4096
+
+// defaultBufferSizes holds the fast path read buffer sizes for
+// attributes known to exceed defaultBufferSize. The sizes are
+// chosen to hold the longest values reported by the drivers
+// listed with each entry. defaultBufferSizes is not modified.
+var defaultBufferSizes = map[string]int{
+	// lego-ev3-color, lego-nxt-light, ht-nxt-color-v2,
+	// ms-ev3-smux and the nxt-i2c drivers.
+	modes: 512,
+	// lego-port and ev3-ports drivers with all modes.
+	commands: 512,
+	// leds with all kernel triggers registered.
+	trigger: 4096,
+	// Sensors with long module and driver names.
+	uevent: 512,
+}
+
+var (
+	// bufferSizes holds the buffer sizes
+	// set by SetBufferSize.
+	bufferSizes = make(map[string]int)
+	bufSizeLock sync.RWMutex
+)
+
+// BufferSize returns the read buffer size used for the named attribute.
+func BufferSize(attr string) int {
+	bufSizeLock.RLock()
+	n, ok := bufferSizes[attr]
+	bufSizeLock.RUnlock()
+	if ok {
+		return n
+	}
+	n, ok = defaultBufferSizes[attr]
+	if ok {
+		return n
+	}
+	return defaultBufferSize
+}
+
+// SetBufferSize sets the read buffer size used for the named attribute.
+// Attribute values longer than the buffer size are read using a slower
+// fallback path. If n is zero, the size for attr is reset to its default,
+// which is 256 bytes for attributes not known to hold longer values.
+// SetBufferSize returns an error if n is negative or greater than the
+// 4096 byte maximum size of a sysfs attribute.
+//
+// Reads of attributes that have previously fallen back to the slow path
+// are not affected by later calls to SetBufferSize.
+func SetBufferSize(attr string, n int) error {
+	if n < 0 || maxBufferSize < n {
+		return fmt.Errorf("ev3dev: invalid buffer size: %d (must be in 0-%d)", n, maxBufferSize)
+	}
+	bufSizeLock.Lock()
+	if n == 0 {
+		delete(bufferSizes, attr)
+	} else {
+		bufferSizes[attr] = n
+	}
+	bufSizeLock.Unlock()
+	return nil
+}
+
 func fileFor(path string) (*os.File, error) {
 	defer fileRegLock.Unlock()
 	fileRegLock.Lock()
@@ -0,0 +1,76 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Accelerometer sensor drivers.
+const (
+	htAccelDriver = "ht-nxt-accel"
+	msIMUDriver   = "ms-absolute-imu"
+)
+
+// htAccelPerG is the number of counts per g reported
+// by the HiTechnic accelerometer in ALL mode.
+const htAccelPerG = 200
+
+// AccelerometerSensor is a HiTechnic NXT acceleration sensor or the
+// accelerometer of a Mindsensors AbsoluteIMU.
+type AccelerometerSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewAccelerometerSensor returns an AccelerometerSensor for the sensor at
+// the given address. The sensor's driver must be ht-nxt-accel or
+// ms-absolute-imu.
+func NewAccelerometerSensor(address string) (*AccelerometerSensor, error) {
+	s, err := ev3dev.NewSensor(ev3dev.WithPort(address))
+	if err != nil {
+		return nil, err
+	}
+	switch d := s.Driver(); d {
+	case htAccelDriver, msIMUDriver:
+		return &AccelerometerSensor{s}, nil
+	default:
+		s.Close()
+		return nil, ev3dev.DriverMismatch{Want: htAccelDriver + " or " + msIMUDriver, Have: d}
+	}
+}
+
+// Acceleration returns the acceleration along the X, Y and Z axes of the
+// sensor in g.
+func (s *AccelerometerSensor) Acceleration() (x, y, z float64, err error) {
+	if s.Driver() == msIMUDriver {
+		return imuAcceleration(s.Sensor)
+	}
+
+	err = ensureMode(s.Sensor, "ALL")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	b, err := s.BinData()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return decodeHTAccel(b)
+}
+
+// decodeHTAccel decodes the HiTechnic accelerometer ALL mode bin data.
+// The data holds the signed upper 8 bits of the X, Y and Z axes followed
+// by the lower 2 bits of each axis.
+func decodeHTAccel(b []byte) (x, y, z float64, err error) {
+	if len(b) < 6 {
+		return 0, 0, 0, fmt.Errorf("ev3dev2: short acceleration data: %d bytes", len(b))
+	}
+	var axes [3]float64
+	for i := range axes {
+		axes[i] = float64(int(int8(b[i]))<<2|int(b[i+3]&0x3)) / htAccelPerG
+	}
+	return axes[0], axes[1], axes[2], nil
+}
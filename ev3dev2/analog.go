@@ -0,0 +1,128 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// analogDriver is the generic NXT analog sensor driver.
+const analogDriver = "nxt-analog"
+
+// AnalogSensor is a generic NXT analog sensor, for example a homemade or
+// third-party sensor without its own driver.
+type AnalogSensor struct {
+	*ev3dev.Sensor
+
+	// Transfer converts the voltage in
+	// volts on pin 1 of the sensor to a
+	// reading in the sensor's units. If
+	// Transfer is nil, Reading returns
+	// the voltage.
+	Transfer func(volts float64) float64
+
+	pin5 bool
+}
+
+// NewAnalogSensor returns an AnalogSensor for the sensor at the given
+// address. The port must already be in nxt-analog mode; see
+// ConfigureAnalogSensor.
+func NewAnalogSensor(address string) (*AnalogSensor, error) {
+	s, err := ev3dev.SensorFor(address, analogDriver)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalogSensor{Sensor: s}, nil
+}
+
+// ConfigureAnalogSensor places the input port at the given address in
+// nxt-analog mode, which loads the generic analog sensor driver, and
+// returns an AnalogSensor for the sensor once it appears, waiting up to
+// timeout. ConfigureAnalogSensor is needed for sensors that the port
+// cannot detect automatically.
+func ConfigureAnalogSensor(address string, timeout time.Duration) (*AnalogSensor, error) {
+	p, err := ev3dev.NewLegoPort(ev3dev.WithPort(address))
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+	if p.Mode() != analogDriver {
+		err = p.SetMode(analogDriver).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+	s, err := ev3dev.NewSensor(
+		ev3dev.WithPort(address),
+		ev3dev.WithDriver(analogDriver),
+		ev3dev.WithTimeout(timeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalogSensor{Sensor: s}, nil
+}
+
+// SetPin5 sets whether pin 5 of the sensor port is driven high, which
+// some sensors use to switch a light or select a measurement range.
+func (s *AnalogSensor) SetPin5(high bool) error {
+	err := ensureMode(s.Sensor, analogMode(high))
+	if err != nil {
+		return err
+	}
+	s.pin5 = high
+	return nil
+}
+
+// analogMode returns the mode for the given pin 5 setting.
+func analogMode(pin5 bool) string {
+	if pin5 {
+		return "ANALOG-1"
+	}
+	return "ANALOG-0"
+}
+
+// Voltage returns the voltage on pin 1 of the sensor in volts.
+func (s *AnalogSensor) Voltage() (float64, error) {
+	mv, err := scaledValue(s.Sensor, analogMode(s.pin5), 0)
+	if err != nil {
+		return 0, err
+	}
+	return mv / 1000, nil
+}
+
+// Reading returns the voltage on pin 1 of the sensor converted by
+// Transfer.
+func (s *AnalogSensor) Reading() (float64, error) {
+	v, err := s.Voltage()
+	if err != nil {
+		return 0, err
+	}
+	return transfer(s.Transfer, v), nil
+}
+
+// transfer returns v converted by fn, or v if fn is nil.
+func transfer(fn func(float64) float64, v float64) float64 {
+	if fn == nil {
+		return v
+	}
+	return fn(v)
+}
+
+// LinearTransfer returns a transfer function that maps voltages linearly
+// so that v0 volts reads as r0 and v1 volts reads as r1. LinearTransfer
+// panics if v0 and v1 are equal.
+func LinearTransfer(v0, r0, v1, r1 float64) func(volts float64) float64 {
+	if v0 == v1 {
+		panic(fmt.Sprintf("ev3dev2: invalid linear transfer: equal voltages %v", v0))
+	}
+	slope := (r1 - r0) / (v1 - v0)
+	return func(volts float64) float64 {
+		return r0 + (volts-v0)*slope
+	}
+}
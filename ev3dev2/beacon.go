@@ -0,0 +1,198 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// BeaconReading is the heading and distance of an EV3 infrared beacon.
+type BeaconReading struct {
+	// Channel is the channel,
+	// 1 to 4, of the beacon.
+	Channel int
+
+	// Heading is the heading of
+	// the beacon as described for
+	// InfraredSensor.Seek.
+	Heading int
+
+	// Distance is the distance
+	// to the beacon as described
+	// for InfraredSensor.Seek.
+	Distance int
+
+	// Visible is whether the beacon
+	// was seen. Heading and Distance
+	// are not meaningful if Visible
+	// is false.
+	Visible bool
+}
+
+// SeekBeacon returns the reading for the beacon on the given channel,
+// 1 to 4, of the remote control.
+func (s *InfraredSensor) SeekBeacon(channel int) (BeaconReading, error) {
+	err := checkChannel(channel)
+	if err != nil {
+		return BeaconReading{}, err
+	}
+	err = ensureMode(s.Sensor, "IR-SEEK")
+	if err != nil {
+		return BeaconReading{}, err
+	}
+	return s.readBeacon(channel)
+}
+
+// SeekBeacons places the sensor in IR-SEEK mode and returns a channel
+// that receives a reading for the beacon on the given channel, 1 to 4,
+// every interval. Readings are not buffered; if the receiver does not
+// keep up, readings are skipped. The channel is closed when ctx is done
+// or the sensor can no longer be read.
+func (s *InfraredSensor) SeekBeacons(ctx context.Context, channel int, interval time.Duration) (<-chan BeaconReading, error) {
+	err := checkChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("ev3dev2: invalid seek interval: %v (must be positive)", interval)
+	}
+	err = ensureMode(s.Sensor, "IR-SEEK")
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan BeaconReading)
+	go func() {
+		defer close(c)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			b, err := s.readBeacon(channel)
+			if err != nil {
+				return
+			}
+			select {
+			case c <- b:
+			case <-ticker.C:
+				continue
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// readBeacon reads the beacon on the given channel
+// with the sensor already in IR-SEEK mode.
+func (s *InfraredSensor) readBeacon(channel int) (BeaconReading, error) {
+	vals, err := s.Values()
+	if err != nil {
+		return BeaconReading{}, err
+	}
+	i := 2 * (channel - 1)
+	if len(vals) < i+2 {
+		return BeaconReading{}, fmt.Errorf("ev3dev2: too few seek values: %d", len(vals))
+	}
+	heading, err := strconv.Atoi(vals[i])
+	if err != nil {
+		return BeaconReading{}, err
+	}
+	distance, err := strconv.Atoi(vals[i+1])
+	if err != nil {
+		return BeaconReading{}, err
+	}
+	heading, distance = seekValues(heading, distance)
+	return BeaconReading{
+		Channel:  channel,
+		Heading:  heading,
+		Distance: distance,
+		Visible:  distance >= 0,
+	}, nil
+}
+
+// FollowBeacon suggests MoveSteering steering and speed values that drive
+// a robot towards an infrared beacon.
+//
+// For example, to follow the beacon on channel 1:
+//
+//	readings, err := ir.SeekBeacons(ctx, 1, 50*time.Millisecond)
+//	...
+//	f := ev3dev2.FollowBeacon{StopDistance: 10, SearchSpeed: 20}
+//	for b := range readings {
+//		err := steer.On(f.Steer(b))
+//		...
+//	}
+type FollowBeacon struct {
+	// Gain is the steering for each
+	// unit of heading. If Gain is zero,
+	// a gain of 4 is used so that the
+	// steering is ±100 at the extreme
+	// headings of ±25.
+	Gain float64
+
+	// Speed is the speed percentage
+	// suggested when the beacon is
+	// far away. If Speed is zero, a
+	// speed of 50% is used.
+	Speed float64
+
+	// StopDistance is the distance at
+	// or below which the suggested
+	// speed is zero. The suggested
+	// speed falls linearly towards
+	// zero as the beacon approaches
+	// StopDistance.
+	StopDistance int
+
+	// SearchSpeed is the speed
+	// percentage suggested for turning
+	// on the spot towards the side
+	// the beacon was last seen when it
+	// is not visible. If SearchSpeed is
+	// zero, the robot is stopped when
+	// the beacon is not visible.
+	SearchSpeed float64
+
+	lastHeading int
+}
+
+// Steer returns the suggested steering and speed for the reading b.
+func (f *FollowBeacon) Steer(b BeaconReading) (steering float64, speed SpeedPercent) {
+	if !b.Visible {
+		if f.SearchSpeed == 0 {
+			return 0, 0
+		}
+		if f.lastHeading < 0 {
+			return -100, SpeedPercent(f.SearchSpeed)
+		}
+		return 100, SpeedPercent(f.SearchSpeed)
+	}
+	f.lastHeading = b.Heading
+
+	gain := f.Gain
+	if gain == 0 {
+		gain = 4
+	}
+	steering = math.Max(-100, math.Min(float64(b.Heading)*gain, 100))
+
+	if b.Distance <= f.StopDistance {
+		return steering, 0
+	}
+	max := f.Speed
+	if max == 0 {
+		max = 50
+	}
+	frac := float64(b.Distance-f.StopDistance) / math.Max(1, float64(100-f.StopDistance))
+	return steering, SpeedPercent(max * math.Min(frac, 1))
+}
@@ -0,0 +1,150 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ev3go/ev3dev/calibration"
+)
+
+// Color is a color detected by the EV3 color sensor in COL-COLOR mode.
+type Color int
+
+// Colors detected by the EV3 color sensor.
+const (
+	NoColor Color = iota
+	Black
+	Blue
+	Green
+	Yellow
+	Red
+	White
+	Brown
+)
+
+// String satisfies the fmt.Stringer interface.
+func (c Color) String() string {
+	if c < 0 || len(colorNames) <= int(c) {
+		return "NoColor"
+	}
+	return colorNames[c]
+}
+
+// DetectedColor returns the detected color.
+func (s *ColorSensor) DetectedColor() (Color, error) {
+	c, err := s.Color()
+	if err != nil {
+		return NoColor, err
+	}
+	if c < 0 || len(colorNames) <= c {
+		return NoColor, nil
+	}
+	return Color(c), nil
+}
+
+// maxRaw is the largest raw RGB-RAW
+// component reported by the sensor.
+const maxRaw = 1020
+
+// HSV is a color in the hue, saturation, value color space.
+type HSV struct {
+	// H is the hue in degrees
+	// in the range [0, 360).
+	H float64
+
+	// S and V are the saturation
+	// and value in the range [0, 1].
+	S, V float64
+}
+
+// RGBToHSV returns the HSV color for the given red, green and blue
+// components in the range [0, 1].
+func RGBToHSV(r, g, b float64) HSV {
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	d := max - min
+
+	var h float64
+	switch {
+	case d == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/d, 6)
+	case max == g:
+		h = 60 * ((b-r)/d + 2)
+	default:
+		h = 60 * ((r-g)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	var s float64
+	if max != 0 {
+		s = d / max
+	}
+	return HSV{H: h, S: s, V: max}
+}
+
+// HSV returns the detected color in the HSV color space. The raw
+// components are scaled by the white reference reading set by
+// CalibrateWhite, or by the largest raw reading if the sensor has no
+// white reference. Scaled components are clamped to [0, 1].
+func (s *ColorSensor) HSV() (HSV, error) {
+	r, g, b, err := s.RGB()
+	if err != nil {
+		return HSV{}, err
+	}
+	return RGBToHSV(
+		normalize(r, s.cal.White.R),
+		normalize(g, s.cal.White.G),
+		normalize(b, s.cal.White.B),
+	), nil
+}
+
+// normalize returns the raw component v scaled by white, or
+// by maxRaw if white is not positive, clamped to [0, 1].
+func normalize(v, white int) float64 {
+	if white <= 0 {
+		white = maxRaw
+	}
+	return math.Max(0, math.Min(float64(v)/float64(white), 1))
+}
+
+// ClassifyRGB returns the name of the reference swatch in refs with the
+// raw reading closest to rgb. Closeness is the Euclidean distance between
+// the raw components. If refs is empty, ok is false.
+func ClassifyRGB(rgb calibration.RGB, refs map[string]calibration.RGB) (name string, ok bool) {
+	names := make([]string, 0, len(refs))
+	for n := range refs {
+		names = append(names, n)
+	}
+	// Sort the names so that ties
+	// are resolved deterministically.
+	sort.Strings(names)
+	best := math.Inf(1)
+	for _, n := range names {
+		ref := refs[n]
+		d := math.Hypot(math.Hypot(float64(rgb.R-ref.R), float64(rgb.G-ref.G)), float64(rgb.B-ref.B))
+		if d < best {
+			best = d
+			name = n
+		}
+	}
+	return name, len(names) != 0
+}
+
+// ClassifyColor returns the name of the reference swatch held in the
+// References of the sensor's calibration that is closest to the detected
+// color, as described for ClassifyRGB.
+func (s *ColorSensor) ClassifyColor() (name string, ok bool, err error) {
+	r, g, b, err := s.RGB()
+	if err != nil {
+		return "", false, err
+	}
+	name, ok = ClassifyRGB(calibration.RGB{R: r, G: g, B: b}, s.cal.References)
+	return name, ok, nil
+}
@@ -0,0 +1,66 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"math"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// CompassSensor is a HiTechnic NXT compass sensor.
+type CompassSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewCompassSensor returns a CompassSensor for the sensor at the given
+// address.
+func NewCompassSensor(address string) (*CompassSensor, error) {
+	s, err := ev3dev.SensorFor(address, "ht-nxt-compass")
+	if err != nil {
+		return nil, err
+	}
+	return &CompassSensor{s}, nil
+}
+
+// Heading returns the heading of the sensor in degrees clockwise from
+// magnetic north, in the range [0, 360).
+func (s *CompassSensor) Heading() (int, error) {
+	return intValue(s.Sensor, "COMPASS", 0)
+}
+
+// BeginCalibration starts calibration of the sensor. While the sensor is
+// calibrating, it should be rotated slowly through at least one and a half
+// full turns, taking about 20 seconds, before EndCalibration is called.
+func (s *CompassSensor) BeginCalibration() error {
+	return s.Command("BEGIN-CAL").Err()
+}
+
+// EndCalibration ends calibration of the sensor.
+func (s *CompassSensor) EndCalibration() error {
+	return s.Command("END-CAL").Err()
+}
+
+// NormalizeHeading returns the heading h in degrees
+// wrapped to the range [0, 360).
+func NormalizeHeading(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+// HeadingDifference returns the signed angle in degrees to turn from the
+// heading from to the heading to by the shortest path, taking wrap-around
+// at north into account. The result is in the range (-180, 180], and is
+// positive for a clockwise turn.
+func HeadingDifference(from, to float64) float64 {
+	d := NormalizeHeading(to - from)
+	if d > 180 {
+		d -= 360
+	}
+	return d
+}
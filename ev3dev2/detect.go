@@ -0,0 +1,91 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"io"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// sensorWrappers holds the constructors for the
+// typed sensor wrappers keyed by sensor driver.
+var sensorWrappers = map[string]func(address string) (ev3dev.Device, error){
+	"lego-ev3-touch":  func(a string) (ev3dev.Device, error) { return NewTouchSensor(a) },
+	"lego-ev3-color":  func(a string) (ev3dev.Device, error) { return NewColorSensor(a) },
+	"lego-ev3-gyro":   func(a string) (ev3dev.Device, error) { return NewGyroSensor(a) },
+	"lego-ev3-us":     func(a string) (ev3dev.Device, error) { return NewUltrasonicSensor(a) },
+	"lego-ev3-ir":     func(a string) (ev3dev.Device, error) { return NewInfraredSensor(a) },
+	"lego-nxt-light":  func(a string) (ev3dev.Device, error) { return NewLightSensor(a) },
+	"lego-nxt-sound":  func(a string) (ev3dev.Device, error) { return NewSoundSensor(a) },
+	"lego-nxt-temp":   func(a string) (ev3dev.Device, error) { return NewTemperatureSensor(a) },
+	"ht-nxt-compass":  func(a string) (ev3dev.Device, error) { return NewCompassSensor(a) },
+	"ht-nxt-accel":    func(a string) (ev3dev.Device, error) { return NewAccelerometerSensor(a) },
+	"ms-absolute-imu": func(a string) (ev3dev.Device, error) { return NewAbsoluteIMU(a) },
+	"nxt-analog":      func(a string) (ev3dev.Device, error) { return NewAnalogSensor(a) },
+}
+
+// DetectSensors returns handles for all the sensors connected to the
+// system, ordered by the address of the port they are attached to. Sensors with a typed wrapper in this package
+// are returned as that type, for example *GyroSensor for lego-ev3-gyro
+// sensors, and other sensors are returned as *ev3dev.Sensor. A type switch
+// can be used to pick out the sensors a program needs:
+//
+//	sensors, err := ev3dev2.DetectSensors()
+//	...
+//	for _, s := range sensors {
+//		switch s := s.(type) {
+//		case *ev3dev2.GyroSensor:
+//			...
+//		case *ev3dev2.ColorSensor:
+//			...
+//		}
+//	}
+//
+// If any sensor cannot be opened, the sensors already opened are closed
+// and the error is returned.
+func DetectSensors() ([]ev3dev.Device, error) {
+	t, err := ev3dev.ReadDeviceTree()
+	if err != nil {
+		return nil, err
+	}
+	var sensors []ev3dev.Device
+	for _, n := range sensorNodes(t) {
+		var (
+			s   ev3dev.Device
+			err error
+		)
+		if wrap, ok := sensorWrappers[n.Driver]; ok {
+			s, err = wrap(n.Address)
+		} else {
+			s, err = ev3dev.SensorFor(n.Address, n.Driver)
+		}
+		if err != nil {
+			for _, s := range sensors {
+				s.(io.Closer).Close()
+			}
+			return nil, err
+		}
+		sensors = append(sensors, s)
+	}
+	return sensors, nil
+}
+
+// sensorNodes returns the lego-sensor nodes of t in port order.
+func sensorNodes(t *ev3dev.DeviceTree) []*ev3dev.TreeNode {
+	var nodes []*ev3dev.TreeNode
+	collect := func(devices []*ev3dev.TreeNode) {
+		for _, d := range devices {
+			if d.Class == "lego-sensor" {
+				nodes = append(nodes, d)
+			}
+		}
+	}
+	for _, p := range t.Ports {
+		collect(p.Devices)
+	}
+	collect(t.Unattached)
+	return nodes
+}
@@ -0,0 +1,14 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ev3dev2 provides a thin compatibility layer over the ev3dev package
+// with names and semantics resembling the python-ev3dev2 library.
+//
+// The package is intended to ease porting of existing Python programs and
+// teaching material to Go. Types such as LargeMotor, MoveTank, MoveSteering
+// and ColorSensor behave like their Python counterparts, but methods return
+// errors rather than raising exceptions, and Python properties are exposed
+// as methods. The underlying ev3dev handles are embedded in each type so
+// the full native API remains available.
+package ev3dev2
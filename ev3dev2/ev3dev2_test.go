@@ -0,0 +1,665 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+	"github.com/ev3go/ev3dev/calibration"
+)
+
+var nativeUnitsTests = []struct {
+	speed                 Speed
+	countPerRot, maxSpeed int
+
+	want    float64
+	wantErr bool
+}{
+	{speed: SpeedPercent(50), countPerRot: 360, maxSpeed: 1050, want: 525},
+	{speed: SpeedPercent(-100), countPerRot: 360, maxSpeed: 1050, want: -1050},
+	{speed: SpeedPercent(101), countPerRot: 360, maxSpeed: 1050, wantErr: true},
+	{speed: SpeedNativeUnits(300), countPerRot: 360, maxSpeed: 1050, want: 300},
+	{speed: SpeedNativeUnits(-1051), countPerRot: 360, maxSpeed: 1050, wantErr: true},
+	{speed: SpeedRPS(2), countPerRot: 360, maxSpeed: 1050, want: 720},
+	{speed: SpeedRPM(60), countPerRot: 360, maxSpeed: 1050, want: 360},
+	{speed: SpeedDPS(-180), countPerRot: 360, maxSpeed: 1050, want: -180},
+	{speed: SpeedDPM(21600), countPerRot: 360, maxSpeed: 1050, want: 360},
+	{speed: SpeedRPS(4), countPerRot: 360, maxSpeed: 1050, wantErr: true},
+}
+
+func TestNativeUnits(t *testing.T) {
+	for _, test := range nativeUnitsTests {
+		got, err := test.speed.NativeUnits(test.countPerRot, test.maxSpeed)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %T(%v): %v", test.speed, test.speed, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected native speed for %T(%v): got:%v want:%v", test.speed, test.speed, got, test.want)
+		}
+	}
+}
+
+var countsForTests = []struct {
+	speed, degrees float64
+	countPerRot    int
+	want           int
+}{
+	{speed: 100, degrees: 90, countPerRot: 360, want: 90},
+	{speed: -100, degrees: 90, countPerRot: 360, want: -90},
+	{speed: 100, degrees: -90, countPerRot: 360, want: -90},
+	{speed: -100, degrees: -90, countPerRot: 360, want: 90},
+	{speed: 100, degrees: 360, countPerRot: 180, want: 180},
+	{speed: 100, degrees: 1, countPerRot: 180, want: 1},
+}
+
+func TestCountsFor(t *testing.T) {
+	for _, test := range countsForTests {
+		got := countsFor(test.speed, test.degrees, test.countPerRot)
+		if got != test.want {
+			t.Errorf("unexpected counts for speed=%v degrees=%v: got:%d want:%d", test.speed, test.degrees, got, test.want)
+		}
+	}
+}
+
+var tankDegreesTests = []struct {
+	left, right, degrees float64
+	wantLeft, wantRight  float64
+}{
+	{left: 100, right: 100, degrees: 360, wantLeft: 360, wantRight: 360},
+	{left: 100, right: 50, degrees: 360, wantLeft: 360, wantRight: 180},
+	{left: 25, right: -100, degrees: 360, wantLeft: 90, wantRight: 360},
+	{left: 0, right: 0, degrees: 360, wantLeft: 360, wantRight: 360},
+	{left: 100, right: 50, degrees: 0, wantLeft: 0, wantRight: 0},
+}
+
+func TestTankDegrees(t *testing.T) {
+	for _, test := range tankDegreesTests {
+		gotLeft, gotRight := tankDegrees(test.left, test.right, test.degrees)
+		if gotLeft != test.wantLeft || gotRight != test.wantRight {
+			t.Errorf("unexpected degrees for left=%v right=%v: got:(%v, %v) want:(%v, %v)",
+				test.left, test.right, gotLeft, gotRight, test.wantLeft, test.wantRight)
+		}
+	}
+}
+
+var steeringSpeedsTests = []struct {
+	steering, speed     float64
+	wantLeft, wantRight float64
+}{
+	{steering: 0, speed: 500, wantLeft: 500, wantRight: 500},
+	{steering: 25, speed: 500, wantLeft: 500, wantRight: 250},
+	{steering: -25, speed: 500, wantLeft: 250, wantRight: 500},
+	{steering: 50, speed: 500, wantLeft: 500, wantRight: 0},
+	{steering: -100, speed: 500, wantLeft: -500, wantRight: 500},
+	{steering: 100, speed: -500, wantLeft: -500, wantRight: 500},
+}
+
+func TestSteeringSpeeds(t *testing.T) {
+	for _, test := range steeringSpeedsTests {
+		gotLeft, gotRight := steeringSpeeds(test.steering, test.speed)
+		if math.Abs(gotLeft-test.wantLeft) > 1e-9 || math.Abs(gotRight-test.wantRight) > 1e-9 {
+			t.Errorf("unexpected speeds for steering=%v speed=%v: got:(%v, %v) want:(%v, %v)",
+				test.steering, test.speed, gotLeft, gotRight, test.wantLeft, test.wantRight)
+		}
+	}
+}
+
+func TestWaitNotMoving(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		states []ev3dev.MotorState
+	}{
+		{name: "stalled", states: []ev3dev.MotorState{ev3dev.Running, ev3dev.Running, ev3dev.Running | ev3dev.Stalled}},
+		{name: "stopped", states: []ev3dev.MotorState{ev3dev.Running, ev3dev.Running | ev3dev.Ramping, 0}},
+	} {
+		var reads int
+		state := func() (ev3dev.MotorState, error) {
+			if reads == len(test.states) {
+				t.Fatalf("%s: state read after wait should have returned", test.name)
+			}
+			stat := test.states[reads]
+			reads++
+			return stat, nil
+		}
+		_, ok, err := waitNotMoving(state, 0, -1)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+		}
+		if !ok {
+			t.Errorf("unexpected timeout for %s", test.name)
+		}
+		if reads != len(test.states) {
+			t.Errorf("unexpected number of state reads for %s: got:%d want:%d", test.name, reads, len(test.states))
+		}
+	}
+
+	running := func() (ev3dev.MotorState, error) { return ev3dev.Running, nil }
+	stat, ok, err := waitNotMoving(running, time.Millisecond, 5*time.Millisecond)
+	if err != nil {
+		t.Errorf("unexpected error for running motor: %v", err)
+	}
+	if ok || stat != ev3dev.Running {
+		t.Errorf("unexpected result for running motor: got:(%v, %t) want:(%v, false)", stat, ok, ev3dev.Running)
+	}
+
+	errFailed := errors.New("read failed")
+	_, _, err = waitNotMoving(func() (ev3dev.MotorState, error) { return 0, errFailed }, 0, -1)
+	if err != errFailed {
+		t.Errorf("unexpected error: got:%v want:%v", err, errFailed)
+	}
+}
+
+func TestSeekValues(t *testing.T) {
+	for _, test := range []struct {
+		heading, distance         int
+		wantHeading, wantDistance int
+	}{
+		{heading: -12, distance: 40, wantHeading: -12, wantDistance: 40},
+		{heading: 25, distance: 0, wantHeading: 25, wantDistance: 0},
+		{heading: 0, distance: -128, wantHeading: 0, wantDistance: -1},
+	} {
+		heading, distance := seekValues(test.heading, test.distance)
+		if heading != test.wantHeading || distance != test.wantDistance {
+			t.Errorf("unexpected seek values for %d,%d: got:%d,%d want:%d,%d",
+				test.heading, test.distance, heading, distance, test.wantHeading, test.wantDistance)
+		}
+	}
+}
+
+func TestRemoteButtons(t *testing.T) {
+	for _, test := range []struct {
+		code    int
+		want    RemoteButton
+		name    string
+		wantErr bool
+	}{
+		{code: 0, want: 0, name: "None"},
+		{code: 1, want: RedUp, name: "RedUp"},
+		{code: 4, want: BlueDown, name: "BlueDown"},
+		{code: 7, want: RedDown | BlueUp, name: "RedDown|BlueUp"},
+		{code: 9, want: Beacon, name: "Beacon"},
+		{code: 11, want: BlueUp | BlueDown, name: "BlueUp|BlueDown"},
+		{code: 12, wantErr: true},
+		{code: -1, wantErr: true},
+	} {
+		got, err := remoteButtons(test.code)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for code %d: %v", test.code, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected buttons for code %d: got:%v want:%v", test.code, got, test.want)
+		}
+		if !test.wantErr && got.String() != test.name {
+			t.Errorf("unexpected name for code %d: got:%q want:%q", test.code, got, test.name)
+		}
+	}
+	for _, c := range []int{0, 5} {
+		if checkChannel(c) == nil {
+			t.Errorf("expected error for channel %d", c)
+		}
+	}
+}
+
+func TestRemoteEvents(t *testing.T) {
+	var buttons [4]RemoteButton
+	for _, test := range []struct {
+		codes   [4]int
+		want    []RemoteEvent
+		wantErr bool
+	}{
+		{
+			codes: [4]int{0, 0, 0, 0},
+		},
+		{
+			codes: [4]int{1, 0, 9, 0},
+			want: []RemoteEvent{
+				{Channel: 1, Button: RedUp, Pressed: true},
+				{Channel: 3, Button: Beacon, Pressed: true},
+			},
+		},
+		{
+			codes: [4]int{5, 0, 9, 0},
+			want: []RemoteEvent{
+				{Channel: 1, Button: BlueUp, Pressed: true},
+			},
+		},
+		{
+			codes: [4]int{3, 0, 0, 2},
+			want: []RemoteEvent{
+				{Channel: 1, Button: RedUp, Pressed: false},
+				{Channel: 3, Button: Beacon, Pressed: false},
+				{Channel: 4, Button: RedDown, Pressed: true},
+			},
+		},
+		{
+			codes:   [4]int{12, 0, 0, 0},
+			wantErr: true,
+		},
+		{
+			codes: [4]int{0, 0, 0, 2},
+			want: []RemoteEvent{
+				{Channel: 1, Button: BlueUp, Pressed: false},
+			},
+		},
+	} {
+		var (
+			got []RemoteEvent
+			err error
+		)
+		got, buttons, err = remoteEvents(nil, buttons, test.codes)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for codes %v: %v", test.codes, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected events for codes %v: got:%v want:%v", test.codes, got, test.want)
+		}
+	}
+}
+
+func TestFollowBeacon(t *testing.T) {
+	var f FollowBeacon
+	for _, test := range []struct {
+		reading      BeaconReading
+		wantSteering float64
+		wantSpeed    SpeedPercent
+	}{
+		{reading: BeaconReading{Heading: 0, Distance: 100, Visible: true}, wantSteering: 0, wantSpeed: 50},
+		{reading: BeaconReading{Heading: -5, Distance: 50, Visible: true}, wantSteering: -20, wantSpeed: 25},
+		{reading: BeaconReading{Heading: 25, Distance: 0, Visible: true}, wantSteering: 100, wantSpeed: 0},
+		{reading: BeaconReading{Distance: -1}, wantSteering: 0, wantSpeed: 0},
+	} {
+		steering, speed := f.Steer(test.reading)
+		if steering != test.wantSteering || speed != test.wantSpeed {
+			t.Errorf("unexpected suggestion for %+v: got:%v,%v want:%v,%v",
+				test.reading, steering, speed, test.wantSteering, test.wantSpeed)
+		}
+	}
+
+	f = FollowBeacon{Gain: 10, Speed: 80, StopDistance: 20, SearchSpeed: 30}
+	for _, test := range []struct {
+		reading      BeaconReading
+		wantSteering float64
+		wantSpeed    SpeedPercent
+	}{
+		{reading: BeaconReading{Distance: -1}, wantSteering: 100, wantSpeed: 30},
+		{reading: BeaconReading{Heading: -12, Distance: 60, Visible: true}, wantSteering: -100, wantSpeed: 40},
+		{reading: BeaconReading{Heading: 3, Distance: 20, Visible: true}, wantSteering: 30, wantSpeed: 0},
+		{reading: BeaconReading{Heading: -1, Distance: 120, Visible: true}, wantSteering: -10, wantSpeed: 80},
+		{reading: BeaconReading{Distance: -1}, wantSteering: -100, wantSpeed: 30},
+	} {
+		steering, speed := f.Steer(test.reading)
+		if steering != test.wantSteering || speed != test.wantSpeed {
+			t.Errorf("unexpected suggestion for %+v: got:%v,%v want:%v,%v",
+				test.reading, steering, speed, test.wantSteering, test.wantSpeed)
+		}
+	}
+}
+
+func TestScaleReflect(t *testing.T) {
+	for _, test := range []struct {
+		v, black, white float64
+		want            float64
+	}{
+		{v: 6, black: 6, white: 86, want: 0},
+		{v: 86, black: 6, white: 86, want: 100},
+		{v: 46, black: 6, white: 86, want: 50},
+		{v: 2, black: 6, white: 86, want: 0},
+		{v: 95, black: 6, white: 86, want: 100},
+	} {
+		got := scaleReflect(test.v, test.black, test.white)
+		if got != test.want {
+			t.Errorf("unexpected scaled reflectance for %v in [%v,%v]: got:%v want:%v",
+				test.v, test.black, test.white, got, test.want)
+		}
+	}
+
+	var s ColorSensor
+	_, err := s.CalibratedReflect()
+	if err == nil {
+		t.Error("expected error for uncalibrated sensor")
+	}
+	c := calibration.Color{BlackReflect: 6, WhiteReflect: 86}
+	s.SetCalibration(c)
+	if got := s.Calibration(); !reflect.DeepEqual(got, c) {
+		t.Errorf("unexpected calibration: got:%+v want:%+v", got, c)
+	}
+}
+
+func TestColorString(t *testing.T) {
+	for _, test := range []struct {
+		c    Color
+		want string
+	}{
+		{c: NoColor, want: "NoColor"},
+		{c: Yellow, want: "Yellow"},
+		{c: Brown, want: "Brown"},
+		{c: Brown + 1, want: "NoColor"},
+		{c: -1, want: "NoColor"},
+	} {
+		if got := test.c.String(); got != test.want {
+			t.Errorf("unexpected name for %d: got:%q want:%q", int(test.c), got, test.want)
+		}
+	}
+	if ColorRed != int(Red) {
+		t.Errorf("mismatched red constants: ColorRed=%d Red=%d", ColorRed, Red)
+	}
+}
+
+func TestRGBToHSV(t *testing.T) {
+	const tol = 1e-12
+	for _, test := range []struct {
+		r, g, b float64
+		want    HSV
+	}{
+		{r: 0, g: 0, b: 0, want: HSV{}},
+		{r: 1, g: 1, b: 1, want: HSV{H: 0, S: 0, V: 1}},
+		{r: 1, g: 0, b: 0, want: HSV{H: 0, S: 1, V: 1}},
+		{r: 0, g: 0.5, b: 0, want: HSV{H: 120, S: 1, V: 0.5}},
+		{r: 0, g: 0, b: 1, want: HSV{H: 240, S: 1, V: 1}},
+		{r: 1, g: 1, b: 0, want: HSV{H: 60, S: 1, V: 1}},
+		{r: 1, g: 0, b: 0.5, want: HSV{H: 330, S: 1, V: 1}},
+		{r: 0.5, g: 0.25, b: 0.25, want: HSV{H: 0, S: 0.5, V: 0.5}},
+	} {
+		got := RGBToHSV(test.r, test.g, test.b)
+		if math.Abs(got.H-test.want.H) > tol || math.Abs(got.S-test.want.S) > tol || math.Abs(got.V-test.want.V) > tol {
+			t.Errorf("unexpected HSV for (%v,%v,%v): got:%+v want:%+v",
+				test.r, test.g, test.b, got, test.want)
+		}
+	}
+	if got := normalize(510, 0); got != 0.5 {
+		t.Errorf("unexpected uncalibrated normalization: got:%v want:0.5", got)
+	}
+	if got := normalize(300, 250); got != 1 {
+		t.Errorf("unexpected clamped normalization: got:%v want:1", got)
+	}
+}
+
+func TestClassifyRGB(t *testing.T) {
+	refs := map[string]calibration.RGB{
+		"red":    {R: 200, G: 30, B: 20},
+		"orange": {R: 220, G: 110, B: 20},
+		"blue":   {R: 20, G: 40, B: 180},
+	}
+	for _, test := range []struct {
+		rgb  calibration.RGB
+		want string
+	}{
+		{rgb: calibration.RGB{R: 190, G: 40, B: 25}, want: "red"},
+		{rgb: calibration.RGB{R: 230, G: 100, B: 10}, want: "orange"},
+		{rgb: calibration.RGB{R: 10, G: 10, B: 250}, want: "blue"},
+	} {
+		got, ok := ClassifyRGB(test.rgb, refs)
+		if !ok || got != test.want {
+			t.Errorf("unexpected class for %+v: got:%q,%t want:%q", test.rgb, got, ok, test.want)
+		}
+	}
+	_, ok := ClassifyRGB(calibration.RGB{}, nil)
+	if ok {
+		t.Error("unexpected classification with no references")
+	}
+}
+
+func TestCheckGyroReset(t *testing.T) {
+	for _, test := range []struct {
+		angle, rate int
+		wantErr     bool
+	}{
+		{angle: 0, rate: 0},
+		{angle: 0, rate: -1},
+		{angle: 3, rate: 0, wantErr: true},
+		{angle: 0, rate: 441, wantErr: true},
+		{angle: 0, rate: -32768, wantErr: true},
+	} {
+		err := checkGyroReset(test.angle, test.rate)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for angle=%d rate=%d: %v", test.angle, test.rate, err)
+		}
+	}
+}
+
+func TestPresenceEvents(t *testing.T) {
+	src := make(chan float64)
+	dst := make(chan PresenceEvent)
+	go presenceEvents(context.Background(), src, dst)
+	go func() {
+		for _, v := range []float64{0, 1, 1, 0, 0, 1} {
+			src <- v
+		}
+		close(src)
+	}()
+	var got []bool
+	for e := range dst {
+		got = append(got, e.Present)
+	}
+	want := []bool{false, true, false, true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected presence events: got:%v want:%v", got, want)
+	}
+}
+
+func TestHeadingDifference(t *testing.T) {
+	for _, test := range []struct {
+		from, to float64
+		want     float64
+	}{
+		{from: 0, to: 90, want: 90},
+		{from: 90, to: 0, want: -90},
+		{from: 350, to: 10, want: 20},
+		{from: 10, to: 350, want: -20},
+		{from: 0, to: 180, want: 180},
+		{from: 180, to: 0, want: 180},
+		{from: 45, to: 45, want: 0},
+		{from: -30, to: 720, want: 30},
+	} {
+		got := HeadingDifference(test.from, test.to)
+		if got != test.want {
+			t.Errorf("unexpected heading difference from %v to %v: got:%v want:%v",
+				test.from, test.to, got, test.want)
+		}
+	}
+	for _, test := range []struct {
+		h, want float64
+	}{
+		{h: 0, want: 0},
+		{h: 360, want: 0},
+		{h: -90, want: 270},
+		{h: 725, want: 5},
+	} {
+		if got := NormalizeHeading(test.h); got != test.want {
+			t.Errorf("unexpected normalized heading for %v: got:%v want:%v", test.h, got, test.want)
+		}
+	}
+}
+
+func TestDecodeHTAccel(t *testing.T) {
+	for _, test := range []struct {
+		data    []byte
+		want    [3]float64
+		wantErr bool
+	}{
+		{data: []byte{0, 0, 50, 0, 0, 0}, want: [3]float64{0, 0, 1}},
+		{data: []byte{0xce, 25, 0, 0, 0, 0}, want: [3]float64{-1, 0.5, 0}},
+		{data: []byte{0xff, 0, 0x7f, 0x3, 0x2, 0x3}, want: [3]float64{-1.0 / 200, 2.0 / 200, 511.0 / 200}},
+		{data: []byte{0xce, 25, 0, 0, 0}, wantErr: true},
+	} {
+		x, y, z, err := decodeHTAccel(test.data)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %v: %v", test.data, err)
+			continue
+		}
+		if got := [3]float64{x, y, z}; got != test.want {
+			t.Errorf("unexpected acceleration for %v: got:%v want:%v", test.data, got, test.want)
+		}
+	}
+}
+
+func TestAbsoluteIMUAccelerationRange(t *testing.T) {
+	var s AbsoluteIMU
+	for _, g := range []int{0, 1, 3, 32} {
+		if s.SetAccelerationRange(g) == nil {
+			t.Errorf("expected error for acceleration range %dg", g)
+		}
+	}
+}
+
+func TestTemperatureAlerts(t *testing.T) {
+	src := make(chan float64)
+	dst := make(chan TemperatureAlert)
+	go temperatureAlerts(context.Background(), 36, 38, src, dst)
+	go func() {
+		for _, v := range []float64{37, 37.5, 38.2, 38.5, 37.9, 35.8, 36} {
+			src <- v
+		}
+		close(src)
+	}()
+	type alert struct {
+		celsius float64
+		r       TemperatureRange
+	}
+	var got []alert
+	for a := range dst {
+		got = append(got, alert{celsius: a.Celsius, r: a.Range})
+	}
+	want := []alert{
+		{celsius: 37, r: InRange},
+		{celsius: 38.2, r: AboveRange},
+		{celsius: 37.9, r: InRange},
+		{celsius: 35.8, r: BelowRange},
+		{celsius: 36, r: InRange},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected temperature alerts: got:%v want:%v", got, want)
+	}
+	if s := BelowRange.String(); s != "BelowRange" {
+		t.Errorf("unexpected range name: got:%q want:%q", s, "BelowRange")
+	}
+}
+
+func TestClapDetector(t *testing.T) {
+	var d ClapDetector
+	start := time.Unix(0, 0)
+	var got []int
+	for i, level := range []float64{
+		// A sound already loud at the start is ignored.
+		70, 20,
+		// A clap.
+		10, 80, 90, 20,
+		// A sustained loud sound.
+		10, 70, 70, 70, 70, 70, 70, 10,
+		// A peak that does not reach the threshold.
+		40, 50, 10,
+		// A second clap.
+		65, 25,
+	} {
+		if d.Sample(start.Add(time.Duration(i)*50*time.Millisecond), level) {
+			got = append(got, i)
+		}
+	}
+	want := []int{5, 18}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected claps: got:%v want:%v", got, want)
+	}
+}
+
+func TestCalibratedReflect(t *testing.T) {
+	errRead := errors.New("read failed")
+	for _, test := range []struct {
+		cal     calibration.Color
+		v       float64
+		readErr error
+		want    float64
+		wantErr bool
+	}{
+		{cal: calibration.Color{BlackReflect: 10, WhiteReflect: 60}, v: 35, want: 50},
+		{cal: calibration.Color{BlackReflect: 10, WhiteReflect: 60}, v: 70, want: 100},
+		{cal: calibration.Color{BlackReflect: 60, WhiteReflect: 10}, v: 35, wantErr: true},
+		{cal: calibration.Color{BlackReflect: 10, WhiteReflect: 60}, readErr: errRead, wantErr: true},
+	} {
+		got, err := calibratedReflect(test.cal, func() (float64, error) { return test.v, test.readErr })
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %+v: %v", test.cal, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected calibrated reflectance for %v with %+v: got:%v want:%v", test.v, test.cal, got, test.want)
+		}
+	}
+
+	var s LightSensor
+	_, err := s.CalibratedReflect()
+	if err == nil {
+		t.Error("expected error for uncalibrated sensor")
+	}
+}
+
+func TestAnalogTransfer(t *testing.T) {
+	if got := transfer(nil, 2.5); got != 2.5 {
+		t.Errorf("unexpected identity transfer: got:%v want:2.5", got)
+	}
+	// A thermistor circuit reading -10°C at 4V and 40°C at 1.5V.
+	fn := LinearTransfer(4, -10, 1.5, 40)
+	for _, test := range []struct {
+		volts, want float64
+	}{
+		{volts: 4, want: -10},
+		{volts: 1.5, want: 40},
+		{volts: 2.75, want: 15},
+	} {
+		if got := transfer(fn, test.volts); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected transfer for %vV: got:%v want:%v", test.volts, got, test.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for equal voltages")
+		}
+	}()
+	LinearTransfer(1, 0, 1, 10)
+}
+
+func TestAnalogMode(t *testing.T) {
+	if m := analogMode(false); m != "ANALOG-0" {
+		t.Errorf("unexpected mode with pin 5 low: got:%q want:%q", m, "ANALOG-0")
+	}
+	if m := analogMode(true); m != "ANALOG-1" {
+		t.Errorf("unexpected mode with pin 5 high: got:%q want:%q", m, "ANALOG-1")
+	}
+}
+
+func TestSensorNodes(t *testing.T) {
+	tree := &ev3dev.DeviceTree{
+		Ports: []*ev3dev.TreeNode{
+			{Class: "lego-port", Name: "port0", Address: "ev3-ports:in1", Devices: []*ev3dev.TreeNode{
+				{Class: "lego-sensor", Name: "sensor1", Address: "ev3-ports:in1", Driver: "lego-ev3-gyro"},
+			}},
+			{Class: "lego-port", Name: "port4", Address: "ev3-ports:outA", Devices: []*ev3dev.TreeNode{
+				{Class: "tacho-motor", Name: "motor0", Address: "ev3-ports:outA", Driver: "lego-ev3-l-motor"},
+			}},
+			{Class: "lego-port", Name: "port1", Address: "ev3-ports:in2", Devices: []*ev3dev.TreeNode{
+				{Class: "lego-sensor", Name: "sensor0", Address: "ev3-ports:in2", Driver: "lego-ev3-color"},
+			}},
+		},
+		Unattached: []*ev3dev.TreeNode{
+			{Class: "lego-sensor", Name: "sensor2", Address: "virtual", Driver: "custom"},
+		},
+	}
+	var got []string
+	for _, n := range sensorNodes(tree) {
+		got = append(got, n.Name)
+	}
+	want := []string{"sensor1", "sensor0", "sensor2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sensor nodes: got:%v want:%v", got, want)
+	}
+}
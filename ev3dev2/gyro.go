@@ -0,0 +1,83 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// gyroMaxRate is the largest rate of rotation
+// reported by the EV3 gyro in degrees per second.
+const gyroMaxRate = 440
+
+// HardReset resets a gyro sensor that has locked up, for example one that
+// reports a drifting angle at rest or no longer changes its readings. The
+// robot must be at rest while HardReset runs.
+//
+// HardReset first cycles the sensor through its GYRO-CAL calibration mode
+// and back to GYRO-G&A, waiting up to timeout for the readings to settle
+// after each mode change. If the sensor then does not report an angle of
+// zero and a sane rate, the sensor's lego-port is switched to other-uart
+// and back to auto, which powers down and re-detects the sensor, and the
+// mode cycle is repeated. The port rebind replaces the sensor's handle, so
+// handles to the underlying ev3dev.Sensor taken before calling HardReset
+// must not be used after it returns.
+func (s *GyroSensor) HardReset(timeout time.Duration) error {
+	err := s.cycleModes(timeout)
+	if err == nil {
+		return nil
+	}
+
+	addr, err := ev3dev.AddressOf(s.Sensor)
+	if err != nil {
+		return err
+	}
+	p, err := ev3dev.NewLegoPort(ev3dev.WithPort(addr))
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	s.Sensor.Close()
+	err = p.SetMode("other-uart").SetMode("auto").Err()
+	if err != nil {
+		return fmt.Errorf("ev3dev2: failed to rebind gyro port %s: %w", addr, err)
+	}
+	sensor, err := ev3dev.NewSensor(
+		ev3dev.WithPort(addr),
+		ev3dev.WithDriver("lego-ev3-gyro"),
+		ev3dev.WithTimeout(timeout),
+	)
+	if err != nil {
+		return fmt.Errorf("ev3dev2: gyro did not reappear on %s: %w", addr, err)
+	}
+	s.Sensor = sensor
+	return s.cycleModes(timeout)
+}
+
+// cycleModes cycles the sensor through GYRO-CAL to GYRO-G&A and
+// returns an error if the readings are not sane afterwards.
+func (s *GyroSensor) cycleModes(settle time.Duration) error {
+	err := s.SetModeAndWait("GYRO-CAL", settle).SetModeAndWait("GYRO-G&A", settle).Err()
+	if err != nil {
+		return err
+	}
+	angle, rate, err := s.AngleAndRate()
+	if err != nil {
+		return err
+	}
+	return checkGyroReset(angle, rate)
+}
+
+// checkGyroReset returns an error if angle and rate are not
+// the readings of a gyro at rest that has just been reset.
+func checkGyroReset(angle, rate int) error {
+	if angle != 0 || rate < -gyroMaxRate || gyroMaxRate < rate {
+		return fmt.Errorf("ev3dev2: gyro not reset: angle=%d rate=%d", angle, rate)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// AbsoluteIMU is a Mindsensors AbsoluteIMU sensor.
+type AbsoluteIMU struct {
+	*ev3dev.Sensor
+}
+
+// NewAbsoluteIMU returns an AbsoluteIMU for the sensor at the given
+// address.
+func NewAbsoluteIMU(address string) (*AbsoluteIMU, error) {
+	s, err := ev3dev.SensorFor(address, msIMUDriver)
+	if err != nil {
+		return nil, err
+	}
+	return &AbsoluteIMU{s}, nil
+}
+
+// Tilt returns the tilt of the sensor along the X, Y and Z axes in the
+// sensor's raw units.
+func (s *AbsoluteIMU) Tilt() (x, y, z int, err error) {
+	return imuInts(s.Sensor, "TILT")
+}
+
+// Acceleration returns the acceleration along the X, Y and Z axes of the
+// sensor in g.
+func (s *AbsoluteIMU) Acceleration() (x, y, z float64, err error) {
+	return imuAcceleration(s.Sensor)
+}
+
+// Rotation returns the rate of rotation about the X, Y and Z axes of the
+// sensor in degrees per second.
+func (s *AbsoluteIMU) Rotation() (x, y, z float64, err error) {
+	err = ensureMode(s.Sensor, "GYRO")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	v, err := s.FloatValues()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if len(v) < 3 {
+		return 0, 0, 0, fmt.Errorf("ev3dev2: too few rotation values: %d", len(v))
+	}
+	return v[0], v[1], v[2], nil
+}
+
+// Heading returns the compass heading of the sensor in degrees clockwise
+// from magnetic north, in the range [0, 360).
+func (s *AbsoluteIMU) Heading() (int, error) {
+	return intValue(s.Sensor, "COMPASS", 0)
+}
+
+// MagneticField returns the magnetic field along the X, Y and Z axes of
+// the sensor in the sensor's raw units.
+func (s *AbsoluteIMU) MagneticField() (x, y, z int, err error) {
+	return imuInts(s.Sensor, "MAG")
+}
+
+// BeginCompassCalibration starts calibration of the compass. While the
+// compass is calibrating, the sensor should be rotated slowly about each
+// of its axes before EndCompassCalibration is called.
+func (s *AbsoluteIMU) BeginCompassCalibration() error {
+	return s.Command("BEGIN-COMP-CAL").Err()
+}
+
+// EndCompassCalibration ends calibration of the compass.
+func (s *AbsoluteIMU) EndCompassCalibration() error {
+	return s.Command("END-COMP-CAL").Err()
+}
+
+// SetAccelerationRange sets the full scale range of the accelerometer.
+// The range must be 2, 4, 8 or 16 g. Changing the range also changes the
+// sensitivity of the gyro.
+func (s *AbsoluteIMU) SetAccelerationRange(g int) error {
+	switch g {
+	case 2, 4, 8, 16:
+	default:
+		return fmt.Errorf("ev3dev2: invalid acceleration range: %dg (must be 2, 4, 8 or 16)", g)
+	}
+	return s.Command(fmt.Sprintf("ACCEL-%dG", g)).Err()
+}
+
+// imuAcceleration returns the acceleration in g measured
+// by an AbsoluteIMU, which reports milli-g.
+func imuAcceleration(s *ev3dev.Sensor) (x, y, z float64, err error) {
+	ix, iy, iz, err := imuInts(s, "ACCEL")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return float64(ix) / 1000, float64(iy) / 1000, float64(iz) / 1000, nil
+}
+
+// imuInts returns the first three values of s in the given mode
+// decoded from the sensor's bin data.
+func imuInts(s *ev3dev.Sensor, mode string) (x, y, z int, err error) {
+	err = ensureMode(s, mode)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	v, err := s.BinDataInts()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if len(v) < 3 {
+		return 0, 0, 0, fmt.Errorf("ev3dev2: too few %s values: %d", mode, len(v))
+	}
+	return v[0], v[1], v[2], nil
+}
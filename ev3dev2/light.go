@@ -0,0 +1,110 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"github.com/ev3go/ev3dev"
+	"github.com/ev3go/ev3dev/calibration"
+)
+
+// ReflectanceSensor is a sensor that measures calibrated reflected light
+// intensity. It is satisfied by ColorSensor and LightSensor, so that line
+// following code can use either sensor.
+type ReflectanceSensor interface {
+	// CalibrateWhite and CalibrateBlack
+	// record the current readings as
+	// the readings for white and black
+	// reference surfaces.
+	CalibrateWhite() error
+	CalibrateBlack() error
+
+	// CalibratedReflect returns the
+	// reflected light intensity scaled
+	// between the black and white
+	// references to 0-100.
+	CalibratedReflect() (float64, error)
+
+	// Calibration and SetCalibration
+	// get and set the calibration of
+	// the sensor.
+	Calibration() calibration.Color
+	SetCalibration(calibration.Color)
+}
+
+var (
+	_ ReflectanceSensor = (*ColorSensor)(nil)
+	_ ReflectanceSensor = (*LightSensor)(nil)
+)
+
+// LightSensor is a LEGO NXT light sensor.
+type LightSensor struct {
+	*ev3dev.Sensor
+
+	// cal holds the reflectance
+	// calibration of the sensor.
+	// Only the reflect fields are
+	// used.
+	cal calibration.Color
+}
+
+// NewLightSensor returns a LightSensor for the sensor at the given address.
+func NewLightSensor(address string) (*LightSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-nxt-light")
+	if err != nil {
+		return nil, err
+	}
+	return &LightSensor{Sensor: s}, nil
+}
+
+// Reflected returns the reflected light intensity as a percentage, with
+// the sensor's LED on.
+func (s *LightSensor) Reflected() (float64, error) {
+	return scaledValue(s.Sensor, "REFLECT", 0)
+}
+
+// Ambient returns the ambient light intensity as a percentage, with the
+// sensor's LED off.
+func (s *LightSensor) Ambient() (float64, error) {
+	return scaledValue(s.Sensor, "AMBIENT", 0)
+}
+
+// CalibrateWhite records the current reflected light intensity as the
+// reading for a white reference surface.
+func (s *LightSensor) CalibrateWhite() error {
+	v, err := s.Reflected()
+	if err != nil {
+		return err
+	}
+	s.cal.WhiteReflect = v
+	return nil
+}
+
+// CalibrateBlack records the current reflected light intensity as the
+// reading for a black reference surface.
+func (s *LightSensor) CalibrateBlack() error {
+	v, err := s.Reflected()
+	if err != nil {
+		return err
+	}
+	s.cal.BlackReflect = v
+	return nil
+}
+
+// CalibratedReflect returns the reflected light intensity scaled as
+// described for ColorSensor.CalibratedReflect.
+func (s *LightSensor) CalibratedReflect() (float64, error) {
+	return calibratedReflect(s.cal, s.Reflected)
+}
+
+// Calibration returns the calibration of the sensor. See
+// ColorSensor.Calibration for details.
+func (s *LightSensor) Calibration() calibration.Color {
+	return s.cal
+}
+
+// SetCalibration sets the calibration of the sensor.
+func (s *LightSensor) SetCalibration(c calibration.Color) {
+	s.cal = c
+}
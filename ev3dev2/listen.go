@@ -0,0 +1,68 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"time"
+)
+
+// PresenceEvent is a change in the presence of another
+// ultrasonic sensor detected by an UltrasonicSensor.
+type PresenceEvent struct {
+	// Time is the time the
+	// change was seen.
+	Time time.Time
+
+	// Present is whether another
+	// ultrasonic sensor is detected.
+	Present bool
+}
+
+// Listen places the sensor in US-LISTEN mode and returns a channel that
+// receives an event each time another ultrasonic sensor starts or stops
+// being detected nearby. The first event holds the presence when Listen
+// is called. The sensor is checked every interval as described for
+// ev3dev.Sensor.Watch. Listen can be used for simple signalling between
+// robots, with one robot switching its sensor on and off while another
+// listens.
+//
+// The channel is closed when ctx is done, when the sensor is closed or
+// when the sensor can no longer be read.
+func (s *UltrasonicSensor) Listen(ctx context.Context, interval time.Duration) (<-chan PresenceEvent, error) {
+	err := ensureMode(s.Sensor, "US-LISTEN")
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.Watch(ctx, 0, interval)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan PresenceEvent)
+	go presenceEvents(ctx, values, c)
+	return c, nil
+}
+
+// presenceEvents sends a PresenceEvent on dst for each change in
+// presence in the US-LISTEN values received from src, closing dst
+// when src is closed or ctx is done.
+func presenceEvents(ctx context.Context, src <-chan float64, dst chan<- PresenceEvent) {
+	defer close(dst)
+	first := true
+	var present bool
+	for v := range src {
+		p := v == 1
+		if !first && p == present {
+			continue
+		}
+		first = false
+		present = p
+		select {
+		case dst <- PresenceEvent{Time: time.Now(), Present: p}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Port addresses for the EV3 brick.
+const (
+	OutputA = "ev3-ports:outA"
+	OutputB = "ev3-ports:outB"
+	OutputC = "ev3-ports:outC"
+	OutputD = "ev3-ports:outD"
+
+	Input1 = "ev3-ports:in1"
+	Input2 = "ev3-ports:in2"
+	Input3 = "ev3-ports:in3"
+	Input4 = "ev3-ports:in4"
+)
+
+// Speed is a motor speed that can be converted to the native units of
+// a motor.
+type Speed interface {
+	// NativeUnits returns the speed in tacho counts per second for
+	// a motor with the given number of counts per rotation and
+	// maximum speed. NativeUnits returns an error if the speed is
+	// not achievable by the motor.
+	NativeUnits(countPerRot, maxSpeed int) (float64, error)
+}
+
+// SpeedPercent is a speed expressed as a percentage of the motor's
+// maximum speed.
+type SpeedPercent float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedPercent) NativeUnits(_, maxSpeed int) (float64, error) {
+	if s < -100 || 100 < s {
+		return 0, fmt.Errorf("ev3dev2: invalid speed percentage: %v (must be in -100-100)", float64(s))
+	}
+	return float64(s) / 100 * float64(maxSpeed), nil
+}
+
+// SpeedNativeUnits is a speed expressed in tacho counts per second.
+type SpeedNativeUnits float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedNativeUnits) NativeUnits(_, maxSpeed int) (float64, error) {
+	return checkNative(float64(s), float64(s), "counts/s", maxSpeed)
+}
+
+// SpeedRPS is a speed expressed in rotations per second.
+type SpeedRPS float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedRPS) NativeUnits(countPerRot, maxSpeed int) (float64, error) {
+	return checkNative(float64(s), float64(s)*float64(countPerRot), "rotations/s", maxSpeed)
+}
+
+// SpeedRPM is a speed expressed in rotations per minute.
+type SpeedRPM float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedRPM) NativeUnits(countPerRot, maxSpeed int) (float64, error) {
+	return checkNative(float64(s), float64(s)*float64(countPerRot)/60, "rotations/min", maxSpeed)
+}
+
+// SpeedDPS is a speed expressed in degrees per second.
+type SpeedDPS float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedDPS) NativeUnits(countPerRot, maxSpeed int) (float64, error) {
+	return checkNative(float64(s), float64(s)*float64(countPerRot)/360, "degrees/s", maxSpeed)
+}
+
+// SpeedDPM is a speed expressed in degrees per minute.
+type SpeedDPM float64
+
+// NativeUnits satisfies the Speed interface.
+func (s SpeedDPM) NativeUnits(countPerRot, maxSpeed int) (float64, error) {
+	return checkNative(float64(s), float64(s)*float64(countPerRot)/(360*60), "degrees/min", maxSpeed)
+}
+
+func checkNative(v, native float64, units string, maxSpeed int) (float64, error) {
+	if math.Abs(native) > float64(maxSpeed) {
+		return 0, fmt.Errorf("ev3dev2: invalid speed: %v %s exceeds maximum of %d counts/s", v, units, maxSpeed)
+	}
+	return native, nil
+}
+
+// Motor is a tacho motor with python-ev3dev2 style methods.
+type Motor struct {
+	*ev3dev.TachoMotor
+}
+
+// LargeMotor is an EV3 large servo motor.
+type LargeMotor struct {
+	Motor
+}
+
+// NewLargeMotor returns a LargeMotor for the motor at the given address.
+func NewLargeMotor(address string) (*LargeMotor, error) {
+	m, err := ev3dev.TachoMotorFor(address, "lego-ev3-l-motor")
+	if err != nil {
+		return nil, err
+	}
+	return &LargeMotor{Motor{m}}, nil
+}
+
+// MediumMotor is an EV3 medium servo motor.
+type MediumMotor struct {
+	Motor
+}
+
+// NewMediumMotor returns a MediumMotor for the motor at the given address.
+func NewMediumMotor(address string) (*MediumMotor, error) {
+	m, err := ev3dev.TachoMotorFor(address, "lego-ev3-m-motor")
+	if err != nil {
+		return nil, err
+	}
+	return &MediumMotor{Motor{m}}, nil
+}
+
+// native returns the speed in native units for the motor.
+func (m Motor) native(speed Speed) (float64, error) {
+	return speed.NativeUnits(m.CountPerRot(), m.MaxSpeed())
+}
+
+// stopActionFor returns the stop action corresponding to the python-ev3dev2
+// brake parameter.
+func stopActionFor(brake bool) ev3dev.StopAction {
+	if brake {
+		return ev3dev.StopActionHold
+	}
+	return ev3dev.StopActionCoast
+}
+
+// On runs the motor at the given speed until Off is called. If block is
+// true, On waits until the motor has stalled or has been stopped by
+// another caller.
+func (m Motor) On(speed Speed, brake, block bool) error {
+	sp, err := m.native(speed)
+	if err != nil {
+		return err
+	}
+	err = m.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(sp))).
+		Command(ev3dev.CommandRunForever).
+		Err()
+	if err != nil || !block {
+		return err
+	}
+	return m.WaitUntilNotMoving(-1)
+}
+
+// Off stops the motor, holding position if brake is true.
+func (m Motor) Off(brake bool) error {
+	return m.SetStopAction(stopActionFor(brake)).Command(ev3dev.CommandStop).Err()
+}
+
+// OnForDegrees rotates the motor by the given number of degrees at the
+// given speed. If the product of speed and degrees is negative, the
+// motor turns in reverse. If block is true, OnForDegrees waits until
+// the motor is no longer moving.
+func (m Motor) OnForDegrees(speed Speed, degrees float64, brake, block bool) error {
+	sp, err := m.native(speed)
+	if err != nil {
+		return err
+	}
+	return m.runToRel(sp, degrees, brake, block)
+}
+
+// OnForRotations rotates the motor by the given number of rotations at
+// the given speed. If the product of speed and rotations is negative,
+// the motor turns in reverse. If block is true, OnForRotations waits
+// until the motor is no longer moving.
+func (m Motor) OnForRotations(speed Speed, rotations float64, brake, block bool) error {
+	return m.OnForDegrees(speed, rotations*360, brake, block)
+}
+
+// runToRel issues a run-to-rel-pos command for the given native speed and
+// relative position in degrees.
+func (m Motor) runToRel(speed, degrees float64, brake, block bool) error {
+	counts := countsFor(speed, degrees, m.CountPerRot())
+	err := m.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(math.Abs(speed)))).
+		SetPositionSetpoint(counts).
+		Command(ev3dev.CommandRunToRelPos).
+		Err()
+	if err != nil || !block {
+		return err
+	}
+	return m.WaitUntilNotMoving(-1)
+}
+
+// countsFor returns the signed relative position in tacho counts for a move
+// of the given number of degrees at the given speed.
+func countsFor(speed, degrees float64, countPerRot int) int {
+	counts := math.Round(math.Abs(degrees) * float64(countPerRot) / 360)
+	if (speed < 0) != (degrees < 0) {
+		counts = -counts
+	}
+	return int(counts)
+}
+
+// OnToPosition rotates the motor to the given absolute position in tacho
+// counts at the given speed. If block is true, OnToPosition waits until
+// the motor is no longer moving.
+func (m Motor) OnToPosition(speed Speed, position int, brake, block bool) error {
+	sp, err := m.native(speed)
+	if err != nil {
+		return err
+	}
+	err = m.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(math.Abs(sp)))).
+		SetPositionSetpoint(position).
+		Command(ev3dev.CommandRunToAbsPos).
+		Err()
+	if err != nil || !block {
+		return err
+	}
+	return m.WaitUntilNotMoving(-1)
+}
+
+// OnForSeconds runs the motor at the given speed for the given number of
+// seconds. If block is true, OnForSeconds waits until the motor is no
+// longer moving.
+func (m Motor) OnForSeconds(speed Speed, seconds float64, brake, block bool) error {
+	sp, err := m.native(speed)
+	if err != nil {
+		return err
+	}
+	if seconds < 0 {
+		return fmt.Errorf("ev3dev2: invalid duration: %vs (must be positive)", seconds)
+	}
+	err = m.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(sp))).
+		SetTimeSetpoint(time.Duration(seconds * float64(time.Second))).
+		Command(ev3dev.CommandRunTimed).
+		Err()
+	if err != nil || !block {
+		return err
+	}
+	return m.WaitUntilNotMoving(-1)
+}
+
+// IsRunning returns whether the motor is running.
+func (m Motor) IsRunning() (bool, error) {
+	stat, err := m.State()
+	return stat&ev3dev.Running != 0, err
+}
+
+// IsStalled returns whether the motor is stalled.
+func (m Motor) IsStalled() (bool, error) {
+	stat, err := m.State()
+	return stat&ev3dev.Stalled != 0, err
+}
+
+// WaitUntilNotMoving waits until the motor is no longer running, the
+// motor has stalled or the timeout is reached. If timeout is negative
+// WaitUntilNotMoving waits indefinitely. A stalled motor is treated as
+// not moving since a run-forever motor remains running when it stalls.
+func (m Motor) WaitUntilNotMoving(timeout time.Duration) error {
+	stat, ok, err := waitNotMoving(m.State, statePoll, timeout)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("ev3dev2: timed out waiting for %v to stop (state=%v)", m.TachoMotor, stat)
+	}
+	return nil
+}
+
+// statePoll is the interval between motor state
+// reads when waiting for a motor to stop.
+const statePoll = 10 * time.Millisecond
+
+// waitNotMoving polls the motor state using state until the motor is no
+// longer running or has stalled, or the timeout is reached. The last state
+// read is returned with ok false if the timeout was reached.
+func waitNotMoving(state func() (ev3dev.MotorState, error), poll, timeout time.Duration) (stat ev3dev.MotorState, ok bool, err error) {
+	end := time.Now().Add(timeout)
+	for {
+		stat, err = state()
+		if err != nil {
+			return stat, false, err
+		}
+		if stat&ev3dev.Running == 0 || stat&ev3dev.Stalled != 0 {
+			return stat, true, nil
+		}
+		if timeout >= 0 && !time.Now().Before(end) {
+			return stat, false, nil
+		}
+		time.Sleep(poll)
+	}
+}
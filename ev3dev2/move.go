@@ -0,0 +1,279 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// MoveTank controls a pair of motors with independent speeds, like
+// the controls of a tank.
+type MoveTank struct {
+	Left, Right Motor
+}
+
+// NewMoveTank returns a MoveTank for the large motors at the given
+// addresses.
+func NewMoveTank(left, right string) (*MoveTank, error) {
+	l, err := NewLargeMotor(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewLargeMotor(right)
+	if err != nil {
+		return nil, err
+	}
+	return &MoveTank{Left: l.Motor, Right: r.Motor}, nil
+}
+
+// natives returns the native speeds for the left and right motors.
+func (t *MoveTank) natives(left, right Speed) (l, r float64, err error) {
+	l, err = t.Left.native(left)
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err = t.Right.native(right)
+	if err != nil {
+		return 0, 0, err
+	}
+	return l, r, nil
+}
+
+// On runs both motors at the given speeds until Off is called.
+func (t *MoveTank) On(left, right Speed) error {
+	l, r, err := t.natives(left, right)
+	if err != nil {
+		return err
+	}
+	return t.on(l, r)
+}
+
+func (t *MoveTank) on(l, r float64) error {
+	err := t.Left.SetSpeedSetpoint(int(math.Round(l))).Command(ev3dev.CommandRunForever).Err()
+	if err != nil {
+		return err
+	}
+	err = t.Right.SetSpeedSetpoint(int(math.Round(r))).Command(ev3dev.CommandRunForever).Err()
+	if err != nil {
+		t.Left.Command(ev3dev.CommandStop).Err()
+	}
+	return err
+}
+
+// Off stops both motors, holding position if brake is true.
+func (t *MoveTank) Off(brake bool) error {
+	errL := t.Left.Off(brake)
+	errR := t.Right.Off(brake)
+	if errL != nil {
+		return errL
+	}
+	return errR
+}
+
+// OnForDegrees rotates the motors at the given speeds. The faster motor
+// turns by the given number of degrees and the slower motor turns by a
+// proportionally smaller amount so that both motors finish together.
+// If block is true, OnForDegrees waits until both motors are no longer
+// moving.
+func (t *MoveTank) OnForDegrees(left, right Speed, degrees float64, brake, block bool) error {
+	l, r, err := t.natives(left, right)
+	if err != nil {
+		return err
+	}
+	return t.onForDegrees(l, r, degrees, brake, block)
+}
+
+func (t *MoveTank) onForDegrees(l, r, degrees float64, brake, block bool) error {
+	ld, rd := tankDegrees(l, r, degrees)
+	err := t.Left.runToRel(l, ld, brake, false)
+	if err != nil {
+		return err
+	}
+	err = t.Right.runToRel(r, rd, brake, false)
+	if err != nil {
+		t.Left.Command(ev3dev.CommandStop).Err()
+		return err
+	}
+	if !block {
+		return nil
+	}
+	return t.WaitUntilNotMoving(-1)
+}
+
+// tankDegrees returns the distance in degrees for each motor of a tank
+// drive such that the faster motor travels the given number of degrees.
+func tankDegrees(left, right, degrees float64) (l, r float64) {
+	switch {
+	case degrees == 0 || (left == 0 && right == 0):
+		return degrees, degrees
+	case math.Abs(left) > math.Abs(right):
+		return degrees, math.Abs(right/left) * degrees
+	default:
+		return math.Abs(left/right) * degrees, degrees
+	}
+}
+
+// OnForRotations rotates the motors at the given speeds. The faster motor
+// turns by the given number of rotations and the slower motor turns by a
+// proportionally smaller amount so that both motors finish together.
+// If block is true, OnForRotations waits until both motors are no longer
+// moving.
+func (t *MoveTank) OnForRotations(left, right Speed, rotations float64, brake, block bool) error {
+	return t.OnForDegrees(left, right, rotations*360, brake, block)
+}
+
+// OnForSeconds runs both motors at the given speeds for the given number
+// of seconds. If block is true, OnForSeconds waits until both motors are
+// no longer moving.
+func (t *MoveTank) OnForSeconds(left, right Speed, seconds float64, brake, block bool) error {
+	l, r, err := t.natives(left, right)
+	if err != nil {
+		return err
+	}
+	return t.onForSeconds(l, r, seconds, brake, block)
+}
+
+func (t *MoveTank) onForSeconds(l, r, seconds float64, brake, block bool) error {
+	if seconds < 0 {
+		return fmt.Errorf("ev3dev2: invalid duration: %vs (must be positive)", seconds)
+	}
+	d := time.Duration(seconds * float64(time.Second))
+	err := t.Left.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(l))).
+		SetTimeSetpoint(d).
+		Err()
+	if err != nil {
+		return err
+	}
+	err = t.Right.SetStopAction(stopActionFor(brake)).
+		SetSpeedSetpoint(int(math.Round(r))).
+		SetTimeSetpoint(d).
+		Err()
+	if err != nil {
+		return err
+	}
+	err = t.Left.Command(ev3dev.CommandRunTimed).Err()
+	if err != nil {
+		return err
+	}
+	err = t.Right.Command(ev3dev.CommandRunTimed).Err()
+	if err != nil {
+		t.Left.Command(ev3dev.CommandStop).Err()
+		return err
+	}
+	if !block {
+		return nil
+	}
+	return t.WaitUntilNotMoving(-1)
+}
+
+// WaitUntilNotMoving waits until both motors are no longer running or the
+// timeout is reached. If timeout is negative WaitUntilNotMoving waits
+// indefinitely.
+func (t *MoveTank) WaitUntilNotMoving(timeout time.Duration) error {
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i, m := range []Motor{t.Left, t.Right} {
+		i, m := i, m
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = m.WaitUntilNotMoving(timeout)
+		}()
+	}
+	wg.Wait()
+	if errs[0] != nil {
+		return errs[0]
+	}
+	return errs[1]
+}
+
+// MoveSteering controls a pair of motors using a single steering value
+// and speed, like the EV3-G move steering block.
+type MoveSteering struct {
+	MoveTank
+}
+
+// NewMoveSteering returns a MoveSteering for the large motors at the given
+// addresses.
+func NewMoveSteering(left, right string) (*MoveSteering, error) {
+	t, err := NewMoveTank(left, right)
+	if err != nil {
+		return nil, err
+	}
+	return &MoveSteering{*t}, nil
+}
+
+// speeds returns the native left and right speeds for the given steering
+// and speed.
+func (s *MoveSteering) speeds(steering float64, speed Speed) (l, r float64, err error) {
+	if steering < -100 || 100 < steering {
+		return 0, 0, fmt.Errorf("ev3dev2: invalid steering: %v (must be in -100-100)", steering)
+	}
+	sp, err := s.Left.native(speed)
+	if err != nil {
+		return 0, 0, err
+	}
+	l, r = steeringSpeeds(steering, sp)
+	return l, r, nil
+}
+
+// steeringSpeeds returns the left and right speeds for the given steering
+// value in [-100, 100] and speed. Positive steering turns right by slowing
+// the right motor and negative steering turns left by slowing the left
+// motor. At ±50 the inner motor is stopped and at ±100 it turns in reverse.
+func steeringSpeeds(steering, speed float64) (l, r float64) {
+	factor := (50 - math.Abs(steering)) / 50
+	l, r = speed, speed
+	if steering >= 0 {
+		r *= factor
+	} else {
+		l *= factor
+	}
+	return l, r
+}
+
+// On runs the motors with the given steering and speed until Off is called.
+func (s *MoveSteering) On(steering float64, speed Speed) error {
+	l, r, err := s.speeds(steering, speed)
+	if err != nil {
+		return err
+	}
+	return s.on(l, r)
+}
+
+// OnForDegrees runs the motors with the given steering and speed until the
+// faster motor has turned by the given number of degrees. If block is true,
+// OnForDegrees waits until both motors are no longer moving.
+func (s *MoveSteering) OnForDegrees(steering float64, speed Speed, degrees float64, brake, block bool) error {
+	l, r, err := s.speeds(steering, speed)
+	if err != nil {
+		return err
+	}
+	return s.onForDegrees(l, r, degrees, brake, block)
+}
+
+// OnForRotations runs the motors with the given steering and speed until the
+// faster motor has turned by the given number of rotations. If block is true,
+// OnForRotations waits until both motors are no longer moving.
+func (s *MoveSteering) OnForRotations(steering float64, speed Speed, rotations float64, brake, block bool) error {
+	return s.OnForDegrees(steering, speed, rotations*360, brake, block)
+}
+
+// OnForSeconds runs the motors with the given steering and speed for the given
+// number of seconds. If block is true, OnForSeconds waits until both motors
+// are no longer moving.
+func (s *MoveSteering) OnForSeconds(steering float64, speed Speed, seconds float64, brake, block bool) error {
+	l, r, err := s.speeds(steering, speed)
+	if err != nil {
+		return err
+	}
+	return s.onForSeconds(l, r, seconds, brake, block)
+}
@@ -0,0 +1,170 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RemoteEvent is a press or release of a button
+// of the EV3 infrared remote control.
+type RemoteEvent struct {
+	// Channel is the channel,
+	// 1 to 4, of the remote.
+	Channel int
+
+	// Button is the button that
+	// was pressed or released.
+	Button RemoteButton
+
+	// Pressed is true for a press
+	// and false for a release.
+	Pressed bool
+}
+
+// String satisfies the fmt.Stringer interface.
+func (e RemoteEvent) String() string {
+	action := "released"
+	if e.Pressed {
+		action = "pressed"
+	}
+	return fmt.Sprintf("channel %d %v %s", e.Channel, e.Button, action)
+}
+
+// RemoteControl decodes the buttons of EV3 infrared remote controls
+// seen by an InfraredSensor into press and release events. Remotes on all
+// four channels are decoded at the same time.
+type RemoteControl struct {
+	sensor   *InfraredSensor
+	interval time.Duration
+
+	buttons [4]RemoteButton
+	err     error
+}
+
+// NewRemoteControl returns a RemoteControl that reads the remote button
+// codes from s every interval. If interval is zero, the codes are read
+// every 50ms.
+func NewRemoteControl(s *InfraredSensor, interval time.Duration) *RemoteControl {
+	if interval == 0 {
+		interval = 50 * time.Millisecond
+	}
+	return &RemoteControl{sensor: s, interval: interval}
+}
+
+// Events places the sensor in IR-REMOTE mode and returns a channel that
+// receives the remote button events on all channels. A button that stays
+// pressed while another button is pressed or released is not reported
+// again, and released buttons are reported before newly pressed buttons.
+// Events are not dropped; reading the codes waits for the receiver.
+//
+// The channel is closed when ctx is done or the codes can no longer be
+// read. Events must not be called again until the channel is closed.
+func (r *RemoteControl) Events(ctx context.Context) (<-chan RemoteEvent, error) {
+	if r.interval <= 0 {
+		return nil, fmt.Errorf("ev3dev2: invalid remote interval: %v (must be positive)", r.interval)
+	}
+	err := ensureMode(r.sensor.Sensor, "IR-REMOTE")
+	if err != nil {
+		return nil, err
+	}
+	r.err = nil
+	c := make(chan RemoteEvent)
+	go func() {
+		defer close(c)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			events, err := r.read()
+			if err != nil {
+				r.err = err
+				return
+			}
+			for _, e := range events {
+				select {
+				case c <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// Err returns the error that caused the most recent channel returned by
+// Events to close. Err must only be called after the channel is closed.
+func (r *RemoteControl) Err() error {
+	return r.err
+}
+
+// Buttons returns the buttons last seen pressed on the given channel. It
+// must not be called while a channel returned by Events is open.
+func (r *RemoteControl) Buttons(channel int) (RemoteButton, error) {
+	err := checkChannel(channel)
+	if err != nil {
+		return 0, err
+	}
+	return r.buttons[channel-1], nil
+}
+
+// read reads the button codes for all channels and returns the
+// events since the last read.
+func (r *RemoteControl) read() ([]RemoteEvent, error) {
+	vals, err := r.sensor.Values()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) < len(r.buttons) {
+		return nil, fmt.Errorf("ev3dev2: too few remote values: %d", len(vals))
+	}
+	var codes [4]int
+	for i := range codes {
+		codes[i], err = strconv.Atoi(vals[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	var events []RemoteEvent
+	events, r.buttons, err = remoteEvents(events, r.buttons, codes)
+	return events, err
+}
+
+// remoteEvents appends to dst the events for a change from the
+// buttons in prev to the buttons encoded in codes, returning the
+// events and the new buttons.
+func remoteEvents(dst []RemoteEvent, prev [4]RemoteButton, codes [4]int) ([]RemoteEvent, [4]RemoteButton, error) {
+	var next [4]RemoteButton
+	for i, code := range codes {
+		b, err := remoteButtons(code)
+		if err != nil {
+			return dst, prev, err
+		}
+		next[i] = b
+	}
+	for i := range next {
+		for _, pressed := range []bool{false, true} {
+			changed := prev[i] &^ next[i]
+			if pressed {
+				changed = next[i] &^ prev[i]
+			}
+			for j := range remoteButtonNames {
+				b := RemoteButton(1 << uint(j))
+				if changed&b != 0 {
+					dst = append(dst, RemoteEvent{Channel: i + 1, Button: b, Pressed: pressed})
+				}
+			}
+		}
+	}
+	return dst, next, nil
+}
@@ -0,0 +1,455 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ev3go/ev3dev"
+	"github.com/ev3go/ev3dev/calibration"
+)
+
+// ensureMode sets the mode of s if it is not already in that mode.
+func ensureMode(s *ev3dev.Sensor, mode string) error {
+	m, err := s.Mode()
+	if err != nil {
+		return err
+	}
+	if m == mode {
+		return nil
+	}
+	return s.SetMode(mode).Err()
+}
+
+// intValue returns the nth value of s in the given mode as an integer.
+func intValue(s *ev3dev.Sensor, mode string, n int) (int, error) {
+	err := ensureMode(s, mode)
+	if err != nil {
+		return 0, err
+	}
+	v, err := s.Value(n)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(v)
+}
+
+// scaledValue returns the nth value of s in the given mode scaled by the
+// sensor's decimals.
+func scaledValue(s *ev3dev.Sensor, mode string, n int) (float64, error) {
+	v, err := intValue(s, mode, n)
+	if err != nil {
+		return 0, err
+	}
+	return float64(v) / math.Pow10(s.Decimals()), nil
+}
+
+// TouchSensor is an EV3 touch sensor.
+type TouchSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewTouchSensor returns a TouchSensor for the sensor at the given address.
+func NewTouchSensor(address string) (*TouchSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-ev3-touch")
+	if err != nil {
+		return nil, err
+	}
+	return &TouchSensor{s}, nil
+}
+
+// IsPressed returns whether the touch sensor is pressed.
+func (s *TouchSensor) IsPressed() (bool, error) {
+	v, err := intValue(s.Sensor, "TOUCH", 0)
+	return v == 1, err
+}
+
+// Color values returned by ColorSensor.Color. See also the Color type
+// returned by DetectedColor.
+const (
+	ColorNoColor = int(NoColor)
+	ColorBlack   = int(Black)
+	ColorBlue    = int(Blue)
+	ColorGreen   = int(Green)
+	ColorYellow  = int(Yellow)
+	ColorRed     = int(Red)
+	ColorWhite   = int(White)
+	ColorBrown   = int(Brown)
+)
+
+var colorNames = [...]string{
+	ColorNoColor: "NoColor",
+	ColorBlack:   "Black",
+	ColorBlue:    "Blue",
+	ColorGreen:   "Green",
+	ColorYellow:  "Yellow",
+	ColorRed:     "Red",
+	ColorWhite:   "White",
+	ColorBrown:   "Brown",
+}
+
+// ColorSensor is an EV3 color sensor.
+type ColorSensor struct {
+	*ev3dev.Sensor
+
+	// cal holds the reflectance
+	// calibration of the sensor.
+	cal calibration.Color
+}
+
+// NewColorSensor returns a ColorSensor for the sensor at the given address.
+func NewColorSensor(address string) (*ColorSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-ev3-color")
+	if err != nil {
+		return nil, err
+	}
+	return &ColorSensor{Sensor: s}, nil
+}
+
+// ReflectedLightIntensity returns the reflected light intensity as a
+// percentage.
+func (s *ColorSensor) ReflectedLightIntensity() (int, error) {
+	return intValue(s.Sensor, "COL-REFLECT", 0)
+}
+
+// AmbientLightIntensity returns the ambient light intensity as a
+// percentage.
+func (s *ColorSensor) AmbientLightIntensity() (int, error) {
+	return intValue(s.Sensor, "COL-AMBIENT", 0)
+}
+
+// Color returns the detected color, one of the Color constants.
+func (s *ColorSensor) Color() (int, error) {
+	return intValue(s.Sensor, "COL-COLOR", 0)
+}
+
+// ColorName returns the name of the detected color.
+func (s *ColorSensor) ColorName() (string, error) {
+	c, err := s.Color()
+	if err != nil {
+		return "", err
+	}
+	if c < 0 || len(colorNames) <= c {
+		return "NoColor", nil
+	}
+	return colorNames[c], nil
+}
+
+// RGB returns the raw red, green and blue components of the detected color.
+func (s *ColorSensor) RGB() (r, g, b int, err error) {
+	r, err = intValue(s.Sensor, "RGB-RAW", 0)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	g, err = intValue(s.Sensor, "RGB-RAW", 1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	b, err = intValue(s.Sensor, "RGB-RAW", 2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return r, g, b, nil
+}
+
+// CalibrateWhite records the current reflected light intensity and raw
+// color readings as the readings for a white reference surface.
+func (s *ColorSensor) CalibrateWhite() error {
+	reflect, rgb, err := s.calibrationReadings()
+	if err != nil {
+		return err
+	}
+	s.cal.WhiteReflect = reflect
+	s.cal.White = rgb
+	return nil
+}
+
+// CalibrateBlack records the current reflected light intensity and raw
+// color readings as the readings for a black reference surface.
+func (s *ColorSensor) CalibrateBlack() error {
+	reflect, rgb, err := s.calibrationReadings()
+	if err != nil {
+		return err
+	}
+	s.cal.BlackReflect = reflect
+	s.cal.Black = rgb
+	return nil
+}
+
+// calibrationReadings returns the current reflected
+// light intensity and raw color readings.
+func (s *ColorSensor) calibrationReadings() (float64, calibration.RGB, error) {
+	reflect, err := s.ReflectedLightIntensity()
+	if err != nil {
+		return 0, calibration.RGB{}, err
+	}
+	r, g, b, err := s.RGB()
+	if err != nil {
+		return 0, calibration.RGB{}, err
+	}
+	return float64(reflect), calibration.RGB{R: r, G: g, B: b}, nil
+}
+
+// CalibratedReflect returns the reflected light intensity scaled so that
+// the black reference reading is 0 and the white reference reading is 100.
+// Readings outside the reference range are clamped to 0-100. An error is
+// returned if the white reference reading is not greater than the black
+// reference reading.
+func (s *ColorSensor) CalibratedReflect() (float64, error) {
+	return calibratedReflect(s.cal, func() (float64, error) {
+		v, err := s.ReflectedLightIntensity()
+		return float64(v), err
+	})
+}
+
+// calibratedReflect returns the reflected light intensity returned by read
+// scaled according to the reflectance calibration in c.
+func calibratedReflect(c calibration.Color, read func() (float64, error)) (float64, error) {
+	if c.WhiteReflect <= c.BlackReflect {
+		return 0, fmt.Errorf("ev3dev2: invalid reflectance calibration: black=%v white=%v",
+			c.BlackReflect, c.WhiteReflect)
+	}
+	v, err := read()
+	if err != nil {
+		return 0, err
+	}
+	return scaleReflect(v, c.BlackReflect, c.WhiteReflect), nil
+}
+
+// scaleReflect returns v scaled to 0-100 between black and white.
+func scaleReflect(v, black, white float64) float64 {
+	return math.Max(0, math.Min(100*(v-black)/(white-black), 100))
+}
+
+// Calibration returns the calibration of the sensor. The calibration can
+// be saved between runs using the calibration package, for example:
+//
+//	f := &calibration.File{Color: map[string]calibration.Color{
+//		addr: s.Calibration(),
+//	}}
+//	err := calibration.Save(path, f)
+func (s *ColorSensor) Calibration() calibration.Color {
+	return s.cal
+}
+
+// SetCalibration sets the calibration of the sensor, for example to a
+// calibration loaded by calibration.Load.
+func (s *ColorSensor) SetCalibration(c calibration.Color) {
+	s.cal = c
+}
+
+// GyroSensor is an EV3 gyro sensor.
+type GyroSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewGyroSensor returns a GyroSensor for the sensor at the given address.
+func NewGyroSensor(address string) (*GyroSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-ev3-gyro")
+	if err != nil {
+		return nil, err
+	}
+	return &GyroSensor{s}, nil
+}
+
+// Angle returns the number of degrees the sensor has been rotated since
+// it was last reset.
+func (s *GyroSensor) Angle() (int, error) {
+	return intValue(s.Sensor, "GYRO-ANG", 0)
+}
+
+// Rate returns the rate of rotation in degrees per second.
+func (s *GyroSensor) Rate() (int, error) {
+	return intValue(s.Sensor, "GYRO-RATE", 0)
+}
+
+// AngleAndRate returns the angle and rate of rotation.
+func (s *GyroSensor) AngleAndRate() (angle, rate int, err error) {
+	angle, err = intValue(s.Sensor, "GYRO-G&A", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	rate, err = intValue(s.Sensor, "GYRO-G&A", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return angle, rate, nil
+}
+
+// Reset resets the angle to zero by cycling the sensor mode.
+// The sensor must be held still while Reset is called.
+func (s *GyroSensor) Reset() error {
+	return s.SetMode("GYRO-RATE").SetMode("GYRO-ANG").Err()
+}
+
+// UltrasonicSensor is an EV3 ultrasonic sensor.
+type UltrasonicSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewUltrasonicSensor returns an UltrasonicSensor for the sensor at the given
+// address.
+func NewUltrasonicSensor(address string) (*UltrasonicSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-ev3-us")
+	if err != nil {
+		return nil, err
+	}
+	return &UltrasonicSensor{s}, nil
+}
+
+// DistanceCentimeters returns the measured distance in centimeters.
+func (s *UltrasonicSensor) DistanceCentimeters() (float64, error) {
+	return scaledValue(s.Sensor, "US-DIST-CM", 0)
+}
+
+// DistanceInches returns the measured distance in inches.
+func (s *UltrasonicSensor) DistanceInches() (float64, error) {
+	return scaledValue(s.Sensor, "US-DIST-IN", 0)
+}
+
+// OtherSensorPresent returns whether another ultrasonic sensor is
+// detected nearby.
+func (s *UltrasonicSensor) OtherSensorPresent() (bool, error) {
+	v, err := intValue(s.Sensor, "US-LISTEN", 0)
+	return v == 1, err
+}
+
+// InfraredSensor is an EV3 infrared sensor.
+type InfraredSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewInfraredSensor returns an InfraredSensor for the sensor at the given
+// address.
+func NewInfraredSensor(address string) (*InfraredSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-ev3-ir")
+	if err != nil {
+		return nil, err
+	}
+	return &InfraredSensor{s}, nil
+}
+
+// Proximity returns the proximity of an object as a percentage where 100
+// is approximately 70cm.
+func (s *InfraredSensor) Proximity() (int, error) {
+	return intValue(s.Sensor, "IR-PROX", 0)
+}
+
+// Seek returns the heading and distance of the beacon on the given
+// channel, 1 to 4, of the remote control. The heading is in the range
+// -25 to 25, with negative headings to the left of the sensor, and the
+// distance is a percentage as described for Proximity. If no beacon is
+// detected on the channel, distance is negative.
+func (s *InfraredSensor) Seek(channel int) (heading, distance int, err error) {
+	err = checkChannel(channel)
+	if err != nil {
+		return 0, 0, err
+	}
+	heading, err = intValue(s.Sensor, "IR-SEEK", 2*(channel-1))
+	if err != nil {
+		return 0, 0, err
+	}
+	distance, err = intValue(s.Sensor, "IR-SEEK", 2*(channel-1)+1)
+	if err != nil {
+		return 0, 0, err
+	}
+	heading, distance = seekValues(heading, distance)
+	return heading, distance, nil
+}
+
+// seekValues normalizes the raw IR-SEEK heading and distance values.
+// The driver reports a distance of -128 when no beacon is detected.
+func seekValues(heading, distance int) (int, int) {
+	if distance == -128 {
+		return 0, -1
+	}
+	return heading, distance
+}
+
+// RemoteButton is a set of buttons of the EV3 infrared remote control.
+type RemoteButton uint
+
+// Remote control buttons.
+const (
+	RedUp RemoteButton = 1 << iota
+	RedDown
+	BlueUp
+	BlueDown
+	Beacon
+)
+
+var remoteButtonNames = [...]string{
+	"RedUp",
+	"RedDown",
+	"BlueUp",
+	"BlueDown",
+	"Beacon",
+}
+
+// String satisfies the fmt.Stringer interface.
+func (b RemoteButton) String() string {
+	if b == 0 {
+		return "None"
+	}
+	var names []string
+	for i, n := range remoteButtonNames {
+		if b&(1<<uint(i)) != 0 {
+			names = append(names, n)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// remoteCodes maps the IR-REMOTE button codes reported
+// by the driver to the buttons they represent.
+var remoteCodes = [...]RemoteButton{
+	0:  0,
+	1:  RedUp,
+	2:  RedDown,
+	3:  BlueUp,
+	4:  BlueDown,
+	5:  RedUp | BlueUp,
+	6:  RedUp | BlueDown,
+	7:  RedDown | BlueUp,
+	8:  RedDown | BlueDown,
+	9:  Beacon,
+	10: RedUp | RedDown,
+	11: BlueUp | BlueDown,
+}
+
+// RemoteButtons returns the buttons pressed on the remote control set to
+// the given channel, 1 to 4. The remote reports at most two buttons at a
+// time, or the beacon button alone.
+func (s *InfraredSensor) RemoteButtons(channel int) (RemoteButton, error) {
+	err := checkChannel(channel)
+	if err != nil {
+		return 0, err
+	}
+	code, err := intValue(s.Sensor, "IR-REMOTE", channel-1)
+	if err != nil {
+		return 0, err
+	}
+	return remoteButtons(code)
+}
+
+// remoteButtons decodes an IR-REMOTE button code.
+func remoteButtons(code int) (RemoteButton, error) {
+	if code < 0 || len(remoteCodes) <= code {
+		return 0, fmt.Errorf("ev3dev2: invalid remote button code: %d", code)
+	}
+	return remoteCodes[code], nil
+}
+
+// checkChannel returns an error if channel is
+// not a valid remote control channel.
+func checkChannel(channel int) error {
+	if channel < 1 || 4 < channel {
+		return fmt.Errorf("ev3dev2: invalid remote channel: %d (must be 1-4)", channel)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// SoundSensor is a LEGO NXT sound sensor.
+type SoundSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewSoundSensor returns a SoundSensor for the sensor at the given
+// address.
+func NewSoundSensor(address string) (*SoundSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-nxt-sound")
+	if err != nil {
+		return nil, err
+	}
+	return &SoundSensor{s}, nil
+}
+
+// SoundPressure returns the sound pressure level as a percentage,
+// measured in the flat weighted DB mode.
+func (s *SoundSensor) SoundPressure() (float64, error) {
+	return scaledValue(s.Sensor, "DB", 0)
+}
+
+// SoundPressureLow returns the sound pressure level as a percentage,
+// measured in the A weighted DBA mode which matches the sensitivity of
+// the human ear.
+func (s *SoundSensor) SoundPressureLow() (float64, error) {
+	return scaledValue(s.Sensor, "DBA", 0)
+}
+
+// ClapDetector detects claps in a sequence of sound pressure levels. A
+// clap is a short peak: the level rises to Threshold or above after being
+// below Quiet, and falls back below Quiet within Window. Louder sounds that
+// last longer than Window are not claps.
+type ClapDetector struct {
+	// Threshold is the level at or
+	// above which a sound may be a
+	// clap. If Threshold is zero, a
+	// threshold of 60% is used.
+	Threshold float64
+
+	// Quiet is the level below which
+	// a sound has ended. If Quiet is
+	// zero, a level of 30% is used.
+	Quiet float64
+
+	// Window is the longest a clap
+	// may last. If Window is zero, a
+	// window of 250ms is used.
+	Window time.Duration
+
+	armed  bool
+	inPeak bool
+	start  time.Time
+}
+
+// Sample adds the level measured at time t and returns whether it
+// completes a clap.
+func (d *ClapDetector) Sample(t time.Time, level float64) bool {
+	threshold := d.Threshold
+	if threshold == 0 {
+		threshold = 60
+	}
+	quiet := d.Quiet
+	if quiet == 0 {
+		quiet = 30
+	}
+	window := d.Window
+	if window == 0 {
+		window = 250 * time.Millisecond
+	}
+
+	switch {
+	case d.inPeak && level < quiet:
+		d.inPeak = false
+		d.armed = true
+		return t.Sub(d.start) <= window
+	case d.inPeak:
+		if t.Sub(d.start) > window {
+			// The sound is too long to be a clap.
+			d.inPeak = false
+		}
+	case level < quiet:
+		d.armed = true
+	case d.armed && level >= threshold:
+		d.inPeak = true
+		d.armed = false
+		d.start = t
+	}
+	return false
+}
+
+// Claps places the sensor in DB mode and returns a channel that receives
+// the time of each clap found by d in the sound pressure levels read every
+// interval. The interval should be well below the detector's window. The
+// channel is closed when ctx is done, when the sensor is closed or when the
+// sensor can no longer be read.
+func (s *SoundSensor) Claps(ctx context.Context, d *ClapDetector, interval time.Duration) (<-chan time.Time, error) {
+	err := ensureMode(s.Sensor, "DB")
+	if err != nil {
+		return nil, err
+	}
+	samples, err := s.Stream(ctx, interval)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan time.Time)
+	go func() {
+		defer close(c)
+		for sample := range samples {
+			if len(sample.Values) == 0 || !d.Sample(sample.Time, sample.Values[0]) {
+				continue
+			}
+			select {
+			case c <- sample.Time:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
@@ -0,0 +1,133 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// TemperatureSensor is a LEGO NXT temperature sensor.
+type TemperatureSensor struct {
+	*ev3dev.Sensor
+}
+
+// NewTemperatureSensor returns a TemperatureSensor for the sensor at the
+// given address.
+func NewTemperatureSensor(address string) (*TemperatureSensor, error) {
+	s, err := ev3dev.SensorFor(address, "lego-nxt-temp")
+	if err != nil {
+		return nil, err
+	}
+	return &TemperatureSensor{s}, nil
+}
+
+// Celsius returns the temperature in degrees Celsius.
+func (s *TemperatureSensor) Celsius() (float64, error) {
+	return scaledValue(s.Sensor, "NXT-TEMP-C", 0)
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (s *TemperatureSensor) Fahrenheit() (float64, error) {
+	return scaledValue(s.Sensor, "NXT-TEMP-F", 0)
+}
+
+// TemperatureRange is the position of a temperature
+// relative to the thresholds of a temperature alert.
+type TemperatureRange int
+
+// Temperature ranges.
+const (
+	InRange TemperatureRange = iota
+	BelowRange
+	AboveRange
+)
+
+var temperatureRangeNames = [...]string{
+	InRange:    "InRange",
+	BelowRange: "BelowRange",
+	AboveRange: "AboveRange",
+}
+
+// String satisfies the fmt.Stringer interface.
+func (r TemperatureRange) String() string {
+	if r < 0 || len(temperatureRangeNames) <= int(r) {
+		return fmt.Sprintf("TemperatureRange(%d)", int(r))
+	}
+	return temperatureRangeNames[r]
+}
+
+// TemperatureAlert is a change in the range
+// of a temperature watched by Alert.
+type TemperatureAlert struct {
+	// Time is the time the
+	// change was seen.
+	Time time.Time
+
+	// Celsius is the temperature
+	// in degrees Celsius.
+	Celsius float64
+
+	// Range is the position of
+	// the temperature relative
+	// to the thresholds.
+	Range TemperatureRange
+}
+
+// Alert places the sensor in NXT-TEMP-C mode and returns a channel that
+// receives an alert each time the temperature moves below low, above high
+// or back into the range between them. The first alert holds the range of
+// the temperature when Alert is called. The sensor is checked every
+// interval as described for ev3dev.Sensor.Watch.
+//
+// The channel is closed when ctx is done, when the sensor is closed or
+// when the sensor can no longer be read.
+func (s *TemperatureSensor) Alert(ctx context.Context, low, high float64, interval time.Duration) (<-chan TemperatureAlert, error) {
+	if high < low {
+		return nil, fmt.Errorf("ev3dev2: invalid temperature thresholds: low=%v high=%v", low, high)
+	}
+	err := ensureMode(s.Sensor, "NXT-TEMP-C")
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.Watch(ctx, 0, interval)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan TemperatureAlert)
+	go temperatureAlerts(ctx, low, high, values, c)
+	return c, nil
+}
+
+// temperatureAlerts sends a TemperatureAlert on dst for each change
+// of range of the temperatures received from src, closing dst when
+// src is closed or ctx is done.
+func temperatureAlerts(ctx context.Context, low, high float64, src <-chan float64, dst chan<- TemperatureAlert) {
+	defer close(dst)
+	first := true
+	var last TemperatureRange
+	for v := range src {
+		r := InRange
+		switch {
+		case v < low:
+			r = BelowRange
+		case v > high:
+			r = AboveRange
+		}
+		if !first && r == last {
+			continue
+		}
+		first = false
+		last = r
+		select {
+		case dst <- TemperatureAlert{Time: time.Now(), Celsius: v, Range: r}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -21,10 +21,13 @@ var intFromTest = []struct {
 	wantInt int
 	wantErr error
 }{
-	{data: "", attr: "empty", err: nil, wantInt: -1, wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: strconv.Atoi: parsing "": invalid syntax at ev3dev_conv_test.go:`)},
+	{data: "", attr: "empty", err: nil, wantInt: -1, wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: malformed data "": empty value at ev3dev_conv_test.go:`)},
 	{data: "1", attr: "one", err: nil, wantInt: 1, wantErr: nil},
 	{data: "0", attr: "zero", err: nil, wantInt: 0, wantErr: nil},
 	{data: "-1", attr: "minus_one", err: nil, wantInt: -1, wantErr: nil},
+	{data: "1\x00", attr: "nul", err: nil, wantInt: -1, wantErr: errors.New(`ev3dev: failed to parse mock nul attribute path/mock/nul: malformed data "1\x00": embedded NUL at ev3dev_conv_test.go:`)},
+	{data: "1\n2", attr: "lines", err: nil, wantInt: -1, wantErr: errors.New(`ev3dev: failed to parse mock lines attribute path/mock/lines: malformed data "1\n2": multiple lines at ev3dev_conv_test.go:`)},
+	{data: " 1", attr: "space", err: nil, wantInt: -1, wantErr: errors.New(`ev3dev: failed to parse mock space attribute path/mock/space: strconv.Atoi: parsing " 1": invalid syntax at ev3dev_conv_test.go:`)},
 	{data: "0", attr: "prior", err: errors.New("prior error"), wantInt: -1, wantErr: errors.New("prior error")},
 }
 
@@ -38,6 +41,13 @@ func TestIntFrom(t *testing.T) {
 		if gotInt != test.wantInt {
 			t.Errorf("unexpected integer result: got:%d want:%d", gotInt, test.wantInt)
 		}
+		var malformed MalformedError
+		wantMalformed := strings.Contains(fmt.Sprint(test.wantErr), "malformed data")
+		if gotMalformed := errors.As(gotErr, &malformed); gotMalformed != wantMalformed {
+			t.Errorf("unexpected MalformedError match for %q: got:%t want:%t", test.data, gotMalformed, wantMalformed)
+		} else if gotMalformed && malformed.Data != test.data {
+			t.Errorf("unexpected MalformedError data: got:%q want:%q", malformed.Data, test.data)
+		}
 	}
 }
 
@@ -52,10 +62,13 @@ var float64FromTest = []struct {
 	wantFloat64 float64
 	wantErr     error
 }{
-	{data: "", attr: "empty", err: nil, wantFloat64: math.NaN(), wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: strconv.ParseFloat: parsing "": invalid syntax at ev3dev_conv_test.go:`)},
+	{data: "", attr: "empty", err: nil, wantFloat64: math.NaN(), wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: malformed data "": empty value at ev3dev_conv_test.go:`)},
 	{data: "1", attr: "one", err: nil, wantFloat64: 1, wantErr: nil},
 	{data: "0", attr: "zero", err: nil, wantFloat64: 0, wantErr: nil},
 	{data: "-1", attr: "minus_one", err: nil, wantFloat64: -1, wantErr: nil},
+	{data: "1.5", attr: "one_half", err: nil, wantFloat64: 1.5, wantErr: nil},
+	{data: "1,5", attr: "comma", err: nil, wantFloat64: math.NaN(), wantErr: errors.New(`ev3dev: failed to parse mock comma attribute path/mock/comma: strconv.ParseFloat: parsing "1,5": invalid syntax at ev3dev_conv_test.go:`)},
+	{data: "\x00", attr: "nul", err: nil, wantFloat64: math.NaN(), wantErr: errors.New(`ev3dev: failed to parse mock nul attribute path/mock/nul: malformed data "\x00": embedded NUL at ev3dev_conv_test.go:`)},
 	{data: "0", attr: "prior", err: errors.New("prior error"), wantFloat64: math.NaN(), wantErr: errors.New("prior error")},
 }
 
@@ -79,10 +92,12 @@ var durationFromTest = []struct {
 	wantDuration time.Duration
 	wantErr      error
 }{
-	{data: "", attr: "empty", err: nil, wantDuration: -1, wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: strconv.Atoi: parsing "": invalid syntax at ev3dev_conv_test.go:`)},
+	{data: "", attr: "empty", err: nil, wantDuration: -1, wantErr: errors.New(`ev3dev: failed to parse mock empty attribute path/mock/empty: malformed data "": empty value at ev3dev_conv_test.go:`)},
 	{data: "1", attr: "one", err: nil, wantDuration: 1 * time.Millisecond, wantErr: nil},
 	{data: "0", attr: "zero", err: nil, wantDuration: 0, wantErr: nil},
 	{data: "-1", attr: "minus_one", err: nil, wantDuration: -1 * time.Millisecond, wantErr: nil},
+	{data: "10\x00", attr: "nul", err: nil, wantDuration: -1, wantErr: errors.New(`ev3dev: failed to parse mock nul attribute path/mock/nul: malformed data "10\x00": embedded NUL at ev3dev_conv_test.go:`)},
+	{data: "9223372036854775", attr: "too_long", err: nil, wantDuration: -1, wantErr: errors.New(`ev3dev: failed to parse mock too_long attribute path/mock/too_long: strconv.ParseInt: parsing "9223372036854775": value out of range at ev3dev_conv_test.go:`)},
 	{data: "0", attr: "prior", err: errors.New("prior error"), wantDuration: -1, wantErr: errors.New("prior error")},
 }
 
@@ -169,7 +184,12 @@ var ueventFromTest = []struct {
 	{data: "", attr: "empty", err: nil, wantUevents: nil, wantErr: nil},
 	{data: "one=1", attr: "one", err: nil, wantUevents: ue{"one": "1"}, wantErr: nil},
 	{data: "zero=0\none=1", attr: "two", err: nil, wantUevents: ue{"zero": "0", "one": "1"}, wantErr: nil},
+	{data: "zero=0\none=1\n", attr: "trailing", err: nil, wantUevents: ue{"zero": "0", "one": "1"}, wantErr: nil},
+	{data: "\n", attr: "blank", err: nil, wantUevents: nil, wantErr: nil},
+	{data: "eq=a=b", attr: "eq", err: nil, wantUevents: ue{"eq": "a=b"}, wantErr: nil},
 	{data: "0", attr: "zero", err: nil, wantUevents: nil, wantErr: errors.New(`ev3dev: failed to parse mock zero attribute path/mock/zero: unexpected line: "0" at ev3dev_conv_test.go:`)},
+	{data: "=0", attr: "nokey", err: nil, wantUevents: nil, wantErr: errors.New(`ev3dev: failed to parse mock nokey attribute path/mock/nokey: unexpected line: "=0" at ev3dev_conv_test.go:`)},
+	{data: "one=1\ntw\x00", attr: "nul", err: nil, wantUevents: nil, wantErr: errors.New(`ev3dev: failed to parse mock nul attribute path/mock/nul: malformed data "one=1\ntw\x00": embedded NUL at ev3dev_conv_test.go:`)},
 	{data: "0", attr: "prior", err: errors.New("prior error"), wantUevents: nil, wantErr: errors.New("prior error")},
 }
 
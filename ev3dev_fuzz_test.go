@@ -0,0 +1,116 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package ev3dev
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func FuzzChomp(f *testing.F) {
+	for _, s := range []string{"", "\n", "1\n", "1", "1\n\n"} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		got := chomp(b)
+		if len(b) != 0 && b[len(b)-1] == '\n' {
+			if len(got) != len(b)-1 {
+				t.Errorf("unexpected chomp result for %q: got:%q", b, got)
+			}
+		} else if string(got) != string(b) {
+			t.Errorf("unexpected chomp result for %q: got:%q", b, got)
+		}
+	})
+}
+
+func FuzzIntFrom(f *testing.F) {
+	for _, s := range []string{"", "0", "-1", "100", "1\x00", "1\n2", " 1", "0x10", "99999999999999999999"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		i, err := intFrom(mockDevice{}, data, "fuzz", nil)
+		if err != nil {
+			if i != -1 {
+				t.Errorf("unexpected integer result with error for %q: got:%d", data, i)
+			}
+			if _, ok := err.(parseError); !ok {
+				t.Errorf("unexpected error type for %q: %T", data, err)
+			}
+			return
+		}
+		if strings.ContainsAny(data, "\x00\n") {
+			t.Errorf("accepted malformed data %q", data)
+		}
+		want, _ := strconv.Atoi(data)
+		if i != want {
+			t.Errorf("unexpected integer result for %q: got:%d want:%d", data, i, want)
+		}
+	})
+}
+
+func FuzzDurationFrom(f *testing.F) {
+	for _, s := range []string{"", "0", "-1", "100", "10\x00", "9223372036854775", "-9223372036854775"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		d, err := durationFrom(mockDevice{}, data, "fuzz", nil)
+		if err != nil {
+			if d != -1 {
+				t.Errorf("unexpected duration result with error for %q: got:%v", data, d)
+			}
+			if _, ok := err.(parseError); !ok {
+				t.Errorf("unexpected error type for %q: %T", data, err)
+			}
+			return
+		}
+		if d%time.Millisecond != 0 {
+			t.Errorf("unexpected sub-millisecond duration for %q: got:%v", data, d)
+		}
+		want, _ := strconv.ParseInt(data, 10, 64)
+		if int64(d/time.Millisecond) != want {
+			t.Errorf("unexpected duration result for %q: got:%v want:%dms", data, d, want)
+		}
+	})
+}
+
+func FuzzUeventFrom(f *testing.F) {
+	for _, s := range []string{"", "\n", "one=1", "zero=0\none=1\n", "eq=a=b", "=0", "0", "one=1\ntw\x00"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		uevent, err := ueventFrom(mockDevice{}, data, "fuzz", nil)
+		if err != nil {
+			if uevent != nil {
+				t.Errorf("unexpected uevent result with error for %q: got:%v", data, uevent)
+			}
+			if _, ok := err.(parseError); !ok {
+				t.Errorf("unexpected error type for %q: %T", data, err)
+			}
+			return
+		}
+		for k, v := range uevent {
+			if k == "" || strings.ContainsAny(k, "=\n\x00") || strings.ContainsAny(v, "\n\x00") {
+				t.Errorf("unexpected uevent entry for %q: %q=%q", data, k, v)
+			}
+		}
+	})
+}
+
+func FuzzStateFrom(f *testing.F) {
+	for _, s := range []string{"", running, running + " " + stalled, "invalid", running + "\x00", " "} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, data string) {
+		stat, err := stateFrom(mockDevice{}, data, "fuzz", nil)
+		if err != nil && stat != 0 {
+			t.Errorf("unexpected state result with error for %q: got:%v", data, stat)
+		}
+	})
+}
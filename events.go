@@ -0,0 +1,381 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"math"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind is the kind of an Event.
+type EventKind int
+
+const (
+	ButtonEvents EventKind = iota + 1
+	HotplugEvents
+	MotorStateEvents
+	PortStatusEvents
+	BatteryEvents
+	KeyEvents
+)
+
+// Event is a notification published on a Bus.
+type Event interface {
+	// Kind returns the kind of the event.
+	Kind() EventKind
+}
+
+// Kind returns ButtonEvents.
+func (ButtonEvent) Kind() EventKind { return ButtonEvents }
+
+// HotplugEvent is published when a device is added or removed.
+type HotplugEvent struct {
+	// Class is the sysfs class of the
+	// device, for example "tacho-motor".
+	Class string
+
+	// Name is the sysfs name of the
+	// device, for example "motor0".
+	Name string
+
+	// Added is true if the device was
+	// added and false if it was removed.
+	Added bool
+}
+
+// Kind returns HotplugEvents.
+func (HotplugEvent) Kind() EventKind { return HotplugEvents }
+
+// MotorStateEvent is published when the state of a tacho motor changes.
+// A stall is indicated by the Stalled flag being set in New.
+type MotorStateEvent struct {
+	// Name is the sysfs name of the motor.
+	Name string
+
+	// Old and New are the previous and
+	// current states of the motor.
+	Old, New MotorState
+}
+
+// Kind returns MotorStateEvents.
+func (MotorStateEvent) Kind() EventKind { return MotorStateEvents }
+
+// PortStatusEvent is published when the status of a lego-port changes.
+type PortStatusEvent struct {
+	// Name is the sysfs name of the port.
+	Name string
+
+	// Status is the new status of the port.
+	Status string
+}
+
+// Kind returns PortStatusEvents.
+func (PortStatusEvent) Kind() EventKind { return PortStatusEvents }
+
+// BatteryEvent is published when the voltage of a power supply changes.
+type BatteryEvent struct {
+	// Supply is the power supply.
+	Supply PowerSupply
+
+	// Voltage is the current voltage
+	// of the power supply.
+	Voltage float64
+}
+
+// Kind returns BatteryEvents.
+func (BatteryEvent) Kind() EventKind { return BatteryEvents }
+
+// Bus is a publish-subscribe event bus. Publishers send Events to the Bus
+// and each Subscription receives the events that pass its filter.
+type Bus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+// NewBus returns a new Bus.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription is a subscription to the events published on a Bus.
+type Subscription struct {
+	// C is the channel on which events
+	// are delivered. C is closed when the
+	// Subscription is closed.
+	C <-chan Event
+
+	c       chan Event
+	filter  func(Event) bool
+	bus     *Bus
+	dropped uint64
+}
+
+// Subscribe returns a new Subscription that receives the events published
+// on the Bus for which filter returns true. If filter is nil, all events are
+// received. The Subscription's channel holds up to buffer undelivered events.
+func (b *Bus) Subscribe(buffer int, filter func(Event) bool) *Subscription {
+	c := make(chan Event, buffer)
+	s := &Subscription{C: c, c: c, filter: filter, bus: b}
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+// Publish sends e to all subscriptions whose filter accepts it. Publish
+// does not block; if a subscription's channel is full, the event is dropped
+// for that subscription and counted by its Dropped method. Filters are
+// called without the Bus being locked, so a filter may use the Bus.
+func (b *Bus) Publish(e Event) {
+	b.mu.Lock()
+	subs := make([]*Subscription, 0, len(b.subs))
+	for s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.Unlock()
+
+	accepted := subs[:0]
+	for _, s := range subs {
+		if s.filter == nil || s.filter(e) {
+			accepted = append(accepted, s)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range accepted {
+		if _, ok := b.subs[s]; !ok {
+			// The subscription was closed
+			// while filtering.
+			continue
+		}
+		select {
+		case s.c <- e:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+}
+
+// Dropped returns the number of events that could not be delivered to the
+// Subscription because its channel was full.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close removes the Subscription from its Bus and closes its channel.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	if _, ok := s.bus.subs[s]; !ok {
+		return
+	}
+	delete(s.bus.subs, s)
+	close(s.c)
+}
+
+// Kinds returns a Subscription filter that accepts events of the
+// given kinds.
+func Kinds(kinds ...EventKind) func(Event) bool {
+	return func(e Event) bool {
+		k := e.Kind()
+		for _, want := range kinds {
+			if k == want {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// PublishButtons publishes the button events received on events to bus
+// until events is closed. It is intended to be used with the Events
+// channel of a ButtonWaiter.
+func PublishButtons(bus *Bus, events <-chan ButtonEvent) {
+	for e := range events {
+		bus.Publish(e)
+	}
+}
+
+// hotplugClasses maps the device classes watched
+// for hot-plug events to their sysfs paths.
+var hotplugClasses = map[string]string{
+	"tacho-motor": TachoMotorPath,
+	"dc-motor":    DCMotorPath,
+	"servo-motor": ServoMotorPath,
+	"lego-sensor": SensorPath,
+	"lego-port":   LegoPortPath,
+}
+
+// Watcher polls the device tree and publishes hot-plug, motor state,
+// port status and battery events to a Bus.
+type Watcher struct {
+	// Bus is the bus events are
+	// published to.
+	Bus *Bus
+
+	// Interval is the polling interval.
+	// If Interval is zero, the device
+	// tree is polled every second.
+	Interval time.Duration
+
+	// Battery is the power supply to watch.
+	// The zero value is interpreted as
+	// described for PowerSupply.
+	Battery PowerSupply
+
+	// BatteryDelta is the change in voltage
+	// that causes a BatteryEvent to be
+	// published. If BatteryDelta is zero,
+	// battery events are not published.
+	BatteryDelta float64
+
+	// devices, states and statuses are
+	// keyed by class and name since device
+	// names are only unique within a class.
+	devices  map[string]bool
+	states   map[string]MotorState
+	statuses map[string]string
+	voltage  float64
+}
+
+// defaultWatchInterval is the default
+// Watcher polling interval.
+const defaultWatchInterval = time.Second
+
+// NewWatcher returns a Watcher that publishes to bus every interval. The
+// returned Watcher watches the default power supply with a battery delta
+// of 0.1V.
+func NewWatcher(bus *Bus, interval time.Duration) *Watcher {
+	return &Watcher{Bus: bus, Interval: interval, BatteryDelta: 0.1}
+}
+
+// Run polls the device tree every Interval until done is closed. The
+// first poll establishes the initial state of the devices and does not
+// publish events except for the initial battery voltage.
+func (w *Watcher) Run(done <-chan struct{}) {
+	interval := w.Interval
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.poll()
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+	}
+}
+
+// poll reads the device tree, publishing events for
+// changes since the last poll.
+func (w *Watcher) poll() {
+	first := w.devices == nil
+	if first {
+		w.devices = make(map[string]bool)
+		w.states = make(map[string]MotorState)
+		w.statuses = make(map[string]string)
+		w.voltage = math.NaN()
+	}
+
+	seen := make(map[string]bool)
+	var events []Event
+	for class, path := range hotplugClasses {
+		names, err := devicesIn(filepath.Join(prefix, path))
+		if err != nil {
+			continue
+		}
+		for _, n := range names {
+			key := class + "/" + n
+			seen[key] = true
+			if !w.devices[key] && !first {
+				events = append(events, HotplugEvent{Class: class, Name: n, Added: true})
+			}
+			w.devices[key] = true
+
+			switch class {
+			case "tacho-motor":
+				stat, err := stateFrom(attributeOf(namedDevice{path: path, name: n}, state))
+				if err != nil {
+					continue
+				}
+				old, ok := w.states[key]
+				if ok && old != stat {
+					events = append(events, MotorStateEvent{Name: n, Old: old, New: stat})
+				}
+				w.states[key] = stat
+			case "lego-port":
+				status, err := stringFrom(attributeOf(namedDevice{path: path, name: n}, status))
+				if err != nil {
+					continue
+				}
+				old, ok := w.statuses[key]
+				if ok && old != status {
+					events = append(events, PortStatusEvent{Name: n, Status: status})
+				}
+				w.statuses[key] = status
+			}
+		}
+	}
+	for key := range w.devices {
+		if seen[key] {
+			continue
+		}
+		delete(w.devices, key)
+		delete(w.states, key)
+		delete(w.statuses, key)
+		class, n := filepath.Split(key)
+		class = class[:len(class)-1]
+		events = append(events, HotplugEvent{Class: class, Name: n, Added: false})
+	}
+	// Map iteration order is random, so sort
+	// events to publish them in a stable order.
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventKey(events[i]) < eventKey(events[j])
+	})
+
+	if w.BatteryDelta != 0 {
+		v, err := w.Battery.Voltage()
+		if err == nil && (math.IsNaN(w.voltage) || math.Abs(v-w.voltage) >= w.BatteryDelta) {
+			events = append(events, BatteryEvent{Supply: w.Battery, Voltage: v})
+			w.voltage = v
+		}
+	}
+
+	for _, e := range events {
+		w.Bus.Publish(e)
+	}
+}
+
+// eventKey returns a sort key for events published by a Watcher.
+func eventKey(e Event) string {
+	switch e := e.(type) {
+	case HotplugEvent:
+		return "0" + e.Class + "/" + e.Name
+	case MotorStateEvent:
+		return "1" + e.Name
+	case PortStatusEvent:
+		return "2" + e.Name
+	}
+	return "3"
+}
+
+// namedDevice is a Device identified by its sysfs class
+// path and name.
+type namedDevice struct {
+	path, name string
+}
+
+func (d namedDevice) Path() string   { return filepath.Join(prefix, d.path) }
+func (d namedDevice) Type() string   { return "" }
+func (d namedDevice) String() string { return d.name }
+func (d namedDevice) Err() error     { return nil }
@@ -0,0 +1,180 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	all := bus.Subscribe(10, nil)
+	buttons := bus.Subscribe(1, Kinds(ButtonEvents))
+
+	events := []Event{
+		ButtonEvent{Button: Left},
+		HotplugEvent{Class: "tacho-motor", Name: "motor0", Added: true},
+		ButtonEvent{Button: Right},
+	}
+	for _, e := range events {
+		bus.Publish(e)
+	}
+	all.Close()
+	buttons.Close()
+	buttons.Close()
+
+	var got []Event
+	for e := range all.C {
+		got = append(got, e)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("unexpected events for unfiltered subscription:\ngot: %v\nwant:%v", got, events)
+	}
+	got = got[:0]
+	for e := range buttons.C {
+		got = append(got, e)
+	}
+	if want := events[:1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events for filtered subscription:\ngot: %v\nwant:%v", got, want)
+	}
+	if buttons.Dropped() != 1 {
+		t.Errorf("unexpected dropped count: got:%d want:1", buttons.Dropped())
+	}
+
+	// Publishing after all subscriptions
+	// are closed must not panic.
+	bus.Publish(ButtonEvent{})
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, data string) {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		err = ioutil.WriteFile(path, []byte(data+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	write(filepath.Join(TachoMotorPath, "motor0", state), running)
+	write(filepath.Join(LegoPortPath, "port0", status), "no-device")
+	write(filepath.Join(PowerSupplyPath, "lego-ev3-battery", voltageNow), "7500000")
+
+	bus := NewBus()
+	sub := bus.Subscribe(10, nil)
+	w := NewWatcher(bus, 0)
+	w.Battery = "lego-ev3-battery"
+
+	w.poll()
+	write(filepath.Join(TachoMotorPath, "motor0", state), running+" "+stalled)
+	write(filepath.Join(LegoPortPath, "port0", status), "ev3-large-motor")
+	write(filepath.Join(SensorPath, "sensor0", "uevent"), "")
+	write(filepath.Join(PowerSupplyPath, "lego-ev3-battery", voltageNow), "7450000")
+	w.poll()
+	err = os.RemoveAll(filepath.Join(dir, TachoMotorPath, "motor0"))
+	if err != nil {
+		t.Fatalf("failed to remove motor: %v", err)
+	}
+	write(filepath.Join(PowerSupplyPath, "lego-ev3-battery", voltageNow), "7000000")
+	w.poll()
+	sub.Close()
+
+	var got []Event
+	for e := range sub.C {
+		got = append(got, e)
+	}
+	want := []Event{
+		BatteryEvent{Supply: "lego-ev3-battery", Voltage: 7.5},
+		HotplugEvent{Class: "lego-sensor", Name: "sensor0", Added: true},
+		MotorStateEvent{Name: "motor0", Old: Running, New: Running | Stalled},
+		PortStatusEvent{Name: "port0", Status: "ev3-large-motor"},
+		HotplugEvent{Class: "tacho-motor", Name: "motor0", Added: false},
+		BatteryEvent{Supply: "lego-ev3-battery", Voltage: 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events:\ngot: %v\nwant:%v", got, want)
+	}
+}
+
+func TestBusFilterReentry(t *testing.T) {
+	bus := NewBus()
+	var inner *Subscription
+	sub := bus.Subscribe(1, func(e Event) bool {
+		// Filters may use the bus.
+		if inner == nil {
+			inner = bus.Subscribe(1, nil)
+		}
+		return true
+	})
+	bus.Publish(ButtonEvent{Button: Left})
+	sub.Close()
+	if inner == nil {
+		t.Fatal("filter was not called")
+	}
+	inner.Close()
+	if got := <-sub.C; got != (ButtonEvent{Button: Left}) {
+		t.Errorf("unexpected event: got:%v want:%v", got, ButtonEvent{Button: Left})
+	}
+}
+
+func TestWatcherSameName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {state: running},
+		filepath.Join(DCMotorPath, "motor0"):    {state: running},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	bus := NewBus()
+	sub := bus.Subscribe(10, nil)
+	w := &Watcher{Bus: bus}
+
+	w.poll()
+	// Removing the DC motor must not affect the
+	// state of the tacho motor with the same name.
+	err = os.RemoveAll(filepath.Join(dir, DCMotorPath, "motor0"))
+	if err != nil {
+		t.Fatalf("failed to remove motor: %v", err)
+	}
+	w.poll()
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {state: running + " " + stalled},
+	})
+	w.poll()
+	sub.Close()
+
+	var got []Event
+	for e := range sub.C {
+		got = append(got, e)
+	}
+	want := []Event{
+		HotplugEvent{Class: "dc-motor", Name: "motor0", Added: false},
+		MotorStateEvent{Name: "motor0", Old: Running, New: Running | Stalled},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events:\ngot: %v\nwant:%v", got, want)
+	}
+}
@@ -31,7 +31,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to find medium motor on outA: %v", err)
 	}
-	err = outA.SetStopAction("brake").Err()
+	err = outA.SetStopAction(ev3dev.StopActionBrake).Err()
 	if err != nil {
 		log.Fatalf("failed to set brake stop for medium motor on outA: %v", err)
 	}
@@ -42,7 +42,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to find left large motor on outB: %v", err)
 	}
-	err = outB.SetStopAction("brake").Err()
+	err = outB.SetStopAction(ev3dev.StopActionBrake).Err()
 	if err != nil {
 		log.Fatalf("failed to set brake stop for left large motor on outB: %v", err)
 	}
@@ -53,7 +53,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to find right large motor on outC: %v", err)
 	}
-	err = outC.SetStopAction("brake").Err()
+	err = outC.SetStopAction(ev3dev.StopActionBrake).Err()
 	if err != nil {
 		log.Fatalf("failed to set brake stop for right large motor on outB: %v", err)
 	}
@@ -63,36 +63,36 @@ func main() {
 		draw.Draw(ev3.LCD, ev3.LCD.Bounds(), gopher, gopher.Bounds().Min, draw.Src)
 
 		// Run medium motor on outA at speed 50, wait for 0.5 second and then brake.
-		outA.SetSpeedSetpoint(50 * maxMedium / 100).Command("run-forever")
+		outA.SetSpeedSetpoint(50 * maxMedium / 100).Command(ev3dev.CommandRunForever)
 		time.Sleep(time.Second / 2)
-		outA.Command("stop")
+		outA.Command(ev3dev.CommandStop)
 		checkErrors(outA)
 
 		// Run large motors on B+C at speed 70, wait for 2 second and then brake.
-		outB.SetSpeedSetpoint(70 * maxLarge / 100).Command("run-forever")
-		outC.SetSpeedSetpoint(70 * maxLarge / 100).Command("run-forever")
+		outB.SetSpeedSetpoint(70 * maxLarge / 100).Command(ev3dev.CommandRunForever)
+		outC.SetSpeedSetpoint(70 * maxLarge / 100).Command(ev3dev.CommandRunForever)
 		checkErrors(outB, outC)
 		time.Sleep(2 * time.Second)
-		outB.Command("stop")
-		outC.Command("stop")
+		outB.Command(ev3dev.CommandStop)
+		outC.Command(ev3dev.CommandStop)
 		checkErrors(outB, outC)
 
 		// Run medium motor on outA at speed -75, wait for 0.5 second and then brake.
-		outA.SetSpeedSetpoint(-75 * maxMedium / 100).Command("run-forever")
+		outA.SetSpeedSetpoint(-75 * maxMedium / 100).Command(ev3dev.CommandRunForever)
 		time.Sleep(time.Second / 2)
-		outA.Command("stop")
+		outA.Command(ev3dev.CommandStop)
 		checkErrors(outA)
 
 		// Render the gopher to the screen.
 		draw.Draw(ev3.LCD, ev3.LCD.Bounds(), gopherSquint, gopherSquint.Bounds().Min, draw.Src)
 
 		// Run large motors on B at speed -50 and C at speed 50, wait for 1 second and then brake.
-		outB.SetSpeedSetpoint(-50 * maxLarge / 100).Command("run-forever")
-		outC.SetSpeedSetpoint(50 * maxLarge / 100).Command("run-forever")
+		outB.SetSpeedSetpoint(-50 * maxLarge / 100).Command(ev3dev.CommandRunForever)
+		outC.SetSpeedSetpoint(50 * maxLarge / 100).Command(ev3dev.CommandRunForever)
 		checkErrors(outB, outC)
 		time.Sleep(time.Second)
-		outB.Command("stop")
-		outC.Command("stop")
+		outB.Command(ev3dev.CommandStop)
+		outC.Command(ev3dev.CommandStop)
 		checkErrors(outB, outC)
 	}
 }
@@ -105,7 +105,7 @@ func znap() {
 			if dist < 25 {
 				err = jaw.
 					SetSpeedSetpoint(-max).
-					Command("run-forever").
+					Command(ev3dev.CommandRunForever).
 					Err()
 				if err != nil {
 					log.Fatalf("failed to run jaw motor: %v", err)
@@ -116,8 +116,8 @@ func znap() {
 				time.Sleep(time.Second / 4)
 
 				err = jaw.
-					SetStopAction("coast").
-					Command("stop").
+					SetStopAction(ev3dev.StopActionCoast).
+					Command(ev3dev.CommandStop).
 					Err()
 				if err != nil {
 					log.Fatalf("failed to stop jaw motor: %v", err)
@@ -135,8 +135,8 @@ func znap() {
 				err = jaw.
 					SetSpeedSetpoint(max).
 					SetTimeSetpoint(time.Second).
-					SetStopAction("hold").
-					Command("run-timed").
+					SetStopAction(ev3dev.StopActionHold).
+					Command(ev3dev.CommandRunTimed).
 					Err()
 				if err != nil {
 					log.Fatalf("failed to run jaw motor: %v", err)
@@ -145,8 +145,8 @@ func znap() {
 				err = jaw.
 					SetSpeedSetpoint(max).
 					SetPositionSetpoint(-120).
-					SetStopAction("hold").
-					Command("run-to-rel-pos").
+					SetStopAction(ev3dev.StopActionHold).
+					Command(ev3dev.CommandRunToRelPos).
 					Err()
 				if err != nil {
 					log.Fatalf("failed to run jaw motor: %v", err)
@@ -164,8 +164,8 @@ func znap() {
 				err = jaw.
 					SetSpeedSetpoint(max).
 					SetPositionSetpoint(120).
-					SetStopAction("coast").
-					Command("run-to-rel-pos").
+					SetStopAction(ev3dev.StopActionCoast).
+					Command(ev3dev.CommandRunToRelPos).
 					Err()
 				if err != nil {
 					log.Fatalf("failed to run jaw motor: %v", err)
@@ -196,7 +196,7 @@ func wander() {
 		SetPolarity(ev3dev.Inversed).
 		SetRampUpSetpoint(200 * time.Millisecond).
 		SetRampDownSetpoint(200 * time.Millisecond).
-		SetStopAction("hold").
+		SetStopAction(ev3dev.StopActionHold).
 		Err()
 	if err != nil {
 		log.Fatalf("failed to set initialize left track: %v", err)
@@ -211,7 +211,7 @@ func wander() {
 		SetPolarity(ev3dev.Inversed).
 		SetRampUpSetpoint(200 * time.Millisecond).
 		SetRampDownSetpoint(200 * time.Millisecond).
-		SetStopAction("hold").
+		SetStopAction(ev3dev.StopActionHold).
 		Err()
 	if err != nil {
 		log.Fatalf("failed to set initialize right track: %v", err)
@@ -245,8 +245,8 @@ func wander() {
 		}
 		setChecking(false)
 
-		left.Command("stop")
-		right.Command("stop")
+		left.Command(ev3dev.CommandStop)
+		right.Command(ev3dev.CommandStop)
 		err = left.Err()
 		if err != nil {
 			log.Fatalf("failed to stop left track: %v", err)
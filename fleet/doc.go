@@ -0,0 +1,7 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fleet provides utilities for coordinating several EV3 bricks
+// over a network.
+package fleet
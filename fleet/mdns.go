@@ -0,0 +1,285 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServiceType is the DNS-SD service type used for swarm announcements.
+const ServiceType = "_ev3dev._udp"
+
+// DNS resource record types and classes used by swarm announcements.
+const (
+	typePTR = 12
+	typeTXT = 16
+	typeSRV = 33
+	typeANY = 255
+
+	classIN = 1
+
+	// cacheFlush is the mDNS cache-flush bit set in the
+	// class of records that are unique to the announcer.
+	cacheFlush = 0x8000
+)
+
+// recordTTL is the time to live in seconds of announced records.
+const recordTTL = 120
+
+// flagResponse is the DNS header flag set for authoritative responses.
+const flagResponse = 0x8400
+
+// serviceLabels returns the labels of the DNS-SD service domain name.
+func serviceLabels() []string {
+	return append(strings.Split(ServiceType, "."), "local")
+}
+
+// announcementMessage returns an mDNS response announcing state as an
+// instance of ServiceType reachable on the given port. The state is
+// carried in the TXT record of the instance.
+func announcementMessage(state State, port int) ([]byte, error) {
+	if state.Name == "" || len(state.Name) > 63 {
+		return nil, fmt.Errorf("fleet: invalid name length: %d (must be in 1-63)", len(state.Name))
+	}
+	service := serviceLabels()
+	instance := append([]string{state.Name}, service...)
+	host := []string{state.Name, "local"}
+
+	txt, err := txtData(state)
+	if err != nil {
+		return nil, err
+	}
+	srv := make([]byte, 6)
+	binary.BigEndian.PutUint16(srv[4:], uint16(port))
+	srv = appendName(srv, host)
+
+	msg := make([]byte, 12)
+	binary.BigEndian.PutUint16(msg[2:], flagResponse)
+	binary.BigEndian.PutUint16(msg[6:], 3)
+	msg = appendRecord(msg, service, typePTR, classIN, appendName(nil, instance))
+	msg = appendRecord(msg, instance, typeSRV, classIN|cacheFlush, srv)
+	msg = appendRecord(msg, instance, typeTXT, classIN|cacheFlush, txt)
+	return msg, nil
+}
+
+// txtData returns the TXT record data holding state.
+func txtData(state State) ([]byte, error) {
+	pairs := []string{
+		"v=" + strconv.Itoa(announcementVersion),
+		"x=" + formatFloat(state.X),
+		"y=" + formatFloat(state.Y),
+		"heading=" + formatFloat(state.Heading),
+	}
+	if state.Role != "" {
+		pairs = append(pairs, "role="+state.Role)
+	}
+	if state.Battery != 0 {
+		pairs = append(pairs, "battery="+formatFloat(state.Battery))
+	}
+	var txt []byte
+	for _, p := range pairs {
+		if len(p) > 255 {
+			return nil, fmt.Errorf("fleet: TXT entry too long: %q", p)
+		}
+		txt = append(txt, byte(len(p)))
+		txt = append(txt, p...)
+	}
+	return txt, nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// appendName appends the uncompressed domain name with the given labels to b.
+func appendName(b []byte, labels []string) []byte {
+	for _, l := range labels {
+		b = append(b, byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, 0)
+}
+
+// appendRecord appends a resource record to b.
+func appendRecord(b []byte, name []string, typ, class uint16, data []byte) []byte {
+	b = appendName(b, name)
+	var hdr [10]byte
+	binary.BigEndian.PutUint16(hdr[0:], typ)
+	binary.BigEndian.PutUint16(hdr[2:], class)
+	binary.BigEndian.PutUint32(hdr[4:], recordTTL)
+	binary.BigEndian.PutUint16(hdr[8:], uint16(len(data)))
+	b = append(b, hdr[:]...)
+	return append(b, data...)
+}
+
+var errMessage = errors.New("fleet: malformed DNS message")
+
+// message is the swarm-relevant content of an mDNS message.
+type message struct {
+	// query is whether the message is a query
+	// for instances of ServiceType.
+	query bool
+
+	// states holds the states announced in
+	// TXT records of ServiceType instances.
+	states []State
+}
+
+// parseMessage returns the swarm-relevant content of the mDNS message in
+// msg. Records that are not related to ServiceType are ignored.
+func parseMessage(msg []byte) (message, error) {
+	var m message
+	if len(msg) < 12 {
+		return m, errMessage
+	}
+	isResponse := msg[2]&0x80 != 0
+	qd := int(binary.BigEndian.Uint16(msg[4:]))
+	rr := int(binary.BigEndian.Uint16(msg[6:])) +
+		int(binary.BigEndian.Uint16(msg[8:])) +
+		int(binary.BigEndian.Uint16(msg[10:]))
+	service := serviceLabels()
+
+	off := 12
+	for i := 0; i < qd; i++ {
+		name, next, err := readName(msg, off)
+		if err != nil {
+			return m, err
+		}
+		if len(msg) < next+4 {
+			return m, errMessage
+		}
+		typ := binary.BigEndian.Uint16(msg[next:])
+		if !isResponse && (typ == typePTR || typ == typeANY) && equalLabels(name, service) {
+			m.query = true
+		}
+		off = next + 4
+	}
+	if isResponse {
+		for i := 0; i < rr; i++ {
+			name, next, err := readName(msg, off)
+			if err != nil {
+				return m, err
+			}
+			if len(msg) < next+10 {
+				return m, errMessage
+			}
+			typ := binary.BigEndian.Uint16(msg[next:])
+			n := int(binary.BigEndian.Uint16(msg[next+8:]))
+			data := next + 10
+			if len(msg) < data+n {
+				return m, errMessage
+			}
+			if typ == typeTXT && len(name) == len(service)+1 && equalLabels(name[1:], service) {
+				state, ok := parseTXT(name[0], msg[data:data+n])
+				if ok {
+					m.states = append(m.states, state)
+				}
+			}
+			off = data + n
+		}
+	}
+	return m, nil
+}
+
+// parseTXT returns the state held in the TXT record data of the named
+// instance. It returns false if the data is not a valid announcement of
+// the current version.
+func parseTXT(name string, data []byte) (State, bool) {
+	state := State{Name: name}
+	var version int
+	for len(data) != 0 {
+		n := int(data[0])
+		if len(data) < n+1 {
+			return State{}, false
+		}
+		kv := string(data[1 : n+1])
+		data = data[n+1:]
+
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			continue
+		}
+		k, v := strings.ToLower(kv[:i]), kv[i+1:]
+		var err error
+		switch k {
+		case "v":
+			version, err = strconv.Atoi(v)
+		case "role":
+			state.Role = v
+		case "x":
+			state.X, err = strconv.ParseFloat(v, 64)
+		case "y":
+			state.Y, err = strconv.ParseFloat(v, 64)
+		case "heading":
+			state.Heading, err = strconv.ParseFloat(v, 64)
+		case "battery":
+			state.Battery, err = strconv.ParseFloat(v, 64)
+		}
+		if err != nil {
+			return State{}, false
+		}
+	}
+	return state, version == announcementVersion
+}
+
+// maxPointers is the maximum number of compression pointers
+// followed when reading a name, preventing pointer loops.
+const maxPointers = 16
+
+// readName reads the possibly compressed domain name starting at off in
+// msg. It returns the labels of the name and the offset following the
+// name in msg.
+func readName(msg []byte, off int) (labels []string, next int, err error) {
+	next = -1
+	for ptrs := 0; ; {
+		if len(msg) <= off {
+			return nil, 0, errMessage
+		}
+		n := int(msg[off])
+		switch n & 0xc0 {
+		case 0x00:
+			if n == 0 {
+				if next < 0 {
+					next = off + 1
+				}
+				return labels, next, nil
+			}
+			if len(msg) < off+1+n {
+				return nil, 0, errMessage
+			}
+			labels = append(labels, string(msg[off+1:off+1+n]))
+			off += 1 + n
+		case 0xc0:
+			if len(msg) < off+2 || ptrs == maxPointers {
+				return nil, 0, errMessage
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+			ptrs++
+		default:
+			return nil, 0, errMessage
+		}
+	}
+}
+
+// equalLabels returns whether a and b are the same
+// domain name ignoring ASCII case.
+func equalLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnnouncementMessage(t *testing.T) {
+	want := State{Name: "alice", Role: "leader", X: 1.5, Y: -2, Heading: 90, Battery: 7.5}
+	msg, err := announcementMessage(want, 5353)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, err := parseMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error parsing announcement: %v", err)
+	}
+	if m.query {
+		t.Error("announcement parsed as query")
+	}
+	if !reflect.DeepEqual(m.states, []State{want}) {
+		t.Errorf("unexpected states: got:%+v want:%+v", m.states, []State{want})
+	}
+
+	for _, name := range []string{"", string(make([]byte, 64))} {
+		_, err = announcementMessage(State{Name: name}, 5353)
+		if err == nil {
+			t.Errorf("expected error for name length %d", len(name))
+		}
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	// A response using name compression as produced by
+	// other mDNS responders. The PTR record's target
+	// refers back to the service name and the TXT
+	// record's owner refers to the PTR target.
+	msg := []byte{0, 0, 0x84, 0, 0, 0, 0, 2, 0, 0, 0, 0}
+	service := len(msg)
+	msg = appendName(msg, serviceLabels())
+	msg = append(msg, 0, typePTR, 0, classIN, 0, 0, 0, 120, 0, 8)
+	instance := len(msg)
+	msg = append(msg, 5, 'c', 'a', 'r', 'o', 'l', 0xc0, byte(service))
+	txt := []byte("\x03v=1\x05x=0.5\x0arole=scout")
+	msg = append(msg, 0xc0, byte(instance))
+	msg = append(msg, 0, typeTXT, 0x80, classIN, 0, 0, 0, 120, 0, byte(len(txt)))
+	msg = append(msg, txt...)
+
+	m, err := parseMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []State{{Name: "carol", Role: "scout", X: 0.5}}
+	if !reflect.DeepEqual(m.states, want) {
+		t.Errorf("unexpected states: got:%+v want:%+v", m.states, want)
+	}
+
+	query := []byte{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}
+	query = appendName(query, []string{"_EV3DEV", "_udp", "local"})
+	query = append(query, 0, typePTR, 0, classIN)
+	m, err = parseMessage(query)
+	if err != nil {
+		t.Fatalf("unexpected error parsing query: %v", err)
+	}
+	if !m.query {
+		t.Error("expected service query")
+	}
+
+	other := []byte{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}
+	other = appendName(other, []string{"_http", "_tcp", "local"})
+	other = append(other, 0, typePTR, 0, classIN)
+	m, err = parseMessage(other)
+	if err != nil {
+		t.Fatalf("unexpected error parsing query: %v", err)
+	}
+	if m.query {
+		t.Error("unexpected service query for other service")
+	}
+
+	loop := []byte{0, 0, 0x84, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0xc0, 12}
+	_, err = parseMessage(loop)
+	if err == nil {
+		t.Error("expected error for compression loop")
+	}
+
+	truncated := make([]byte, len(msg)-4)
+	copy(truncated, msg)
+	_, err = parseMessage(truncated)
+	if err == nil {
+		t.Error("expected error for truncated message")
+	}
+
+	stale := make([]byte, len(msg))
+	copy(stale, msg)
+	// Replace the version in "\x03v=1".
+	stale[len(stale)-len(txt)+3] = '0'
+	m, err = parseMessage(stale)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.states) != 0 {
+		t.Errorf("unexpected states for old version: %+v", m.states)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+func init() {
+	ev3dev.RegisterFeature("fleet")
+}
+
+// Rendezvous message types.
+const (
+	syncRequest = 'S'
+	syncReply   = 'R'
+	startNotify = 'G'
+)
+
+// startRepeats is the number of times a start message
+// is sent to each peer to tolerate packet loss.
+const startRepeats = 3
+
+// Leader coordinates a synchronized start of a group of Followers.
+// Followers estimate the offset between their clock and the Leader's
+// clock by exchanging timestamped UDP packets with the Leader, so no
+// external time source is required.
+type Leader struct {
+	conn net.PacketConn
+
+	mu    sync.Mutex
+	peers map[string]net.Addr
+
+	// closed is set by Close so that Serve
+	// can distinguish a closed connection
+	// from a read error.
+	closed bool
+
+	// now is the clock used by the Leader.
+	// It is replaced during testing.
+	now func() time.Time
+}
+
+// NewLeader returns a new Leader using the provided connection. The
+// Leader does not respond to Followers until Serve is called.
+func NewLeader(conn net.PacketConn) *Leader {
+	return &Leader{conn: conn, peers: make(map[string]net.Addr), now: time.Now}
+}
+
+// Serve responds to clock synchronization requests from Followers and
+// records each Follower as a peer to be notified by Start. Serve returns
+// when the Leader's connection is closed.
+func (l *Leader) Serve() error {
+	var buf [64]byte
+	for {
+		n, addr, err := l.conn.ReadFrom(buf[:])
+		if err != nil {
+			l.mu.Lock()
+			closed := l.closed
+			l.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		if n != 9 || buf[0] != syncRequest {
+			continue
+		}
+		var reply [17]byte
+		reply[0] = syncReply
+		copy(reply[1:9], buf[1:9])
+		binary.BigEndian.PutUint64(reply[9:], uint64(l.now().UnixNano()))
+		_, err = l.conn.WriteTo(reply[:], addr)
+		if err != nil {
+			continue
+		}
+		l.mu.Lock()
+		l.peers[addr.String()] = addr
+		l.mu.Unlock()
+	}
+}
+
+// Peers returns the number of Followers that have synchronized
+// with the Leader.
+func (l *Leader) Peers() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.peers)
+}
+
+// Start notifies all synchronized Followers to start after the given
+// delay and then waits until the start time. Start returns the start
+// time. The delay must be long enough for the notification to reach
+// all the Followers.
+func (l *Leader) Start(delay time.Duration) (time.Time, error) {
+	if delay < 0 {
+		return time.Time{}, fmt.Errorf("fleet: invalid start delay: %v (must not be negative)", delay)
+	}
+	start := l.now().Add(delay)
+	var msg [9]byte
+	msg[0] = startNotify
+	binary.BigEndian.PutUint64(msg[1:], uint64(start.UnixNano()))
+
+	l.mu.Lock()
+	peers := make([]net.Addr, 0, len(l.peers))
+	for _, addr := range l.peers {
+		peers = append(peers, addr)
+	}
+	l.mu.Unlock()
+
+	var first error
+	for i := 0; i < startRepeats; i++ {
+		for _, addr := range peers {
+			_, err := l.conn.WriteTo(msg[:], addr)
+			if err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	time.Sleep(start.Sub(l.now()))
+	return start, first
+}
+
+// Close closes the Leader's connection.
+func (l *Leader) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	return l.conn.Close()
+}
+
+// Follower is a member of a group coordinated by a Leader.
+type Follower struct {
+	conn   net.PacketConn
+	leader net.Addr
+
+	offset time.Duration
+	rtt    time.Duration
+
+	// last is the Leader's start time of the
+	// last start notification acted on.
+	last uint64
+}
+
+// Join synchronizes with the Leader at the given address, returning a
+// Follower. Join makes the given number of clock synchronization
+// exchanges with the Leader, each waiting for at most timeout, and
+// estimates the clock offset from the exchange with the shortest round
+// trip time. Join returns an error if no exchange succeeds.
+func Join(conn net.PacketConn, leader net.Addr, samples int, timeout time.Duration) (*Follower, error) {
+	if samples < 1 {
+		return nil, fmt.Errorf("fleet: invalid number of samples: %d (must be positive)", samples)
+	}
+	f := &Follower{conn: conn, leader: leader, rtt: -1}
+	var last error
+	for i := 0; i < samples; i++ {
+		offset, rtt, err := f.exchange(timeout)
+		if err != nil {
+			last = err
+			continue
+		}
+		if f.rtt < 0 || rtt < f.rtt {
+			f.offset = offset
+			f.rtt = rtt
+		}
+	}
+	if f.rtt < 0 {
+		return nil, fmt.Errorf("fleet: failed to synchronize with %v: %w", leader, last)
+	}
+	return f, nil
+}
+
+// exchange performs a single clock synchronization exchange with the
+// Leader, returning the estimated clock offset and the round trip time.
+func (f *Follower) exchange(timeout time.Duration) (offset, rtt time.Duration, err error) {
+	var req [9]byte
+	req[0] = syncRequest
+	t0 := time.Now()
+	binary.BigEndian.PutUint64(req[1:], uint64(t0.UnixNano()))
+	_, err = f.conn.WriteTo(req[:], f.leader)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = f.conn.SetReadDeadline(t0.Add(timeout))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.conn.SetReadDeadline(time.Time{})
+
+	var buf [64]byte
+	for {
+		n, _, err := f.conn.ReadFrom(buf[:])
+		if err != nil {
+			return 0, 0, err
+		}
+		t1 := time.Now()
+		if n != 17 || buf[0] != syncReply || binary.BigEndian.Uint64(buf[1:9]) != uint64(t0.UnixNano()) {
+			// Ignore stale replies and
+			// unexpected messages.
+			continue
+		}
+		leader := time.Unix(0, int64(binary.BigEndian.Uint64(buf[9:])))
+		rtt = t1.Sub(t0)
+		// Assume the reply was generated half way
+		// through the round trip.
+		offset = leader.Sub(t0.Add(rtt / 2))
+		return offset, rtt, nil
+	}
+}
+
+// Offset returns the estimated offset of the Leader's clock from the
+// local clock.
+func (f *Follower) Offset() time.Duration { return f.offset }
+
+// RTT returns the round trip time of the exchange used to estimate the
+// clock offset. The error of the offset estimate is at most half the
+// round trip time.
+func (f *Follower) RTT() time.Duration { return f.rtt }
+
+// WaitStart waits for a start notification from the Leader and then waits
+// until the start time, returning the start time in the local clock.
+// WaitStart returns an error if no notification is received within timeout.
+// Repeats of a notification that has already been acted on are ignored. If
+// the start time of a new notification has already passed, WaitStart
+// returns immediately.
+func (f *Follower) WaitStart(timeout time.Duration) (time.Time, error) {
+	err := f.conn.SetReadDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.conn.SetReadDeadline(time.Time{})
+
+	var buf [64]byte
+	for {
+		n, _, err := f.conn.ReadFrom(buf[:])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if n != 9 || buf[0] != startNotify {
+			continue
+		}
+		leader := binary.BigEndian.Uint64(buf[1:])
+		if leader == f.last {
+			// Ignore repeats of an earlier
+			// start notification.
+			continue
+		}
+		f.last = leader
+		start := time.Unix(0, int64(leader)).Add(-f.offset)
+		time.Sleep(time.Until(start))
+		return start, nil
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRendezvous(t *testing.T) {
+	const (
+		followers = 3
+		skew      = time.Hour
+		tolerance = 20 * time.Millisecond
+	)
+
+	lc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	leader := NewLeader(lc)
+	// Run the leader's clock an hour ahead
+	// of the followers' clocks.
+	leader.now = func() time.Time { return time.Now().Add(skew) }
+	served := make(chan error)
+	go func() { served <- leader.Serve() }()
+
+	type result struct {
+		start time.Time
+		err   error
+	}
+	results := make(chan result, followers)
+	joined := make(chan *Follower, followers)
+	for i := 0; i < followers; i++ {
+		fc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		defer fc.Close()
+		f, err := Join(fc, lc.LocalAddr(), 5, time.Second)
+		if err != nil {
+			t.Fatalf("failed to join: %v", err)
+		}
+		if d := f.Offset() - skew; d < -tolerance || tolerance < d {
+			t.Errorf("unexpected offset estimate: got:%v want:%v±%v", f.Offset(), skew, tolerance)
+		}
+		joined <- f
+	}
+	close(joined)
+	for f := range joined {
+		go func(f *Follower) {
+			start, err := f.WaitStart(5 * time.Second)
+			results <- result{start: start, err: err}
+		}(f)
+	}
+
+	if n := leader.Peers(); n != followers {
+		t.Errorf("unexpected number of peers: got:%d want:%d", n, followers)
+	}
+	start, err := leader.Start(200 * time.Millisecond)
+	if err != nil {
+		t.Errorf("unexpected error starting: %v", err)
+	}
+	want := start.Add(-skew)
+	for i := 0; i < followers; i++ {
+		r := <-results
+		if r.err != nil {
+			t.Errorf("unexpected error waiting for start: %v", r.err)
+			continue
+		}
+		if d := r.start.Sub(want); d < -tolerance || tolerance < d {
+			t.Errorf("unexpected start time: got:%v want:%v±%v", r.start, want, tolerance)
+		}
+	}
+
+	err = leader.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing leader: %v", err)
+	}
+	err = <-served
+	if err != nil {
+		t.Errorf("unexpected error from Serve: %v", err)
+	}
+}
+
+func TestJoinTimeout(t *testing.T) {
+	// Listen without serving so that
+	// synchronization requests are
+	// never answered.
+	lc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lc.Close()
+	fc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer fc.Close()
+	_, err = Join(fc, lc.LocalAddr(), 2, 10*time.Millisecond)
+	if err == nil {
+		t.Error("expected error joining unresponsive leader")
+	}
+}
+
+func TestWaitStartRepeats(t *testing.T) {
+	lc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lc.Close()
+	fc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer fc.Close()
+	f := &Follower{conn: fc, leader: lc.LocalAddr()}
+
+	notify := func(start time.Time) {
+		var msg [9]byte
+		msg[0] = startNotify
+		binary.BigEndian.PutUint64(msg[1:], uint64(start.UnixNano()))
+		_, err := lc.WriteTo(msg[:], fc.LocalAddr())
+		if err != nil {
+			t.Fatalf("failed to notify: %v", err)
+		}
+	}
+
+	// A notification received late must
+	// start the follower immediately.
+	late := time.Now().Add(-time.Second)
+	for i := 0; i < startRepeats; i++ {
+		notify(late)
+	}
+	begin := time.Now()
+	start, err := f.WaitStart(time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for late start: %v", err)
+	}
+	if !start.Equal(late) {
+		t.Errorf("unexpected start time: got:%v want:%v", start, late)
+	}
+	if d := time.Since(begin); d > 100*time.Millisecond {
+		t.Errorf("unexpected delay for late start: %v", d)
+	}
+
+	// The remaining repeats must be
+	// ignored by the next wait.
+	_, err = f.WaitStart(50 * time.Millisecond)
+	if err == nil {
+		t.Error("expected timeout after repeated notifications")
+	}
+}
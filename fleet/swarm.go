@@ -0,0 +1,205 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultGroup is the mDNS multicast group address used for swarm
+// announcements.
+const DefaultGroup = "224.0.0.251:5353"
+
+// announcementVersion is the version of the swarm
+// announcement TXT record format.
+const announcementVersion = 1
+
+// State is the state shared by a brick with its swarm.
+type State struct {
+	// Name is the unique name of the brick.
+	Name string `json:"name"`
+
+	// Role is the user-defined role
+	// of the brick in the swarm.
+	Role string `json:"role,omitempty"`
+
+	// X, Y and Heading are the estimated
+	// position and heading of the brick in
+	// user-defined units.
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Heading float64 `json:"heading"`
+
+	// Battery is the battery voltage
+	// of the brick.
+	Battery float64 `json:"battery,omitempty"`
+}
+
+// Peer is a brick discovered by a Swarm.
+type Peer struct {
+	State
+
+	// Addr is the address the peer
+	// announced from.
+	Addr net.Addr
+
+	// Seen is the time the last
+	// announcement was received.
+	Seen time.Time
+}
+
+// Swarm discovers peer bricks and shares state with them using DNS-SD
+// over multicast DNS. Each brick is announced as an instance of the
+// ServiceType service named by its State Name, with the remaining
+// fields of its State held in the instance's TXT record as v, role,
+// x, y, heading and battery keys. Bricks can therefore be browsed
+// with standard DNS-SD tools, for example
+//
+//	avahi-browse -r _ev3dev._udp
+//
+// Name must be a valid DNS label of at most 63 bytes.
+type Swarm struct {
+	conn  net.PacketConn
+	group net.Addr
+
+	mu    sync.Mutex
+	self  State
+	peers map[string]Peer
+
+	// closed is set by Close so that Serve
+	// can distinguish a closed connection
+	// from a read error.
+	closed bool
+}
+
+// ListenGroup returns a connection listening on the multicast group at
+// the given address, and the group address for use with NewSwarm. If
+// ifi is nil, the system's default multicast interface is used.
+func ListenGroup(address string, ifi *net.Interface) (*net.UDPConn, *net.UDPAddr, error) {
+	group, err := net.ResolveUDPAddr("udp4", address)
+	if err != nil {
+		return nil, nil, err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", ifi, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, group, nil
+}
+
+// NewSwarm returns a Swarm that receives announcements on conn and sends
+// its own state to the group address. The provided state's Name must be
+// unique within the swarm.
+func NewSwarm(conn net.PacketConn, group net.Addr, self State) *Swarm {
+	return &Swarm{conn: conn, group: group, self: self, peers: make(map[string]Peer)}
+}
+
+// SetState sets the state shared by the Swarm. The Name of the state
+// is not altered.
+func (s *Swarm) SetState(state State) {
+	s.mu.Lock()
+	state.Name = s.self.Name
+	s.self = state
+	s.mu.Unlock()
+}
+
+// Announce sends the Swarm's state to the group as an mDNS response.
+func (s *Swarm) Announce() error {
+	var port int
+	if addr, ok := s.conn.LocalAddr().(*net.UDPAddr); ok {
+		port = addr.Port
+	}
+	s.mu.Lock()
+	msg, err := announcementMessage(s.self, port)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.WriteTo(msg, s.group)
+	return err
+}
+
+// Serve receives announcements from peers until the Swarm's connection is
+// closed, and announces the Swarm's state in response to DNS-SD queries
+// for ServiceType. Invalid messages, records unrelated to ServiceType and
+// the Swarm's own announcements are ignored.
+func (s *Swarm) Serve() error {
+	// mDNS messages may be up to 9000 bytes.
+	buf := make([]byte, 9000)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		m, err := parseMessage(buf[:n])
+		if err != nil {
+			continue
+		}
+		if m.query {
+			// Failure to answer is not fatal; the
+			// querier will retry and periodic
+			// announcements continue.
+			s.Announce()
+		}
+		now := time.Now()
+		s.mu.Lock()
+		for _, state := range m.states {
+			if state.Name != s.self.Name {
+				s.peers[state.Name] = Peer{State: state, Addr: addr, Seen: now}
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Run announces the Swarm's state every interval until done is closed.
+func (s *Swarm) Run(interval time.Duration, done <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.Announce()
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-done:
+			return nil
+		}
+	}
+}
+
+// Peers returns the peers that have announced within maxAge, sorted by
+// name. If maxAge is zero, all peers that have ever announced are returned.
+func (s *Swarm) Peers(maxAge time.Duration) []Peer {
+	now := time.Now()
+	s.mu.Lock()
+	peers := make([]Peer, 0, len(s.peers))
+	for _, p := range s.peers {
+		if maxAge == 0 || now.Sub(p.Seen) <= maxAge {
+			peers = append(peers, p)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+	return peers
+}
+
+// Close closes the Swarm's connection.
+func (s *Swarm) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	return s.conn.Close()
+}
@@ -0,0 +1,81 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fleet
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSwarm(t *testing.T) {
+	// Use unicast loopback addresses in place of
+	// a multicast group so the test does not depend
+	// on multicast routing being available.
+	ca, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	cb, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	a := NewSwarm(ca, cb.LocalAddr(), State{Name: "alice", Role: "leader"})
+	b := NewSwarm(cb, cb.LocalAddr(), State{Name: "bob", Role: "follower"})
+	served := make(chan error, 2)
+	go func() { served <- a.Serve() }()
+	go func() { served <- b.Serve() }()
+
+	a.SetState(State{Name: "ignored", Role: "leader", X: 1, Y: 2, Heading: 90, Battery: 7.5})
+	err = a.Announce()
+	if err != nil {
+		t.Fatalf("unexpected error announcing: %v", err)
+	}
+	// Bob's announcement is sent to himself
+	// and must be ignored.
+	err = b.Announce()
+	if err != nil {
+		t.Fatalf("unexpected error announcing: %v", err)
+	}
+	_, err = ca.WriteTo([]byte("not an announcement"), cb.LocalAddr())
+	if err != nil {
+		t.Fatalf("unexpected error writing: %v", err)
+	}
+
+	var peers []Peer
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		peers = b.Peers(time.Minute)
+		if len(peers) != 0 {
+			break
+		}
+	}
+	if len(peers) != 1 {
+		t.Fatalf("unexpected number of peers: got:%d want:1", len(peers))
+	}
+	want := State{Name: "alice", Role: "leader", X: 1, Y: 2, Heading: 90, Battery: 7.5}
+	if peers[0].State != want {
+		t.Errorf("unexpected peer state: got:%+v want:%+v", peers[0].State, want)
+	}
+	if peers[0].Addr.String() != ca.LocalAddr().String() {
+		t.Errorf("unexpected peer address: got:%v want:%v", peers[0].Addr, ca.LocalAddr())
+	}
+	if n := len(a.Peers(0)); n != 0 {
+		t.Errorf("unexpected peers for alice: got:%d want:0", n)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if n := len(b.Peers(10 * time.Millisecond)); n != 0 {
+		t.Errorf("unexpected number of fresh peers: got:%d want:0", n)
+	}
+
+	a.Close()
+	b.Close()
+	for i := 0; i < 2; i++ {
+		err = <-served
+		if err != nil {
+			t.Errorf("unexpected error from Serve: %v", err)
+		}
+	}
+}
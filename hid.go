@@ -0,0 +1,207 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InputDevice is an input event device, for example a USB HID numeric
+// keypad or presentation clicker.
+type InputDevice struct {
+	// Name is the name reported
+	// by the device driver.
+	Name string
+
+	// Event is the name of the
+	// event device, "event2" for
+	// example.
+	Event string
+}
+
+// DevPath returns the path to the device's event device node.
+func (d InputDevice) DevPath() string { return filepath.Join("/dev/input", d.Event) }
+
+// InputDevices returns the input event devices present on the system,
+// including the brick buttons, sorted by event device name.
+func InputDevices() ([]InputDevice, error) {
+	names, err := devicesIn(filepath.Join(prefix, InputPath))
+	if err != nil {
+		return nil, fmt.Errorf("ev3dev: failed to list input devices: %w", err)
+	}
+	var devices []InputDevice
+	for _, n := range names {
+		if !strings.HasPrefix(n, "event") {
+			continue
+		}
+		b, err := readAttr(context.Background(), filepath.Join(prefix, InputPath, n, "device", "name"))
+		if err != nil {
+			return nil, fmt.Errorf("ev3dev: failed to read input device name for %s: %w", n, err)
+		}
+		devices = append(devices, InputDevice{Name: string(chomp(b)), Event: n})
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Event < devices[j].Event })
+	return devices, nil
+}
+
+// Linux key codes for keys commonly found on keypads and presentation
+// clickers. Other codes are listed in linux/input-event-codes.h.
+const (
+	KeyEsc      = 1
+	KeyEnter    = 28
+	KeyB        = 48
+	KeyF5       = 63
+	KeyKP7      = 71
+	KeyKP8      = 72
+	KeyKP9      = 73
+	KeyKPMinus  = 74
+	KeyKP4      = 75
+	KeyKP5      = 76
+	KeyKP6      = 77
+	KeyKPPlus   = 78
+	KeyKP1      = 79
+	KeyKP2      = 80
+	KeyKP3      = 81
+	KeyKP0      = 82
+	KeyKPDot    = 83
+	KeyKPEnter  = 96
+	KeyPageUp   = 104
+	KeyPageDown = 109
+)
+
+// Key event values.
+const (
+	KeyReleased = 0
+	KeyPressed  = 1
+	KeyRepeated = 2
+)
+
+// KeyEvent is a key event from an input device, including the time of the
+// event. The Err value reflects any error state arising from reading the
+// event.
+type KeyEvent struct {
+	// Device is the name of the
+	// device reporting the event.
+	Device string
+
+	// Code is the Linux key code.
+	Code uint16
+
+	// Value is KeyReleased, KeyPressed
+	// or KeyRepeated.
+	Value     uint
+	TimeStamp time.Duration
+	Err       error
+}
+
+// Kind returns KeyEvents.
+func (KeyEvent) Kind() EventKind { return KeyEvents }
+
+// KeyReader provides a mechanism to block waiting for key events from an
+// input device. After a read error is delivered, the Events channel is
+// closed; this happens when a USB device is unplugged.
+type KeyReader struct {
+	Events <-chan KeyEvent
+
+	f    *os.File
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewKeyReader returns a KeyReader for the given input device.
+func NewKeyReader(dev InputDevice) (*KeyReader, error) {
+	f, err := os.Open(dev.DevPath())
+	if err != nil {
+		return nil, fmt.Errorf("ev3dev: failed to open input device %q: %v", dev.Name, err)
+	}
+	return newKeyReader(f, dev.Name), nil
+}
+
+// ev_key is the input event type of key events.
+const ev_key = 1
+
+func newKeyReader(f *os.File, name string) *KeyReader {
+	c := make(chan KeyEvent)
+	r := &KeyReader{Events: c, f: f, done: make(chan struct{})}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer close(c)
+		var buf [16]byte
+		for {
+			var e KeyEvent
+			_, err := io.ReadFull(f, buf[:])
+			if err != nil {
+				select {
+				case <-r.done:
+					// The read was interrupted by Close.
+					return
+				default:
+				}
+				e = KeyEvent{Device: name, Err: err}
+			} else {
+				if binary.LittleEndian.Uint16(buf[8:10]) != ev_key {
+					// Ignore synchronization and
+					// scan code events.
+					continue
+				}
+				e = getKeyEvent(buf[:])
+				e.Device = name
+			}
+			select {
+			case c <- e:
+			case <-r.done:
+				return
+			}
+			if e.Err != nil {
+				return
+			}
+		}
+	}()
+	return r
+}
+
+func getKeyEvent(buf []byte) KeyEvent {
+	sec := binary.LittleEndian.Uint32(buf[:4])
+	usec := binary.LittleEndian.Uint32(buf[4:8])
+	return KeyEvent{
+		Code:      binary.LittleEndian.Uint16(buf[10:12]),
+		TimeStamp: time.Duration(sec)*time.Second + time.Duration(usec)*time.Microsecond,
+		Value:     uint(binary.LittleEndian.Uint32(buf[12:16])),
+	}
+}
+
+// Close closes the input device and the Events channel. A pending read
+// of the input device is interrupted by Close.
+func (r *KeyReader) Close() error {
+	select {
+	case <-r.done:
+		return nil
+	default:
+		close(r.done)
+		err := r.f.Close()
+		r.wg.Wait()
+		return err
+	}
+}
+
+// PublishKeys publishes the key events received on events to bus until
+// events is closed. It is intended to be used with the Events channel of
+// a KeyReader.
+func PublishKeys(bus *Bus, events <-chan KeyEvent) {
+	for e := range events {
+		bus.Publish(e)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInputDevices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(InputPath, "event1", "device"): {"name": "USB Keypad"},
+		filepath.Join(InputPath, "event0", "device"): {"name": "EV3 Brick Buttons"},
+		filepath.Join(InputPath, "input0"):           {"name": "EV3 Brick Buttons"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	got, err := InputDevices()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []InputDevice{
+		{Name: "EV3 Brick Buttons", Event: "event0"},
+		{Name: "USB Keypad", Event: "event1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected input devices:\ngot: %+v\nwant:%+v", got, want)
+	}
+	if p := got[1].DevPath(); p != "/dev/input/event1" {
+		t.Errorf("unexpected device path: got:%q want:%q", p, "/dev/input/event1")
+	}
+}
+
+func inputEvent(sec, usec uint32, typ, code uint16, value uint32) []byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint32(b[0:4], sec)
+	binary.LittleEndian.PutUint32(b[4:8], usec)
+	binary.LittleEndian.PutUint16(b[8:10], typ)
+	binary.LittleEndian.PutUint16(b[10:12], code)
+	binary.LittleEndian.PutUint32(b[12:16], value)
+	return b[:]
+}
+
+func TestKeyReader(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer w.Close()
+
+	keys := newKeyReader(r, "USB Keypad")
+	bus := NewBus()
+	sub := bus.Subscribe(10, Kinds(KeyEvents))
+	done := make(chan struct{})
+	go func() {
+		PublishKeys(bus, keys.Events)
+		close(done)
+	}()
+
+	var events []byte
+	for _, e := range [][]byte{
+		inputEvent(1, 500, 4, 4, 0x70059), // EV_MSC scan code.
+		inputEvent(1, 500, ev_key, KeyKP1, KeyPressed),
+		inputEvent(1, 500, 0, 0, 0), // EV_SYN.
+		inputEvent(2, 0, ev_key, KeyKP1, KeyReleased),
+	} {
+		events = append(events, e...)
+	}
+	_, err = w.Write(events)
+	if err != nil {
+		t.Fatalf("failed to write events: %v", err)
+	}
+
+	want := []KeyEvent{
+		{Device: "USB Keypad", Code: KeyKP1, Value: KeyPressed, TimeStamp: time.Second + 500*time.Microsecond},
+		{Device: "USB Keypad", Code: KeyKP1, Value: KeyReleased, TimeStamp: 2 * time.Second},
+	}
+	for i, w := range want {
+		select {
+		case e := <-sub.C:
+			if e != w {
+				t.Errorf("unexpected event %d: got:%+v want:%+v", i, e, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", i)
+		}
+	}
+
+	err = keys.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing key reader: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("PublishKeys did not return after Close")
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httpdebug provides an HTTP debug server for inspecting ev3dev
+// devices on a running brick.
+package httpdebug
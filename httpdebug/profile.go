@@ -0,0 +1,256 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpdebug
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxProfileDuration is the maximum duration of CPU profiles
+// and execution traces.
+const maxProfileDuration = 5 * time.Minute
+
+// EnableProfiling adds profiling and runtime statistics endpoints to
+// the Server. Profiling is disabled by default.
+//
+// The endpoints are
+//
+//	/debug/pprof/         index of available profiles
+//	/debug/pprof/cmdline  command line of the program
+//	/debug/pprof/profile  CPU profile, ?seconds=n (default 30)
+//	/debug/pprof/symbol   symbol lookup for go tool pprof
+//	/debug/pprof/trace    execution trace, ?seconds=n (default 1)
+//	/debug/pprof/{name}   named runtime profile, for example heap
+//	/debug/runtime        runtime statistics as JSON
+//
+// Profiles are written in the format read by go tool pprof. The
+// handlers are registered only on the Server's own mux; importing
+// httpdebug does not add handlers to http.DefaultServeMux.
+//
+// Unless allowRemote is true, the profiling endpoints only respond to
+// requests from the loopback interface, so remote profiling requires
+// an SSH tunnel to the brick. EnableProfiling must be called before the
+// Server starts handling requests.
+func (s *Server) EnableProfiling(allowRemote bool) {
+	guard := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !allowRemote && !isLoopback(r.RemoteAddr) {
+				http.Error(w, "profiling only available from loopback", http.StatusForbidden)
+				return
+			}
+			h(w, r)
+		}
+	}
+	s.mux.HandleFunc("/debug/pprof/", guard(profileIndex))
+	s.mux.HandleFunc("/debug/pprof/cmdline", guard(cmdline))
+	s.mux.HandleFunc("/debug/pprof/profile", guard(cpuProfile))
+	s.mux.HandleFunc("/debug/pprof/symbol", guard(symbol))
+	s.mux.HandleFunc("/debug/pprof/trace", guard(executionTrace))
+	s.mux.HandleFunc("/debug/runtime", guard(s.runtimeStats))
+	s.start = time.Now()
+}
+
+// isLoopback returns whether the host of addr is a loopback address.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// seconds returns the duration specified by the seconds query
+// parameter of r or def if it is not present.
+func seconds(r *http.Request, def time.Duration) (time.Duration, error) {
+	v := r.URL.Query().Get("seconds")
+	if v == "" {
+		return def, nil
+	}
+	sec, err := strconv.Atoi(v)
+	d := time.Duration(sec) * time.Second
+	if err != nil || d <= 0 || maxProfileDuration < d {
+		return 0, fmt.Errorf("invalid seconds %q (must be in 1-%d)", v, int(maxProfileDuration/time.Second))
+	}
+	return d, nil
+}
+
+// sleep waits for d or until the request is cancelled.
+func sleep(r *http.Request, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+}
+
+func profileIndex(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/")
+	if name != "" {
+		p := pprof.Lookup(name)
+		if p == nil {
+			http.NotFound(w, r)
+			return
+		}
+		debug, _ := strconv.Atoi(r.URL.Query().Get("debug"))
+		if debug == 0 {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		p.WriteTo(w, debug)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := profileTemplate.Execute(w, pprof.Profiles())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var profileTemplate = template.Must(template.New("profiles").Parse(`<!DOCTYPE html>
+<html>
+<head><title>ev3dev profiles</title></head>
+<body>
+<h1>ev3dev profiles</h1>
+<ul>
+<li><a href="profile">profile</a> (CPU, 30s)</li>
+<li><a href="trace">trace</a> (1s)</li>
+{{range .}}<li><a href="{{.Name}}?debug=1">{{.Name}}</a> ({{.Count}})</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+func cpuProfile(w http.ResponseWriter, r *http.Request) {
+	d, err := seconds(r, 30*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	err = pprof.StartCPUProfile(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(r, d)
+	pprof.StopCPUProfile()
+}
+
+func executionTrace(w http.ResponseWriter, r *http.Request) {
+	d, err := seconds(r, time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="trace"`)
+	err = trace.Start(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleep(r, d)
+	trace.Stop()
+}
+
+func cmdline(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, strings.Join(os.Args, "\x00"))
+}
+
+// symbol implements the symbol lookup protocol used by go tool pprof.
+// A GET reports that symbols are available and a POST body holding
+// '+' separated hexadecimal program counters is answered with one
+// line per resolved address giving the address and function name.
+func symbol(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "num_symbols: 1")
+	if r.Method == "POST" {
+		b := bufio.NewReader(r.Body)
+		for {
+			word, err := b.ReadSlice('+')
+			if err == nil {
+				word = word[:len(word)-1]
+			}
+			pc, _ := strconv.ParseUint(string(word), 0, 64)
+			if pc != 0 {
+				if f := runtime.FuncForPC(uintptr(pc)); f != nil {
+					fmt.Fprintf(&buf, "%#x %s\n", pc, f.Name())
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				break
+			}
+		}
+	}
+	w.Write(buf.Bytes())
+}
+
+// RuntimeStats holds the runtime statistics served by a Server
+// with profiling enabled. Uptime is the time since profiling was
+// enabled and the remaining fields correspond to the values of
+// the same names in the runtime package.
+type RuntimeStats struct {
+	Uptime        time.Duration `json:"uptime_ns"`
+	Goroutines    int           `json:"goroutines"`
+	GOMAXPROCS    int           `json:"gomaxprocs"`
+	HeapAlloc     uint64        `json:"heap_alloc"`
+	HeapSys       uint64        `json:"heap_sys"`
+	HeapObjects   uint64        `json:"heap_objects"`
+	TotalAlloc    uint64        `json:"total_alloc"`
+	Mallocs       uint64        `json:"mallocs"`
+	Frees         uint64        `json:"frees"`
+	NumGC         uint32        `json:"num_gc"`
+	PauseTotal    time.Duration `json:"pause_total_ns"`
+	LastPause     time.Duration `json:"last_pause_ns"`
+	GCCPUFraction float64       `json:"gc_cpu_fraction"`
+}
+
+func (s *Server) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	stats := RuntimeStats{
+		Uptime:        time.Since(s.start),
+		Goroutines:    runtime.NumGoroutine(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		HeapAlloc:     m.HeapAlloc,
+		HeapSys:       m.HeapSys,
+		HeapObjects:   m.HeapObjects,
+		TotalAlloc:    m.TotalAlloc,
+		Mallocs:       m.Mallocs,
+		Frees:         m.Frees,
+		NumGC:         m.NumGC,
+		PauseTotal:    time.Duration(m.PauseTotalNs),
+		LastPause:     time.Duration(m.PauseNs[(m.NumGC+255)%256]),
+		GCCPUFraction: m.GCCPUFraction,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
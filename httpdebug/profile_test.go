@@ -0,0 +1,78 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpdebug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProfiling(t *testing.T) {
+	const (
+		remote   = "192.0.2.1:1234"
+		loopback = "127.0.0.1:1234"
+	)
+
+	s := NewServer()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/runtime", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unexpected status with profiling disabled: got:%d want:%d", rec.Code, http.StatusNotFound)
+	}
+
+	s.EnableProfiling(false)
+	for _, test := range []struct {
+		path   string
+		remote string
+		want   int
+	}{
+		{path: "/debug/runtime", remote: remote, want: http.StatusForbidden},
+		{path: "/debug/pprof/", remote: remote, want: http.StatusForbidden},
+		{path: "/debug/runtime", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/heap", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/goroutine?debug=1", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/missing", remote: loopback, want: http.StatusNotFound},
+		{path: "/debug/pprof/cmdline", remote: remote, want: http.StatusForbidden},
+		{path: "/debug/pprof/cmdline", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/symbol", remote: remote, want: http.StatusForbidden},
+		{path: "/debug/pprof/symbol", remote: loopback, want: http.StatusOK},
+		{path: "/debug/pprof/profile?seconds=0", remote: loopback, want: http.StatusBadRequest},
+		{path: "/debug/pprof/profile?seconds=1", remote: remote, want: http.StatusForbidden},
+		{path: "/debug/pprof/trace?seconds=forever", remote: loopback, want: http.StatusBadRequest},
+		{path: "/debug/pprof/trace?seconds=1", remote: remote, want: http.StatusForbidden},
+	} {
+		req := httptest.NewRequest("GET", test.path, nil)
+		req.RemoteAddr = test.remote
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != test.want {
+			t.Errorf("unexpected status for %s from %s: got:%d want:%d", test.path, test.remote, rec.Code, test.want)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/debug/runtime", nil)
+	req.RemoteAddr = loopback
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	var stats RuntimeStats
+	err := json.Unmarshal(rec.Body.Bytes(), &stats)
+	if err != nil {
+		t.Fatalf("failed to unmarshal runtime stats: %v", err)
+	}
+	if stats.Goroutines < 1 || stats.GOMAXPROCS < 1 || stats.HeapAlloc == 0 {
+		t.Errorf("unexpected runtime stats: %+v", stats)
+	}
+
+	s = NewServer()
+	s.EnableProfiling(true)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/runtime", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected status for remote request with remote profiling: got:%d want:%d", rec.Code, http.StatusOK)
+	}
+}
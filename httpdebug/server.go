@@ -0,0 +1,239 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpdebug
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+func init() {
+	ev3dev.RegisterFeature("debug-server")
+}
+
+// classes maps the device class names accepted by the
+// server to their sysfs paths.
+var classes = map[string]string{
+	"tacho-motor":  ev3dev.TachoMotorPath,
+	"dc-motor":     ev3dev.DCMotorPath,
+	"servo-motor":  ev3dev.ServoMotorPath,
+	"lego-sensor":  ev3dev.SensorPath,
+	"lego-port":    ev3dev.LegoPortPath,
+	"leds":         ev3dev.LEDPath,
+	"power_supply": ev3dev.PowerSupplyPath,
+}
+
+const (
+	// defaultRate is the default rate of attribute
+	// streaming in Hz.
+	defaultRate = 10
+
+	// maxRate is the maximum rate of attribute
+	// streaming in Hz.
+	maxRate = 1000
+)
+
+// Server is an HTTP debug server. It serves a dashboard listing the
+// devices present on the brick at "/" and streams attribute values
+// using Server-Sent Events at "/stream".
+//
+// The stream endpoint takes the query parameters class, device, attr and
+// rate. The class is the sysfs device class, one of tacho-motor,
+// dc-motor, servo-motor, lego-sensor, lego-port, leds or power_supply.
+// The device and attr parameters specify the device directory name and
+// attribute, and rate is the sampling rate in Hz, defaulting to 10 with
+// a maximum of 1000. For example
+//
+//	/stream?class=lego-sensor&device=sensor0&attr=value0&rate=50
+//
+// streams the first value of sensor0 fifty times a second. Each event
+// holds the attribute value with surrounding white space removed.
+type Server struct {
+	mux *http.ServeMux
+
+	// start is the time EnableProfiling
+	// was called. It is used to report
+	// the uptime in runtime statistics.
+	start time.Time
+
+	// root is the root of the sysfs
+	// tree. It is set during testing.
+	root string
+}
+
+// NewServer returns a new debug Server.
+func NewServer() *Server {
+	s := &Server{mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", s.index)
+	s.mux.HandleFunc("/stream", s.stream)
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// path returns the sysfs path for the given class, device and attribute.
+func (s *Server) path(class, device, attr string) (string, error) {
+	dir, ok := classes[class]
+	if !ok {
+		return "", fmt.Errorf("unknown class %q", class)
+	}
+	for _, e := range []string{device, attr} {
+		if !isElement(e) {
+			return "", fmt.Errorf("invalid path element %q", e)
+		}
+	}
+	return filepath.Join(s.root, dir, device, attr), nil
+}
+
+// isElement returns whether e is a valid single path element.
+func isElement(e string) bool {
+	return e != "" && e != "." && e != ".." && !strings.ContainsAny(e, `/\`)
+}
+
+func (s *Server) stream(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	path, err := s.path(q.Get("class"), q.Get("device"), q.Get("attr"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rate := defaultRate
+	if v := q.Get("rate"); v != "" {
+		rate, err = strconv.Atoi(v)
+		if err != nil || rate < 1 || maxRate < rate {
+			http.Error(w, fmt.Sprintf("invalid rate %q (must be in 1-%d)", v, maxRate), http.StatusBadRequest)
+			return
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	_, err = ioutil.ReadFile(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+	for {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", oneLine(err.Error()))
+			flusher.Flush()
+			return
+		}
+		_, err = fmt.Fprintf(w, "data: %s\n\n", oneLine(string(bytes.TrimSpace(b))))
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+		select {
+		case <-ticker.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// oneLine returns s with new lines replaced by spaces so that
+// it can be sent as a single event data line.
+func oneLine(s string) string {
+	return strings.Replace(s, "\n", " ", -1)
+}
+
+// devices returns the device directory names for each class.
+func (s *Server) devices() map[string][]string {
+	devs := make(map[string][]string)
+	for class, dir := range classes {
+		fis, err := ioutil.ReadDir(filepath.Join(s.root, dir))
+		if err != nil {
+			continue
+		}
+		for _, fi := range fis {
+			devs[class] = append(devs[class], fi.Name())
+		}
+		sort.Strings(devs[class])
+	}
+	return devs
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := indexTemplate.Execute(w, s.devices())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><title>ev3dev debug</title></head>
+<body>
+<h1>ev3dev debug</h1>
+<form id="stream">
+<select name="class">{{range $class, $devs := .}}<option>{{$class}}</option>{{end}}</select>
+<input name="device" placeholder="device">
+<input name="attr" placeholder="attribute">
+<input name="rate" type="number" min="1" max="1000" value="10">
+<button>Plot</button>
+</form>
+<canvas id="plot" width="800" height="300" style="border:1px solid #ccc"></canvas>
+<pre id="last"></pre>
+<h2>Devices</h2>
+{{range $class, $devs := .}}<h3>{{$class}}</h3><ul>{{range $devs}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<script>
+var source;
+document.getElementById("stream").onsubmit = function(e) {
+	e.preventDefault();
+	if (source) source.close();
+	var data = [];
+	var canvas = document.getElementById("plot");
+	var ctx = canvas.getContext("2d");
+	source = new EventSource("stream?" + new URLSearchParams(new FormData(e.target)));
+	source.onmessage = function(e) {
+		document.getElementById("last").textContent = e.data;
+		var v = parseFloat(e.data);
+		if (isNaN(v)) return;
+		data.push(v);
+		if (data.length > canvas.width) data.shift();
+		var min = Math.min.apply(null, data), max = Math.max.apply(null, data);
+		var scale = max > min ? (canvas.height - 2) / (max - min) : 0;
+		ctx.clearRect(0, 0, canvas.width, canvas.height);
+		ctx.beginPath();
+		data.forEach(function(d, i) {
+			var y = canvas.height - 1 - (d - min) * scale;
+			if (i == 0) ctx.moveTo(i, y); else ctx.lineTo(i, y);
+		});
+		ctx.stroke();
+	};
+};
+</script>
+</body>
+</html>
+`))
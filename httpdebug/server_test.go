@@ -0,0 +1,128 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpdebug
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ev3go/ev3dev"
+)
+
+func testServer(t *testing.T) (*Server, string) {
+	root, err := ioutil.TempDir("", "httpdebug")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	dir := filepath.Join(root, ev3dev.SensorPath, "sensor0")
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		t.Fatalf("failed to create device directory: %v", err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "value0"), []byte("42\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write attribute: %v", err)
+	}
+	s := NewServer()
+	s.root = root
+	return s, root
+}
+
+func TestStream(t *testing.T) {
+	s, root := testServer(t)
+	defer os.RemoveAll(root)
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequest("GET", srv.URL+"/stream?class=lego-sensor&device=sensor0&attr=value0&rate=100", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("failed to get stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: got:%d want:%d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("unexpected content type: got:%q want:%q", ct, "text/event-stream")
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	var events []string
+	for len(events) < 3 && sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		events = append(events, strings.TrimPrefix(line, "data: "))
+		if len(events) == 1 {
+			err = ioutil.WriteFile(filepath.Join(root, ev3dev.SensorPath, "sensor0", "value0"), []byte("-7\n"), 0644)
+			if err != nil {
+				t.Fatalf("failed to write attribute: %v", err)
+			}
+		}
+	}
+	if len(events) != 3 {
+		t.Fatalf("unexpected number of events: got:%d want:3", len(events))
+	}
+	if events[0] != "42" || events[2] != "-7" {
+		t.Errorf("unexpected events: got:%q want:[42 ... -7]", events)
+	}
+}
+
+func TestStreamBadRequest(t *testing.T) {
+	s, root := testServer(t)
+	defer os.RemoveAll(root)
+
+	for _, test := range []struct {
+		query string
+		want  int
+	}{
+		{query: "class=unknown&device=sensor0&attr=value0", want: http.StatusBadRequest},
+		{query: "class=lego-sensor&device=..&attr=value0", want: http.StatusBadRequest},
+		{query: "class=lego-sensor&device=sensor0&attr=../../etc", want: http.StatusBadRequest},
+		{query: "class=lego-sensor&device=sensor0&attr=value0&rate=0", want: http.StatusBadRequest},
+		{query: "class=lego-sensor&device=sensor0&attr=value0&rate=fast", want: http.StatusBadRequest},
+		{query: "class=lego-sensor&device=sensor1&attr=value0", want: http.StatusNotFound},
+	} {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest("GET", "/stream?"+test.query, nil))
+		if rec.Code != test.want {
+			t.Errorf("unexpected status for %q: got:%d want:%d", test.query, rec.Code, test.want)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	s, root := testServer(t)
+	defer os.RemoveAll(root)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status: got:%d want:%d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "<li>sensor0</li>") {
+		t.Errorf("expected sensor0 in device listing:\n%s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", "/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unexpected status for missing page: got:%d want:%d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// activity is incremented each time a motor command is issued
+// or Touch is called. It is used by IdleManager to detect that
+// the program is active.
+var activity uint64
+
+// noteActivity records activity for d writing to attr.
+func noteActivity(d Device, attr string) {
+	if attr != command {
+		return
+	}
+	if _, ok := d.(quietDevice); ok {
+		return
+	}
+	atomic.AddUint64(&activity, 1)
+}
+
+// quietDevice is a Device whose commands are
+// not recorded as activity.
+type quietDevice struct {
+	Device
+}
+
+// Touch records program activity, resetting the idle timer of any
+// IdleManager and restoring from power saving. Commands issued to motors
+// are recorded as activity automatically.
+func Touch() {
+	atomic.AddUint64(&activity, 1)
+}
+
+// IdleManager saves power when a program has been idle for a period.
+// When no motor commands have been issued, no motor has been running and
+// Touch has not been called for the Timeout period, the IdleManager floats
+// all attached motors, dims all LEDs and lowers the poll rate of all
+// sensors that support it. On the next activity the LED triggers and
+// brightnesses, sensor poll rates and motor stop actions are restored.
+// Motors are not restarted.
+//
+// Programs that are active without issuing motor commands, for example
+// programs that only read sensors, should call Touch periodically.
+type IdleManager struct {
+	// Timeout is the period without activity
+	// after which power saving starts.
+	Timeout time.Duration
+
+	// LEDBrightness is the fraction of each
+	// LED's brightness retained while idle.
+	// The default of zero turns LEDs off.
+	LEDBrightness float64
+
+	// PollRate is the sensor poll rate used
+	// while idle. If PollRate is zero, sensor
+	// poll rates are not altered.
+	PollRate time.Duration
+
+	// Interval is the interval between
+	// activity checks. If Interval is
+	// zero, checks are made every 100ms.
+	Interval time.Duration
+
+	mu    sync.Mutex
+	idle  bool
+	saved []func() error
+}
+
+// NewIdleManager returns a new IdleManager with the given timeout, a
+// sensor poll rate of one second while idle and an activity check
+// interval of 100ms.
+func NewIdleManager(timeout time.Duration) *IdleManager {
+	return &IdleManager{Timeout: timeout, PollRate: time.Second, Interval: defaultIdleInterval}
+}
+
+// defaultIdleInterval is the default interval
+// between IdleManager activity checks.
+const defaultIdleInterval = 100 * time.Millisecond
+
+// Idle returns whether the IdleManager is currently saving power.
+func (m *IdleManager) Idle() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.idle
+}
+
+// Run checks for activity every Interval until done is closed, starting
+// power saving after Timeout without activity and restoring on the next
+// activity. Run restores any saved state before returning. Errors during
+// power saving and restoration are ignored, but the first restoration
+// error is returned if the IdleManager is idle when done is closed.
+func (m *IdleManager) Run(done <-chan struct{}) error {
+	interval := m.Interval
+	if interval == 0 {
+		interval = defaultIdleInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	seen := atomic.LoadUint64(&activity)
+	last := time.Now()
+	for {
+		select {
+		case <-done:
+			return m.wake()
+		case now := <-ticker.C:
+			a := atomic.LoadUint64(&activity)
+			if a != seen {
+				seen = a
+				last = now
+				m.wake()
+				continue
+			}
+			if !m.Idle() && now.Sub(last) >= m.Timeout {
+				if motorsRunning() {
+					// A motor running without new
+					// commands, for example in
+					// run-forever, is activity.
+					last = now
+					continue
+				}
+				m.sleep()
+			}
+		}
+	}
+}
+
+// sleep starts power saving, recording the functions
+// needed to restore the original state.
+func (m *IdleManager) sleep() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idle = true
+	m.saved = m.saved[:0]
+	m.floatMotors()
+	m.dimLEDs()
+	if m.PollRate > 0 {
+		m.slowSensors()
+	}
+}
+
+// wake restores the state saved by sleep.
+func (m *IdleManager) wake() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.idle {
+		return nil
+	}
+	var first error
+	for _, restore := range m.saved {
+		err := restore()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	m.idle = false
+	m.saved = m.saved[:0]
+	return first
+}
+
+// motorClasses returns new handles for each class of motor.
+var motorClasses = []func() idSetter{
+	func() idSetter { return new(TachoMotor) },
+	func() idSetter { return new(LinearActuator) },
+	func() idSetter { return new(DCMotor) },
+	func() idSetter { return new(ServoMotor) },
+}
+
+// attachedMotors returns handles for all attached motors.
+func attachedMotors() []idSetter {
+	var motors []idSetter
+	for _, newMotor := range motorClasses {
+		class := newMotor()
+		names, err := devicesIn(class.Path())
+		if err != nil {
+			continue
+		}
+		devices, err := sortedDevices(names, class.Type())
+		if err != nil {
+			continue
+		}
+		for _, d := range devices {
+			m := newMotor()
+			if m.setID(d.id) != nil {
+				continue
+			}
+			motors = append(motors, m)
+		}
+	}
+	return motors
+}
+
+// motorsRunning returns whether any attached motor is running.
+func motorsRunning() bool {
+	for _, m := range attachedMotors() {
+		stat, err := stringSliceFrom(attributeOf(m, state))
+		if err != nil {
+			continue
+		}
+		for _, s := range stat {
+			if s == "running" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// floatMotors floats all attached motors, recording their
+// stop actions for restoration where they are changed.
+func (m *IdleManager) floatMotors() {
+	for _, motor := range attachedMotors() {
+		dev := quietDevice{motor}
+		if _, ok := motor.(*ServoMotor); ok {
+			setAttributeOf(dev, command, string(CommandFloat))
+			continue
+		}
+		action, err := stringFrom(attributeOf(dev, stopAction))
+		if err != nil {
+			continue
+		}
+		if action != string(StopActionCoast) {
+			if setAttributeOf(dev, stopAction, string(StopActionCoast)) != nil {
+				continue
+			}
+			m.saved = append(m.saved, func() error {
+				return setAttributeOf(dev, stopAction, action)
+			})
+		}
+		setAttributeOf(dev, command, string(CommandStop))
+	}
+}
+
+// dimLEDs dims all LEDs, recording their triggers and
+// brightness for restoration. Dimming an LED clears its
+// trigger, so the trigger is restored before the brightness.
+func (m *IdleManager) dimLEDs() {
+	leds := ledStates()
+	for _, l := range leds {
+		dim := &LED{Name: l.led.Name}
+		dim.SetBrightness(int(float64(l.brightness) * m.LEDBrightness))
+	}
+	m.saved = append(m.saved, func() error {
+		return restoreLEDs(leds)
+	})
+}
+
+// slowSensors sets the poll rate of all sensors that have a poll rate
+// to the idle poll rate, recording their poll rates for restoration.
+func (m *IdleManager) slowSensors() {
+	names, err := devicesIn((*Sensor)(nil).Path())
+	if err != nil {
+		return
+	}
+	devices, err := sortedDevices(names, sensorPrefix)
+	if err != nil {
+		return
+	}
+	for _, d := range devices {
+		s := &Sensor{id: d.id}
+		rate, err := s.PollRate()
+		if err != nil || rate == 0 {
+			// The sensor does not support
+			// polling or is not polled.
+			continue
+		}
+		err = s.SetPollRate(m.PollRate).Err()
+		if err != nil {
+			continue
+		}
+		m.saved = append(m.saved, func() error {
+			return s.SetPollRate(rate).Err()
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdleManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	motor := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	motor[stopAction] = "hold"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): motor,
+		filepath.Join(LEDPath, "led0:red:brick-status"): {
+			brightness:    "255",
+			maxBrightness: "255",
+			trigger:       "none [heartbeat]",
+		},
+		filepath.Join(SensorPath, "sensor0"): {pollRate: "50"},
+		filepath.Join(SensorPath, "sensor1"): {pollRate: "0"},
+	})
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := NewIdleManager(50 * time.Millisecond)
+	m.Interval = 5 * time.Millisecond
+	done := make(chan struct{})
+	errc := make(chan error)
+	go func() { errc <- m.Run(done) }()
+
+	waitFor := func(idle bool) {
+		for deadline := time.Now().Add(5 * time.Second); m.Idle() != idle; {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for idle state %t", idle)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(true)
+	for name, want := range map[string]string{
+		filepath.Join(TachoMotorPath, "motor0", stopAction):         "coast",
+		filepath.Join(TachoMotorPath, "motor0", command):            "stop",
+		filepath.Join(LEDPath, "led0:red:brick-status", brightness): "0",
+		filepath.Join(SensorPath, "sensor0", pollRate):              "1000",
+		filepath.Join(SensorPath, "sensor1", pollRate):              "0",
+	} {
+		if got := read(name); got != want {
+			t.Errorf("unexpected idle value for %s: got:%q want:%q", name, got, want)
+		}
+	}
+
+	Touch()
+	waitFor(false)
+	for name, want := range map[string]string{
+		filepath.Join(TachoMotorPath, "motor0", stopAction):      "hold",
+		filepath.Join(LEDPath, "led0:red:brick-status", trigger): "heartbeat",
+		filepath.Join(SensorPath, "sensor0", pollRate):           "50",
+	} {
+		if got := read(name); got != want {
+			t.Errorf("unexpected restored value for %s: got:%q want:%q", name, got, want)
+		}
+	}
+
+	close(done)
+	err = <-errc
+	if err != nil {
+		t.Errorf("unexpected error from Run: %v", err)
+	}
+}
+
+func TestIdleManagerRunning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	motor := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	motor[state] = "running"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): motor,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	// Use the default check interval.
+	m := &IdleManager{Timeout: 10 * time.Millisecond}
+	done := make(chan struct{})
+	errc := make(chan error)
+	go func() { errc <- m.Run(done) }()
+
+	time.Sleep(3 * defaultIdleInterval)
+	if m.Idle() {
+		t.Error("unexpected idle state with running motor")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, TachoMotorPath, "motor0", state), nil, 0644)
+	if err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); !m.Idle(); {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for idle state after motor stopped")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(done)
+	err = <-errc
+	if err != nil {
+		t.Errorf("unexpected error from Run: %v", err)
+	}
+}
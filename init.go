@@ -0,0 +1,163 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DeviceSpec specifies a device to be initialized by Initialize.
+type DeviceSpec struct {
+	// Port and Driver specify the device
+	// in the same way as the port and driver
+	// parameters of TachoMotorFor.
+	Port, Driver string
+
+	// Device is the handle to initialize.
+	// It must be a non-nil *TachoMotor,
+	// *DCMotor, *ServoMotor, *LinearActuator,
+	// *Sensor or *LegoPort.
+	Device Device
+}
+
+// Initialize initializes the devices described by specs, running at most
+// n initializations concurrently. If n is less than one, all devices are
+// initialized concurrently. Each device is initialized in the same way as by
+// the corresponding XxxFor function.
+//
+// If any device fails to initialize, Initialize returns an InitErrors
+// holding the error for each failed device. As with the XxxFor functions,
+// a device with a DriverMismatch error is still initialized.
+func Initialize(n int, specs ...DeviceSpec) error {
+	if n < 1 || len(specs) < n {
+		n = len(specs)
+	}
+	errs := make([]error, len(specs))
+
+	// Devices specified only by driver are found in order
+	// before initialization starts so that specs with the
+	// same driver are given distinct devices. The search
+	// does not claim devices, so each search continues from
+	// the device found for the previous spec.
+	found := make([]*foundDevice, len(specs))
+	last := make(map[string]int)
+	for i, s := range specs {
+		d, ok := s.Device.(idSetter)
+		if !ok || reflect.ValueOf(d).IsNil() || s.Port != "" {
+			continue
+		}
+		key := fmt.Sprintf("%T %s", d, s.Driver)
+		after, ok := last[key]
+		if !ok {
+			after = -1
+		}
+		id, err := deviceIDFor("", s.Driver, nilOf(d), after)
+		if id != -1 {
+			last[key] = id
+		}
+		found[i] = &foundDevice{id: id, err: err}
+	}
+
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	for i, s := range specs {
+		d, ok := s.Device.(idSetter)
+		if !ok || reflect.ValueOf(d).IsNil() {
+			errs[i] = fmt.Errorf("ev3dev: device type %T not supported", s.Device)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, s DeviceSpec, d idSetter) {
+			sem <- struct{}{}
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = initialize(s.Port, s.Driver, d, found[i])
+		}(i, s, d)
+	}
+	wg.Wait()
+
+	var failed InitErrors
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, InitError{Spec: specs[i], Err: err})
+		}
+	}
+	if failed == nil {
+		return nil
+	}
+	return failed
+}
+
+// foundDevice is the result of a device search.
+type foundDevice struct {
+	id  int
+	err error
+}
+
+// nilOf returns a nil handle of the same type as d. Searching with a nil
+// handle does not claim the port during the search, as is done by the
+// XxxFor functions.
+func nilOf(d idSetter) Device {
+	return reflect.Zero(reflect.TypeOf(d)).Interface().(Device)
+}
+
+// initialize initializes d with the device matching port and driver. If
+// found is not nil, it holds the result of an earlier search.
+func initialize(port, driver string, d idSetter, found *foundDevice) error {
+	var (
+		id  int
+		err error
+	)
+	if found != nil {
+		id, err = found.id, found.err
+	} else {
+		id, err = deviceIDFor(port, driver, nilOf(d), -1)
+	}
+	if id == -1 {
+		return err
+	}
+	_err := d.setID(id)
+	if _err != nil {
+		return _err
+	}
+	if u, ok := d.(unreader); ok {
+		setStrictFinalizer(u)
+	}
+	return err
+}
+
+// InitError is an error returned by Initialize for a single device.
+type InitError struct {
+	// Spec is the specification of
+	// the device that failed.
+	Spec DeviceSpec
+
+	// Err is the initialization error.
+	Err error
+}
+
+func (e InitError) Error() string {
+	return fmt.Sprintf("%s on %s: %v", e.Spec.Driver, e.Spec.Port, e.Err)
+}
+
+func (e InitError) Cause() error  { return e.Err }
+func (e InitError) Unwrap() error { return e.Err }
+
+// InitErrors is the error returned by Initialize when one or more
+// devices fail to initialize.
+type InitErrors []InitError
+
+func (e InitErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return fmt.Sprintf("ev3dev: failed to initialize %d devices: %s", len(e), strings.Join(s, "; "))
+}
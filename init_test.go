@@ -0,0 +1,175 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitializeErrors(t *testing.T) {
+	err := Initialize(2)
+	if err != nil {
+		t.Errorf("unexpected error for empty initialization: %v", err)
+	}
+
+	specs := []DeviceSpec{
+		{Port: "outA", Driver: "lego-ev3-l-motor", Device: mockDevice{}},
+		{Port: "outB", Driver: "lego-ev3-l-motor", Device: (*TachoMotor)(nil)},
+		{Port: "in1", Driver: "lego-ev3-touch", Device: &Sensor{}},
+	}
+	err = Initialize(2, specs...)
+	errs, ok := err.(InitErrors)
+	if !ok {
+		t.Fatalf("unexpected error type: got:%T want:%T", err, errs)
+	}
+	if len(errs) != len(specs) {
+		t.Fatalf("unexpected number of errors: got:%d want:%d", len(errs), len(specs))
+	}
+	for i, e := range errs {
+		if e.Spec != specs[i] {
+			t.Errorf("unexpected spec for error %d: got:%v want:%v", i, e.Spec, specs[i])
+		}
+	}
+	for i, want := range []string{"not supported", "not supported", "could not get devices"} {
+		if !strings.Contains(errs[i].Error(), want) {
+			t.Errorf("unexpected error %d: got:%q want substring:%q", i, errs[i], want)
+		}
+	}
+}
+
+// tachoMotorAttrs returns the attributes needed to
+// initialize a tacho-motor at the given address.
+func tachoMotorAttrs(addr, driver string) map[string]string {
+	return map[string]string{
+		address:     addr,
+		driverName:  driver,
+		countPerRot: "360",
+		maxSpeed:    "1050",
+		commands:    "run-forever run-to-abs-pos run-to-rel-pos run-timed run-direct stop reset",
+		stopActions: "coast brake hold",
+		command:     "",
+		state:       "",
+		stopAction:  "coast",
+	}
+}
+
+// makeTree writes the attributes in tree, keyed by device
+// path relative to dir, into dir.
+func makeTree(t *testing.T, dir string, tree map[string]map[string]string) {
+	t.Helper()
+	for path, attrs := range tree {
+		for attr, data := range attrs {
+			p := filepath.Join(dir, path, attr)
+			err := os.MkdirAll(filepath.Dir(p), 0755)
+			if err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+			err = ioutil.WriteFile(p, []byte(data+"\n"), 0644)
+			if err != nil {
+				t.Fatalf("failed to write attribute: %v", err)
+			}
+		}
+	}
+}
+
+func TestInitializeDistinct(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+		filepath.Join(TachoMotorPath, "motor1"): tachoMotorAttrs("ev3-ports:outB", "lego-ev3-m-motor"),
+		filepath.Join(TachoMotorPath, "motor2"): tachoMotorAttrs("ev3-ports:outC", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	var left, right, medium, extra TachoMotor
+	err = Initialize(0,
+		DeviceSpec{Driver: "lego-ev3-l-motor", Device: &left},
+		DeviceSpec{Port: "ev3-ports:outB", Driver: "lego-ev3-m-motor", Device: &medium},
+		DeviceSpec{Driver: "lego-ev3-l-motor", Device: &right},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		m    *TachoMotor
+		want string
+	}{
+		{m: &left, want: "motor0"},
+		{m: &medium, want: "motor1"},
+		{m: &right, want: "motor2"},
+	} {
+		if got := test.m.String(); got != test.want {
+			t.Errorf("unexpected device: got:%s want:%s", got, test.want)
+		}
+	}
+
+	err = Initialize(0,
+		DeviceSpec{Driver: "lego-ev3-l-motor", Device: &left},
+		DeviceSpec{Driver: "lego-ev3-l-motor", Device: &right},
+		DeviceSpec{Driver: "lego-ev3-l-motor", Device: &extra},
+	)
+	errs, ok := err.(InitErrors)
+	if !ok || len(errs) != 1 || errs[0].Spec.Device != Device(&extra) {
+		t.Errorf("expected single error for third large motor: got:%v", err)
+	}
+}
+
+func TestTachoMotorNext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	tree := map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+		filepath.Join(TachoMotorPath, "motor1"): tachoMotorAttrs("ev3-ports:outB", "lego-ev3-l-motor"),
+	}
+	for _, attrs := range tree {
+		attrs[speedSetpoint] = "0"
+	}
+	makeTree(t, dir, tree)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m, err := TachoMotorFor("ev3-ports:outA", "lego-ev3-l-motor")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n, err := m.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.String() != "motor1" {
+		t.Errorf("unexpected next motor: got:%s want:motor1", n)
+	}
+	if got := n.Driver(); got != "lego-ev3-l-motor" {
+		t.Errorf("unexpected driver: got:%q want:%q", got, "lego-ev3-l-motor")
+	}
+	if got := n.MaxSpeed(); got != 1050 {
+		t.Errorf("unexpected max speed: got:%d want:%d", got, 1050)
+	}
+	err = n.SetSpeedSetpoint(500).Err()
+	if err != nil {
+		t.Errorf("unexpected error setting speed setpoint: %v", err)
+	}
+	got, err := n.SpeedSetpoint()
+	if err != nil {
+		t.Errorf("unexpected error getting speed setpoint: %v", err)
+	}
+	if got != 500 {
+		t.Errorf("unexpected speed setpoint: got:%d want:%d", got, 500)
+	}
+}
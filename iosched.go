@@ -0,0 +1,210 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+)
+
+// ioClass is the class of an attribute IO operation.
+type ioClass int
+
+const (
+	readIO ioClass = iota
+	writeIO
+)
+
+var (
+	// sched is the attribute IO scheduler. If sched
+	// is nil, attribute IO is performed directly by
+	// the calling goroutine.
+	sched     *ioScheduler
+	schedLock sync.RWMutex
+)
+
+// SetIOWeights sets how attribute reads and writes are scheduled. If reads
+// and writes are both zero, the default, attribute IO is performed directly
+// by the calling goroutine.
+//
+// Otherwise all attribute IO is serialized through a single scheduler
+// goroutine that takes turns between pending reads and pending writes,
+// performing up to reads reads and then up to writes writes in each turn.
+// This prevents a goroutine issuing a high rate of writes, for example
+// duty cycle updates to a motor in run-direct mode, from starving
+// goroutines reading sensors, and vice versa, while allowing the share
+// of IO given to each to be chosen.
+//
+// Operations already queued when SetIOWeights is called are completed by
+// the previous scheduler.
+// SetIOWeights panics if either weight is negative or if only one is zero.
+func SetIOWeights(reads, writes int) {
+	if reads < 0 || writes < 0 || (reads == 0) != (writes == 0) {
+		panic(fmt.Sprintf("ev3dev: invalid IO weights: reads=%d writes=%d", reads, writes))
+	}
+	schedLock.Lock()
+	defer schedLock.Unlock()
+	if sched != nil {
+		sched.stop()
+		sched = nil
+	}
+	if reads == 0 {
+		return
+	}
+	sched = newIOScheduler(reads, writes)
+	go sched.run()
+}
+
+// readAttr reads the attribute file at path, using the
+// attribute IO scheduler if one is set.
+func readAttr(ctx context.Context, path string) (b []byte, err error) {
+	cerr := do(ctx, readIO, func() { b, err = readFile(path) })
+	if cerr != nil {
+		return nil, cerr
+	}
+	return b, err
+}
+
+// writeAttr writes data to the attribute file at path,
+// using the attribute IO scheduler if one is set.
+func writeAttr(ctx context.Context, path, data string) (err error) {
+	cerr := do(ctx, writeIO, func() { err = ioutil.WriteFile(path, []byte(data), 0) })
+	if cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+// do calls fn, using the attribute IO scheduler if one is set.
+// If ctx is done before the scheduler starts fn, fn is not
+// called and ctx.Err() is returned.
+func do(ctx context.Context, class ioClass, fn func()) error {
+	schedLock.RLock()
+	s := sched
+	schedLock.RUnlock()
+	if s == nil {
+		fn()
+		return nil
+	}
+	return s.do(ctx, class, fn)
+}
+
+// ioOp is a queued attribute IO operation.
+type ioOp struct {
+	fn   func()
+	done chan struct{}
+
+	// started and abandoned are guarded
+	// by the scheduler's mutex.
+	started   bool
+	abandoned bool
+}
+
+// ioScheduler is a weighted round robin attribute IO scheduler.
+type ioScheduler struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	weights [2]int
+	queue   [2][]*ioOp
+
+	// class is the class currently being
+	// served and served is the number of
+	// operations served in its turn.
+	class  ioClass
+	served int
+
+	stopped bool
+}
+
+func newIOScheduler(reads, writes int) *ioScheduler {
+	s := &ioScheduler{weights: [2]int{readIO: reads, writeIO: writes}}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// do queues fn in the given class and waits for it to be called.
+// If ctx is done before fn is started, the operation is abandoned
+// and ctx.Err() is returned. Once fn has started, do waits for it
+// to return since fn may write to the caller's variables.
+func (s *ioScheduler) do(ctx context.Context, class ioClass, fn func()) error {
+	op := &ioOp{fn: fn, done: make(chan struct{})}
+	s.mu.Lock()
+	s.queue[class] = append(s.queue[class], op)
+	s.mu.Unlock()
+	s.cond.Signal()
+	select {
+	case <-op.done:
+		return nil
+	case <-ctx.Done():
+	}
+	s.mu.Lock()
+	if !op.started {
+		op.abandoned = true
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+	s.mu.Unlock()
+	<-op.done
+	return nil
+}
+
+// run performs queued operations until the scheduler is
+// stopped and its queues are empty.
+func (s *ioScheduler) run() {
+	for {
+		op := s.next()
+		if op == nil {
+			return
+		}
+		op.fn()
+		close(op.done)
+	}
+}
+
+// next returns the next operation to perform, waiting until one is
+// queued. It returns nil if the scheduler is stopped and no operations
+// are queued.
+func (s *ioScheduler) next() *ioOp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		// Three steps are needed to return to the
+		// current class with a fresh turn when the
+		// other class has nothing queued.
+		for i := 0; i < 3; i++ {
+			q := s.queue[s.class]
+			for len(q) != 0 && q[0].abandoned {
+				q[0] = nil
+				q = q[1:]
+			}
+			s.queue[s.class] = q
+			if len(q) != 0 && s.served < s.weights[s.class] {
+				op := q[0]
+				q[0] = nil
+				s.queue[s.class] = q[1:]
+				s.served++
+				op.started = true
+				return op
+			}
+			s.class = 1 - s.class
+			s.served = 0
+		}
+		if s.stopped {
+			return nil
+		}
+		s.cond.Wait()
+	}
+}
+
+// stop stops the scheduler once its queues are empty.
+func (s *ioScheduler) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
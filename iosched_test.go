@@ -0,0 +1,239 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var ioSchedulerTests = []struct {
+	reads, writes int
+	queued        string
+	want          string
+}{
+	{reads: 1, writes: 1, queued: "rrrrwwww", want: "rwrwrwrw"},
+	{reads: 3, writes: 1, queued: "rrrrrwwww", want: "rrrwrrwww"},
+	{reads: 1, writes: 4, queued: "rrrwwwwwwwwww", want: "rwwwwrwwwwrww"},
+	{reads: 2, writes: 2, queued: "rrrrrrr", want: "rrrrrrr"},
+	{reads: 2, writes: 2, queued: "wwwww", want: "wwwww"},
+}
+
+func TestIOSchedulerOrder(t *testing.T) {
+	for _, test := range ioSchedulerTests {
+		s := newIOScheduler(test.reads, test.writes)
+		for _, c := range test.queued {
+			class := readIO
+			if c == 'w' {
+				class = writeIO
+			}
+			s.queue[class] = append(s.queue[class], &ioOp{fn: func() {}})
+		}
+		s.stop()
+
+		var got []byte
+		for {
+			op := s.next()
+			if op == nil {
+				break
+			}
+			// The class being served is the
+			// class of the returned op.
+			got = append(got, "rw"[s.class])
+		}
+		if string(got) != test.want {
+			t.Errorf("unexpected order for weights reads=%d writes=%d: got:%s want:%s",
+				test.reads, test.writes, got, test.want)
+		}
+	}
+}
+
+func TestSetIOWeightsPanics(t *testing.T) {
+	for _, w := range [][2]int{{-1, 1}, {1, -1}, {0, 1}, {1, 0}} {
+		panicked := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			SetIOWeights(w[0], w[1])
+			return false
+		}()
+		if !panicked {
+			t.Errorf("expected panic for weights %v", w)
+		}
+	}
+	schedLock.RLock()
+	s := sched
+	schedLock.RUnlock()
+	if s != nil {
+		t.Error("unexpected scheduler after invalid weights")
+	}
+}
+
+func TestIOSchedulerFairness(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{
+		filepath.Join(TachoMotorPath, "motor0", dutyCycleSetpoint),
+		filepath.Join(SensorPath, "sensor0", "value0"),
+	} {
+		path := filepath.Join(dir, name)
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		err = ioutil.WriteFile(path, []byte("0\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	SetIOWeights(1, 1)
+	defer SetIOWeights(0, 0)
+
+	motor := namedDevice{path: TachoMotorPath, name: "motor0"}
+	sensor := namedDevice{path: SensorPath, name: "sensor0"}
+
+	// Each side hammers the scheduler while the other
+	// performs a fixed number of operations. Neither
+	// must be starved by the other.
+	const n = 200
+	for _, test := range []struct {
+		name         string
+		hammer, work func() error
+	}{
+		{
+			name:   "writes",
+			hammer: func() error { return setAttributeOf(motor, dutyCycleSetpoint, "50") },
+			work: func() error {
+				_, err := intFrom(attributeOf(sensor, "value0"))
+				return err
+			},
+		},
+		{
+			name: "reads",
+			hammer: func() error {
+				_, err := intFrom(attributeOf(sensor, "value0"))
+				return err
+			},
+			work: func() error { return setAttributeOf(motor, dutyCycleSetpoint, "50") },
+		},
+	} {
+		var (
+			stop    int32
+			hammers int64
+			errc    = make(chan error, 1)
+		)
+		go func() {
+			for atomic.LoadInt32(&stop) == 0 {
+				err := test.hammer()
+				if err != nil {
+					errc <- err
+					return
+				}
+				atomic.AddInt64(&hammers, 1)
+			}
+			errc <- nil
+		}()
+
+		deadline := time.Now().Add(10 * time.Second)
+		// Wait for the load to start so that the
+		// work is measured while it is running.
+		for atomic.LoadInt64(&hammers) == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		started := atomic.LoadInt64(&hammers)
+		for i := 0; i < n; i++ {
+			err := test.work()
+			if err != nil {
+				t.Fatalf("unexpected error under %s load: %v", test.name, err)
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("starved under %s load: completed %d of %d operations", test.name, i, n)
+			}
+		}
+		atomic.StoreInt32(&stop, 1)
+		err := <-errc
+		if err != nil {
+			t.Errorf("unexpected error from %s load: %v", test.name, err)
+		}
+		if atomic.LoadInt64(&hammers) == started {
+			t.Errorf("no %s completed under load", test.name)
+		}
+	}
+}
+
+func TestIOSchedulerDirect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "attr")
+	err = ioutil.WriteFile(path, nil, 0644)
+	if err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	for _, weights := range [][2]int{{0, 0}, {2, 1}} {
+		SetIOWeights(weights[0], weights[1])
+		err = writeAttr(context.Background(), path, "value\n")
+		if err != nil {
+			t.Errorf("unexpected error writing with weights %v: %v", weights, err)
+		}
+		b, err := readAttr(context.Background(), path)
+		if err != nil {
+			t.Errorf("unexpected error reading with weights %v: %v", weights, err)
+		}
+		if got := strings.TrimSpace(string(b)); got != "value" {
+			t.Errorf("unexpected value with weights %v: got:%q want:%q", weights, got, "value")
+		}
+	}
+	SetIOWeights(0, 0)
+}
+
+func TestIOSchedulerCancel(t *testing.T) {
+	s := newIOScheduler(1, 1)
+	go s.run()
+	defer s.stop()
+
+	// Block the scheduler so that the
+	// next operation remains queued.
+	block := make(chan struct{})
+	running := make(chan struct{})
+	go s.do(context.Background(), readIO, func() {
+		close(running)
+		<-block
+	})
+	<-running
+
+	var called int32
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := s.do(ctx, readIO, func() { atomic.StoreInt32(&called, 1) })
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error for cancelled operation: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+	close(block)
+
+	// The abandoned operation must be skipped
+	// and later operations must still be served.
+	err = s.do(context.Background(), readIO, func() {})
+	if err != nil {
+		t.Errorf("unexpected error after cancelled operation: %v", err)
+	}
+	if atomic.LoadInt32(&called) != 0 {
+		t.Error("abandoned operation was called")
+	}
+}
@@ -5,10 +5,13 @@
 package ev3dev
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +24,10 @@ import (
 type LED struct {
 	Name fmt.Stringer
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
 	err error
 }
 
@@ -156,3 +163,78 @@ func (l *LED) SetDelayOn(d time.Duration) *LED {
 func (l *LED) Uevent() (map[string]string, error) {
 	return ueventFrom(attributeOf(ledDevice{l}, uevent))
 }
+
+// LEDName is a parsed LED class device name. LED names follow the Linux
+// convention "devicename:color:function", for example
+// "led0:red:brick-status", or the older ev3dev convention
+// "devicename:position:color:function", for example "ev3:left:red:ev3dev".
+//
+// LEDName satisfies fmt.Stringer and so may be used as the Name of an LED.
+type LEDName struct {
+	// Device is the device name
+	// part of the LED name.
+	Device string
+
+	// Position is the position of the LED.
+	// For names without an explicit position
+	// the device name is used.
+	Position string
+
+	// Color and Function are the color and
+	// function parts of the LED name. Either
+	// may be empty.
+	Color    string
+	Function string
+
+	name string
+}
+
+// ParseLEDName parses an LED class device name. It returns false if name
+// does not have the form of a structured LED name.
+func ParseLEDName(name string) (n LEDName, ok bool) {
+	parts := strings.Split(name, ":")
+	switch len(parts) {
+	case 3:
+		return LEDName{Device: parts[0], Position: parts[0], Color: parts[1], Function: parts[2], name: name}, true
+	case 4:
+		return LEDName{Device: parts[0], Position: parts[1], Color: parts[2], Function: parts[3], name: name}, true
+	default:
+		return LEDName{}, false
+	}
+}
+
+// String satisfies the fmt.Stringer interface.
+func (n LEDName) String() string { return n.name }
+
+// LEDKey is the key of an LED in the map returned by LEDs.
+type LEDKey struct {
+	Position string
+	Color    string
+}
+
+// LEDs returns the LEDs present in the LED sysfs directory, keyed by
+// position and color. More than one LED may share a position and color,
+// for example when they have different functions; these are ordered by
+// name. LEDs with names that cannot be parsed by ParseLEDName are omitted.
+//
+// LEDs allows programs to work with the LEDs of platforms other than the
+// EV3 without depending on hard-coded LED names.
+func LEDs() (map[LEDKey][]*LED, error) {
+	names, err := devicesIn((*LED)(nil).Path())
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	leds := make(map[LEDKey][]*LED)
+	for _, name := range names {
+		n, ok := ParseLEDName(name)
+		if !ok {
+			continue
+		}
+		k := LEDKey{Position: n.Position, Color: n.Color}
+		l := &LED{Name: n}
+		setStrictFinalizer(l)
+		leds[k] = append(leds[k], l)
+	}
+	return leds, nil
+}
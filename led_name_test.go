@@ -0,0 +1,92 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLEDName(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		want   LEDName
+		wantOK bool
+	}{
+		{
+			name:   "led0:red:brick-status",
+			want:   LEDName{Device: "led0", Position: "led0", Color: "red", Function: "brick-status", name: "led0:red:brick-status"},
+			wantOK: true,
+		},
+		{
+			name:   "ev3:left:green:ev3dev",
+			want:   LEDName{Device: "ev3", Position: "left", Color: "green", Function: "ev3dev", name: "ev3:left:green:ev3dev"},
+			wantOK: true,
+		},
+		{
+			name:   "mmc0::",
+			want:   LEDName{Device: "mmc0", Position: "mmc0", name: "mmc0::"},
+			wantOK: true,
+		},
+		{name: "led0", wantOK: false},
+		{name: "a:b:c:d:e", wantOK: false},
+	} {
+		got, ok := ParseLEDName(test.name)
+		if ok != test.wantOK {
+			t.Errorf("unexpected ok for %q: got:%t want:%t", test.name, ok, test.wantOK)
+		}
+		if got != test.want {
+			t.Errorf("unexpected result for %q:\ngot: %#v\nwant:%#v", test.name, got, test.want)
+		}
+		if ok && got.String() != test.name {
+			t.Errorf("unexpected string for %q: got:%q", test.name, got)
+		}
+	}
+}
+
+func TestLEDs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for _, n := range []string{
+		"led1:green:brick-status",
+		"led0:red:brick-status",
+		"led0:green:brick-status",
+		"led0:green:heartbeat",
+		"unstructured",
+	} {
+		err = os.MkdirAll(filepath.Join(dir, LEDPath, n), 0755)
+		if err != nil {
+			t.Fatalf("failed to create LED directory: %v", err)
+		}
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	leds, err := LEDs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[LEDKey][]string)
+	for k, l := range leds {
+		for _, led := range l {
+			got[k] = append(got[k], led.String())
+		}
+	}
+	want := map[LEDKey][]string{
+		{Position: "led0", Color: "green"}: {"led0:green:brick-status", "led0:green:heartbeat"},
+		{Position: "led0", Color: "red"}:   {"led0:red:brick-status"},
+		{Position: "led1", Color: "green"}: {"led1:green:brick-status"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected LEDs:\ngot: %v\nwant:%v", got, want)
+	}
+}
@@ -5,6 +5,7 @@
 package ev3dev
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -32,6 +33,14 @@ type LegoPort struct {
 	// Device cached value:
 	driver string
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
 	err error
 }
 
@@ -53,6 +62,7 @@ func (p *LegoPort) Err() error {
 // idInt and setID satisfy the idSetter interface.
 func (p *LegoPort) setID(id int) error {
 	t := LegoPort{id: id}
+	forgetName(&t)
 	var err error
 	t.modes, err = stringSliceFrom(attributeOf(&t, modes))
 	if err != nil {
@@ -94,6 +104,7 @@ func LegoPortFor(port, driver string) (*LegoPort, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&p)
 	return &p, err
 }
 
@@ -108,7 +119,13 @@ func (p *LegoPort) Next() (*LegoPort, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &LegoPort{id: id}, err
+	var n LegoPort
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
 // Driver returns the driver used by the LegoPort.
@@ -5,6 +5,7 @@
 package ev3dev
 
 import (
+	"context"
 	"math"
 	"path/filepath"
 	"strconv"
@@ -22,6 +23,22 @@ type LinearActuator struct {
 	countPerMeter, fullTravelCount, maxSpeed int
 	commands, stopActions                    []string
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
+	// notifier serves the thresholds
+	// registered by NotifyAtPosition.
+	notifier *positionNotifier
+
+	// limits holds the soft travel
+	// limits set by SetTravelLimits.
+	limits *travelLimits
+
 	err error
 }
 
@@ -41,14 +58,13 @@ func (m *LinearActuator) String() string {
 
 // Err returns the error state of the LinearActuator and clears it.
 func (m *LinearActuator) Err() error {
-	err := m.err
-	m.err = nil
-	return err
+	return takeErr(&m.err)
 }
 
 // idInt and setID satisfy the idSetter interface.
 func (m *LinearActuator) setID(id int) error {
 	t := LinearActuator{id: id}
+	forgetName(&t)
 	var err error
 	t.countPerMeter, err = intFrom(attributeOf(&t, countPerMeter))
 	if err != nil {
@@ -102,6 +118,7 @@ func LinearActuatorFor(port, driver string) (*LinearActuator, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&m)
 	return &m, err
 }
 
@@ -116,7 +133,13 @@ func (m *LinearActuator) Next() (*LinearActuator, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &LinearActuator{id: id}, err
+	var n LinearActuator
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
 // Driver returns the driver used by the LinearActuator.
@@ -136,23 +159,30 @@ func (m *LinearActuator) Commands() []string {
 	return avail
 }
 
-// Command issues a command to the LinearActuator.
-func (m *LinearActuator) Command(comm string) *LinearActuator {
-	if m.err != nil {
+// Command issues a command to the LinearActuator. The command must be one of the
+// commands listed by Commands, and must not move the actuator outside the
+// limits set by SetTravelLimits.
+func (m *LinearActuator) Command(comm MotorCommand) *LinearActuator {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, c := range m.commands {
-		if c == comm {
+		if c == string(comm) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, command, "", comm, m.Commands())
+		setErr(&m.err, newInvalidValueError(m, command, "", string(comm), m.Commands()))
+		return m
+	}
+	err := m.checkTravel(comm)
+	if err != nil {
+		setErr(&m.err, err)
 		return m
 	}
-	m.err = setAttributeOf(m, command, comm)
+	setErr(&m.err, setAttributeOf(m, command, string(comm)))
 	return m
 }
 
@@ -178,14 +208,14 @@ func (m *LinearActuator) DutyCycleSetpoint() (int, error) {
 
 // SetDutyCycleSetpoint sets the duty cycle setpoint value for the LinearActuator
 func (m *LinearActuator) SetDutyCycleSetpoint(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < -100 || 100 < sp {
-		m.err = newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100)
+		setErr(&m.err, newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100))
 		return m
 	}
-	m.err = setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -197,14 +227,14 @@ func (m *LinearActuator) Polarity() (Polarity, error) {
 
 // SetPolarity sets the polarity of the LinearActuator
 func (m *LinearActuator) SetPolarity(p Polarity) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if p != Normal && p != Inversed {
-		m.err = newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)})
+		setErr(&m.err, newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)}))
 		return m
 	}
-	m.err = setAttributeOf(m, polarity, string(p))
+	setErr(&m.err, setAttributeOf(m, polarity, string(p)))
 	return m
 }
 
@@ -215,14 +245,14 @@ func (m *LinearActuator) Position() (int, error) {
 
 // SetPosition sets the position value for the LinearActuator.
 func (m *LinearActuator) SetPosition(pos int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if pos != int(int32(pos)) {
-		m.err = newValueOutOfRangeError(m, position, pos, math.MinInt32, math.MaxInt32)
+		setErr(&m.err, newValueOutOfRangeError(m, position, pos, math.MinInt32, math.MaxInt32))
 		return m
 	}
-	m.err = setAttributeOf(m, position, strconv.Itoa(pos))
+	setErr(&m.err, setAttributeOf(m, position, strconv.Itoa(pos)))
 	return m
 }
 
@@ -233,10 +263,10 @@ func (m *LinearActuator) HoldPIDKd() (int, error) {
 
 // SetHoldPIDKd sets the derivative constant for the position PID for the LinearActuator.
 func (m *LinearActuator) SetHoldPIDKd(k int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDkd, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDkd, strconv.Itoa(k)))
 	return m
 }
 
@@ -247,10 +277,10 @@ func (m *LinearActuator) HoldPIDKi() (int, error) {
 
 // SetHoldPIDKi sets the integral constant for the position PID for the LinearActuator.
 func (m *LinearActuator) SetHoldPIDKi(k int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDki, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDki, strconv.Itoa(k)))
 	return m
 }
 
@@ -261,13 +291,23 @@ func (m *LinearActuator) HoldPIDKp() (int, error) {
 
 // SetHoldPIDKp sets the proportional constant for the position PID for the LinearActuator.
 func (m *LinearActuator) SetHoldPIDKp(k int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDkp, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDkp, strconv.Itoa(k)))
 	return m
 }
 
+// HoldPID returns the constants of the position PID for the LinearActuator.
+func (m *LinearActuator) HoldPID() (PID, error) {
+	return pidOf(m, holdPIDkp, holdPIDki, holdPIDkd)
+}
+
+// SetHoldPID sets the constants of the position PID for the LinearActuator.
+func (m *LinearActuator) SetHoldPID(pid PID) *LinearActuator {
+	return m.SetHoldPIDKp(pid.Kp).SetHoldPIDKi(pid.Ki).SetHoldPIDKd(pid.Kd)
+}
+
 // MaxSpeed returns the maximum value that is accepted by SpeedSetpoint.
 func (m *LinearActuator) MaxSpeed() int {
 	return m.maxSpeed
@@ -280,14 +320,14 @@ func (m *LinearActuator) PositionSetpoint() (int, error) {
 
 // SetPositionSetpoint sets the position setpoint value for the LinearActuator.
 func (m *LinearActuator) SetPositionSetpoint(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp != int(int32(sp)) {
-		m.err = newValueOutOfRangeError(m, positionSetpoint, sp, math.MinInt32, math.MaxInt32)
+		setErr(&m.err, newValueOutOfRangeError(m, positionSetpoint, sp, math.MinInt32, math.MaxInt32))
 		return m
 	}
-	m.err = setAttributeOf(m, positionSetpoint, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, positionSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -302,11 +342,16 @@ func (m *LinearActuator) SpeedSetpoint() (int, error) {
 }
 
 // SetSpeedSetpoint sets the speed setpoint value for the LinearActuator.
+// The magnitude of sp must not exceed MaxSpeed.
 func (m *LinearActuator) SetSpeedSetpoint(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedSetpoint, strconv.Itoa(sp))
+	if sp < -m.maxSpeed || m.maxSpeed < sp {
+		setErr(&m.err, newValueOutOfRangeError(m, speedSetpoint, sp, -m.maxSpeed, m.maxSpeed))
+		return m
+	}
+	setErr(&m.err, setAttributeOf(m, speedSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -317,14 +362,14 @@ func (m *LinearActuator) RampUpSetpoint() (time.Duration, error) {
 
 // SetRampUpSetpoint sets the ramp up setpoint value for the LinearActuator.
 func (m *LinearActuator) SetRampUpSetpoint(sp time.Duration) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, rampUpSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, rampUpSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -335,14 +380,14 @@ func (m *LinearActuator) RampDownSetpoint() (time.Duration, error) {
 
 // SetRampDownSetpoint sets the ramp down setpoint value for the LinearActuator.
 func (m *LinearActuator) SetRampDownSetpoint(sp time.Duration) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, rampDownSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, rampDownSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -353,10 +398,10 @@ func (m *LinearActuator) SpeedPIDKd() (int, error) {
 
 // SetSpeedPIDKd sets the derivative constant for the speed regulation PID for the LinearActuator.
 func (m *LinearActuator) SetSpeedPIDKd(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDkd, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, speedPIDkd, strconv.Itoa(sp)))
 	return m
 }
 
@@ -367,10 +412,10 @@ func (m *LinearActuator) SpeedPIDKi() (int, error) {
 
 // SetSpeedPIDKi sets the integral constant for the speed regulation PID for the LinearActuator.
 func (m *LinearActuator) SetSpeedPIDKi(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDki, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, speedPIDki, strconv.Itoa(sp)))
 	return m
 }
 
@@ -381,45 +426,64 @@ func (m *LinearActuator) SpeedPIDKp() (int, error) {
 
 // SetSpeedPIDKp sets the proportional constant for the speed regulation PID for the LinearActuator.
 func (m *LinearActuator) SetSpeedPIDKp(sp int) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDkp, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, speedPIDkp, strconv.Itoa(sp)))
 	return m
 }
 
+// SpeedPID returns the constants of the speed regulation PID for the LinearActuator.
+func (m *LinearActuator) SpeedPID() (PID, error) {
+	return pidOf(m, speedPIDkp, speedPIDki, speedPIDkd)
+}
+
+// SetSpeedPID sets the constants of the speed regulation PID for the LinearActuator.
+func (m *LinearActuator) SetSpeedPID(pid PID) *LinearActuator {
+	return m.SetSpeedPIDKp(pid.Kp).SetSpeedPIDKi(pid.Ki).SetSpeedPIDKd(pid.Kd)
+}
+
 // State returns the current state of the LinearActuator.
 func (m *LinearActuator) State() (MotorState, error) {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return 0, m.Err()
 	}
 	return stateFrom(attributeOf(m, state))
 }
 
+// WaitContext waits until the LinearActuator reaches the wanted motor state under
+// the motor state mask, or ctx is done. It is equivalent to
+// WaitContext(ctx, m, mask, want, not, any).
+func (m *LinearActuator) WaitContext(ctx context.Context, mask, want, not MotorState, any bool) (stat MotorState, ok bool, err error) {
+	return WaitContext(ctx, m, mask, want, not, any)
+}
+
 // StopAction returns the stop action used when a stop command is issued
 // to the LinearActuator.
-func (m *LinearActuator) StopAction() (string, error) {
-	return stringFrom(attributeOf(m, stopAction))
+func (m *LinearActuator) StopAction() (StopAction, error) {
+	action, err := stringFrom(attributeOf(m, stopAction))
+	return StopAction(action), err
 }
 
 // SetStopAction sets the stop action to be used when a stop command is
-// issued to the LinearActuator.
-func (m *LinearActuator) SetStopAction(action string) *LinearActuator {
-	if m.err != nil {
+// issued to the LinearActuator. The action must be one
+// of the stop actions listed by StopActions.
+func (m *LinearActuator) SetStopAction(action StopAction) *LinearActuator {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, a := range m.stopActions {
-		if a == action {
+		if a == string(action) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, stopAction, "", action, m.StopActions())
+		setErr(&m.err, newInvalidValueError(m, stopAction, "", string(action), m.StopActions()))
 		return m
 	}
-	m.err = setAttributeOf(m, stopAction, action)
+	setErr(&m.err, setAttributeOf(m, stopAction, string(action)))
 	return m
 }
 
@@ -443,14 +507,14 @@ func (m *LinearActuator) TimeSetpoint() (time.Duration, error) {
 
 // SetTimeSetpoint sets the time setpoint value for the LinearActuator.
 func (m *LinearActuator) SetTimeSetpoint(sp time.Duration) *LinearActuator {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, timeSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, timeSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -0,0 +1,66 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"errors"
+)
+
+// Home drives the LinearActuator towards an end stop until it stalls,
+// stops it, and sets its position to zero. The actuator is driven in the
+// direction given by the sign of direction at the magnitude of speed.
+// Home returns the travel in tacho counts between the starting position
+// and the end stop.
+//
+// The wait for the stall is cancelled if the LinearActuator's default
+// context is done. If the wait fails, the actuator is still stopped, but
+// its position is left unchanged.
+func (m *LinearActuator) Home(direction, speed int) (travel int, err error) {
+	if direction == 0 {
+		return 0, errors.New("ev3dev: no homing direction")
+	}
+	if speed < 0 {
+		speed = -speed
+	}
+	if direction < 0 {
+		speed = -speed
+	}
+	start, err := m.Position()
+	if err != nil {
+		return 0, err
+	}
+	err = m.SetSpeedSetpoint(speed).Command(CommandRunForever).Err()
+	if err != nil {
+		return 0, err
+	}
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, _, err = WaitContext(ctx, m, Stalled, Stalled, 0, false)
+	// Stop without the default context, which
+	// may be done, and the error state.
+	stopErr := setAttributeOf(errlessDevice{m}, command, string(CommandStop))
+	if err != nil {
+		return 0, err
+	}
+	if stopErr != nil {
+		return 0, stopErr
+	}
+	end, err := m.Position()
+	if err != nil {
+		return 0, err
+	}
+	err = m.SetPosition(0).Err()
+	if err != nil {
+		return 0, err
+	}
+	travel = end - start
+	if travel < 0 {
+		travel = -travel
+	}
+	return travel, nil
+}
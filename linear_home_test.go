@@ -0,0 +1,106 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinearActuatorHome(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "linear0"): {
+			position:      "100",
+			speedSetpoint: "0",
+			command:       "",
+			state:         running,
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		return filepath.Join(dir, TachoMotorPath, "linear0", name)
+	}
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(attr(name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+	newActuator := func() *LinearActuator {
+		return &LinearActuator{
+			id:       0,
+			maxSpeed: 24,
+			commands: []string{"run-forever", "run-to-abs-pos", "run-to-rel-pos", "stop"},
+		}
+	}
+
+	_, err = newActuator().Home(0, 20)
+	if err == nil {
+		t.Error("expected error for zero direction")
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ioutil.WriteFile(attr(position), []byte("-500\n"), 0644)
+		// Write a state of the same length as the
+		// current one so that a concurrent read
+		// cannot see a mixture of the two.
+		ioutil.WriteFile(attr(state), []byte(stalled+"\n"), 0644)
+	}()
+	travel, err := newActuator().Home(-1, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if travel != 600 {
+		t.Errorf("unexpected travel: got:%d want:600", travel)
+	}
+	for name, want := range map[string]string{speedSetpoint: "-20", command: "stop", position: "0"} {
+		if got := read(name); got != want {
+			t.Errorf("unexpected %s: got:%q want:%q", name, got, want)
+		}
+	}
+
+	err = ioutil.WriteFile(attr(state), []byte(running+"\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	err = ioutil.WriteFile(attr(position), []byte("100\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write position: %v", err)
+	}
+	err = ioutil.WriteFile(attr(command), nil, 0644)
+	if err != nil {
+		t.Fatalf("failed to clear command: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = newActuator().WithContext(ctx).Home(1, 20)
+	if err == nil {
+		t.Error("expected error for cancelled homing")
+	}
+	if got := read(command); got != "stop" {
+		t.Errorf("actuator not stopped after failed homing: got:%q", got)
+	}
+	if got := read(position); got != "100" {
+		t.Errorf("position changed after failed homing: got:%q", got)
+	}
+}
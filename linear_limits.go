@@ -0,0 +1,162 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// travelLimits is the soft travel window of a LinearActuator.
+type travelLimits struct {
+	min, max int
+}
+
+// SetTravelLimits sets soft travel limits for the LinearActuator, in tacho
+// counts. Once set, the run-to-abs-pos and run-to-rel-pos commands are
+// rejected by Command if the position they would move the actuator to is
+// outside [min, max]. Position setpoints are checked when the command is
+// issued, since their meaning depends on the command. Other run commands
+// are not checked; SuperviseTravel may be used to stop the actuator if it
+// leaves the window while running them.
+func (m *LinearActuator) SetTravelLimits(min, max int) *LinearActuator {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if min > max {
+		setErr(&m.err, fmt.Errorf("ev3dev: invalid travel limits for %s: %d-%d", deviceName(m), min, max))
+		return m
+	}
+	m.limits = &travelLimits{min: min, max: max}
+	return m
+}
+
+// SetTravelLimitsMM sets soft travel limits for the LinearActuator in
+// millimetres. The limits are rounded to the nearest tacho count as
+// described for SetPositionSetpointMM. See SetTravelLimits for details.
+func (m *LinearActuator) SetTravelLimitsMM(min, max float64) *LinearActuator {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	lo, err := m.countsForMM(min)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	hi, err := m.countsForMM(max)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	return m.SetTravelLimits(lo, hi)
+}
+
+// ClearTravelLimits removes the soft travel limits of the LinearActuator.
+func (m *LinearActuator) ClearTravelLimits() *LinearActuator {
+	m.limits = nil
+	return m
+}
+
+// TravelLimits returns the soft travel limits of the LinearActuator in
+// tacho counts. If no limits are set, ok is false.
+func (m *LinearActuator) TravelLimits() (min, max int, ok bool) {
+	if m.limits == nil {
+		return 0, 0, false
+	}
+	return m.limits.min, m.limits.max, true
+}
+
+// checkTravel returns an error if issuing comm would move the
+// LinearActuator to a position outside its travel limits.
+func (m *LinearActuator) checkTravel(comm MotorCommand) error {
+	l := m.limits
+	if l == nil {
+		return nil
+	}
+	var target int
+	switch comm {
+	case CommandRunToAbsPos:
+		sp, err := m.PositionSetpoint()
+		if err != nil {
+			return err
+		}
+		target = sp
+	case CommandRunToRelPos:
+		sp, err := m.PositionSetpoint()
+		if err != nil {
+			return err
+		}
+		pos, err := m.Position()
+		if err != nil {
+			return err
+		}
+		target = pos + sp
+	default:
+		return nil
+	}
+	if target < l.min || l.max < target {
+		return fmt.Errorf("ev3dev: %s target position for %s outside travel limits: %d (must be in %d-%d)", comm, deviceName(m), target, l.min, l.max)
+	}
+	return nil
+}
+
+// SuperviseTravel starts a goroutine that polls the position of the
+// LinearActuator every 5ms and issues the stop command if the position is
+// outside the travel limits set by SetTravelLimits. The limits in effect
+// when SuperviseTravel is called are used. The goroutine does not use the
+// LinearActuator's error state or its default context, so a stop can be
+// issued even after the default context is done.
+//
+// The returned channel receives an error and is closed if the actuator is
+// stopped by the supervisor, or if the position can no longer be read, in
+// which case the actuator is also stopped. The channel is closed without a
+// value when ctx is done or the LinearActuator is closed.
+func (m *LinearActuator) SuperviseTravel(ctx context.Context) <-chan error {
+	c := make(chan error, 1)
+	l := m.limits
+	if l == nil {
+		c <- fmt.Errorf("ev3dev: no travel limits for %s", deviceName(m))
+		close(c)
+		return c
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	unhook := addCloseHook(&m.onClose, cancel)
+	go func() {
+		defer close(c)
+		defer cancel()
+		defer unhook()
+		err := superviseTravel(ctx, errlessDevice{m}, *l)
+		if err != nil {
+			c <- err
+		}
+	}()
+	return c
+}
+
+// superviseTravel polls the position of d until it leaves the
+// window l, when it stops d, or until ctx is done.
+func superviseTravel(ctx context.Context, d Device, l travelLimits) error {
+	ticker := time.NewTicker(positionPoll)
+	defer ticker.Stop()
+	for {
+		pos, err := intFrom(attributeOf(d, position))
+		if err == nil && (pos < l.min || l.max < pos) {
+			err = fmt.Errorf("ev3dev: %s position outside travel limits: %d (must be in %d-%d)", deviceName(d), pos, l.min, l.max)
+		}
+		if err != nil {
+			stopErr := setAttributeOf(d, command, string(CommandStop))
+			if stopErr != nil {
+				return fmt.Errorf("%v: failed to stop: %v", err, stopErr)
+			}
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinearActuatorTravelLimits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "linear0"): {
+			position:         "100",
+			positionSetpoint: "0",
+			speedSetpoint:    "0",
+			command:          "",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		return filepath.Join(dir, TachoMotorPath, "linear0", name)
+	}
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(attr(name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	// Values for the Firgelli L12 50mm actuator.
+	m := &LinearActuator{
+		id:              0,
+		countPerMeter:   20000,
+		fullTravelCount: 1000,
+		maxSpeed:        24,
+		commands:        []string{"run-forever", "run-to-abs-pos", "run-to-rel-pos", "stop"},
+	}
+	if _, _, ok := m.TravelLimits(); ok {
+		t.Error("unexpected travel limits before setting")
+	}
+	err = m.SetTravelLimits(10, -10).Err()
+	if err == nil {
+		t.Error("expected error for inverted limits")
+	}
+	err = m.SetTravelLimitsMM(0, 25).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if min, max, ok := m.TravelLimits(); !ok || min != 0 || max != 500 {
+		t.Errorf("unexpected travel limits: got:%d-%d ok=%t want:0-500 ok=true", min, max, ok)
+	}
+
+	for _, test := range []struct {
+		sp    int
+		comm  MotorCommand
+		valid bool
+	}{
+		{sp: 500, comm: CommandRunToAbsPos, valid: true},
+		{sp: 501, comm: CommandRunToAbsPos, valid: false},
+		{sp: -1, comm: CommandRunToAbsPos, valid: false},
+		{sp: 400, comm: CommandRunToRelPos, valid: true},
+		{sp: 401, comm: CommandRunToRelPos, valid: false},
+		{sp: -101, comm: CommandRunToRelPos, valid: false},
+		{sp: 1000, comm: CommandRunForever, valid: true},
+	} {
+		err = ioutil.WriteFile(attr(command), nil, 0644)
+		if err != nil {
+			t.Fatalf("failed to clear command: %v", err)
+		}
+		err = m.SetPositionSetpoint(test.sp).Command(test.comm).Err()
+		if test.valid {
+			if err != nil {
+				t.Errorf("unexpected error for %s to %d: %v", test.comm, test.sp, err)
+			}
+			if got := read(command); got != string(test.comm) {
+				t.Errorf("unexpected command: got:%q want:%q", got, test.comm)
+			}
+		} else {
+			if err == nil {
+				t.Errorf("expected error for %s to %d", test.comm, test.sp)
+			}
+			if got := read(command); got != "" {
+				t.Errorf("unexpected command issued outside limits: %q", got)
+			}
+		}
+	}
+
+	err = m.ClearTravelLimits().SetPositionSetpoint(900).Command(CommandRunToAbsPos).Err()
+	if err != nil {
+		t.Errorf("unexpected error after clearing limits: %v", err)
+	}
+	err = <-m.SuperviseTravel(context.Background())
+	if err == nil {
+		t.Error("expected error supervising without limits")
+	}
+
+	err = ioutil.WriteFile(attr(command), []byte("run-forever\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write command: %v", err)
+	}
+	c := m.SetTravelLimits(0, 500).SuperviseTravel(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	select {
+	case err := <-c:
+		t.Fatalf("unexpected supervisor result within limits: %v", err)
+	default:
+	}
+	// Write a position of the same length as the
+	// current one so that a concurrent read cannot
+	// see a mixture of the two.
+	err = ioutil.WriteFile(attr(position), []byte("900\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write position: %v", err)
+	}
+	select {
+	case err = <-c:
+		if err == nil || !strings.Contains(err.Error(), "900") {
+			t.Errorf("unexpected supervisor error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for supervisor")
+	}
+	if got := read(command); got != string(CommandStop) {
+		t.Errorf("actuator not stopped by supervisor: got:%q", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c = m.SetTravelLimits(0, 1000).SuperviseTravel(ctx)
+	cancel()
+	if err, ok := <-c; ok {
+		t.Errorf("unexpected supervisor result after cancel: %v", err)
+	}
+	c = m.SuperviseTravel(context.Background())
+	err = m.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+	if err, ok := <-c; ok {
+		t.Errorf("unexpected supervisor result after close: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// PositionMM returns the current position of the LinearActuator in
+// millimetres.
+func (m *LinearActuator) PositionMM() (float64, error) {
+	if m.countPerMeter <= 0 {
+		return 0, fmt.Errorf("ev3dev: no count per meter for %s", deviceName(m))
+	}
+	pos, err := m.Position()
+	if err != nil {
+		return 0, err
+	}
+	return float64(pos) * 1000 / float64(m.countPerMeter), nil
+}
+
+// SetPositionSetpointMM sets the position setpoint of the LinearActuator
+// in millimetres. The setpoint is rounded to the nearest tacho count, with
+// halves rounded away from zero, and its magnitude must not exceed the full
+// travel of the LinearActuator.
+func (m *LinearActuator) SetPositionSetpointMM(mm float64) *LinearActuator {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	sp, err := m.countsForMM(mm)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	return m.SetPositionSetpoint(sp)
+}
+
+// SpeedMMPerSec returns the current speed of the LinearActuator in
+// millimetres per second.
+func (m *LinearActuator) SpeedMMPerSec() (float64, error) {
+	if m.countPerMeter <= 0 {
+		return 0, fmt.Errorf("ev3dev: no count per meter for %s", deviceName(m))
+	}
+	speed, err := m.Speed()
+	if err != nil {
+		return 0, err
+	}
+	return float64(speed) * 1000 / float64(m.countPerMeter), nil
+}
+
+// MaxSpeedMMPerSec returns the maximum speed setpoint of the
+// LinearActuator in millimetres per second.
+func (m *LinearActuator) MaxSpeedMMPerSec() (float64, error) {
+	if m.countPerMeter <= 0 {
+		return 0, fmt.Errorf("ev3dev: no count per meter for %s", deviceName(m))
+	}
+	return float64(m.maxSpeed) * 1000 / float64(m.countPerMeter), nil
+}
+
+// SetSpeedSetpointMMPerSec sets the speed setpoint of the LinearActuator
+// in millimetres per second. The setpoint is rounded to the nearest tacho
+// count per second, with halves rounded away from zero, and its magnitude
+// must not exceed MaxSpeed.
+func (m *LinearActuator) SetSpeedSetpointMMPerSec(mmps float64) *LinearActuator {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if m.countPerMeter <= 0 {
+		setErr(&m.err, fmt.Errorf("ev3dev: no count per meter for %s", deviceName(m)))
+		return m
+	}
+	sp := math.Round(mmps * float64(m.countPerMeter) / 1000)
+	if !(-float64(m.maxSpeed) <= sp && sp <= float64(m.maxSpeed)) {
+		setErr(&m.err, fmt.Errorf("ev3dev: speed setpoint for %s out of range: %vmm/s (must be in %v-%vmm/s)",
+			deviceName(m), mmps, -float64(m.maxSpeed)*1000/float64(m.countPerMeter), float64(m.maxSpeed)*1000/float64(m.countPerMeter)))
+		return m
+	}
+	return m.SetSpeedSetpoint(int(sp))
+}
+
+// DriveDistanceMM moves the LinearActuator by mm millimetres at the given
+// speed by setting the position and speed setpoints and issuing the
+// run-to-rel-pos command. The distance is rounded as described for
+// SetPositionSetpointMM and its magnitude must not exceed the full travel
+// of the LinearActuator. If block is true, DriveDistanceMM waits until the
+// actuator is no longer running and returns the final motor state. Otherwise
+// the returned motor state is zero.
+//
+// The wait is cancelled if the LinearActuator's default context is done.
+func (m *LinearActuator) DriveDistanceMM(mm float64, speed int, block bool) (MotorState, error) {
+	err := m.SetPositionSetpointMM(mm).SetSpeedSetpoint(speed).Command(CommandRunToRelPos).Err()
+	if err != nil || !block {
+		return 0, err
+	}
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stat, _, err := WaitContext(ctx, m, Running, 0, 0, false)
+	return stat, err
+}
+
+// countsForMM returns the number of tacho counts in mm millimetres of
+// travel, checking that it is within the full travel of the actuator.
+// The range is not checked if the full travel count is not known.
+func (m *LinearActuator) countsForMM(mm float64) (int, error) {
+	if m.countPerMeter <= 0 {
+		return 0, fmt.Errorf("ev3dev: no count per meter for %s", deviceName(m))
+	}
+	c := math.Round(mm * float64(m.countPerMeter) / 1000)
+	if math.IsNaN(c) || c < math.MinInt32 || math.MaxInt32 < c {
+		return 0, fmt.Errorf("ev3dev: position setpoint for %s out of range: %vmm", deviceName(m), mm)
+	}
+	counts := int(c)
+	if m.fullTravelCount > 0 && (counts < -m.fullTravelCount || m.fullTravelCount < counts) {
+		return 0, newValueOutOfRangeError(m, positionSetpoint, counts, -m.fullTravelCount, m.fullTravelCount)
+	}
+	return counts, nil
+}
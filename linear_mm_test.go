@@ -0,0 +1,187 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLinearActuatorMM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "linear0"): {
+			position:         "0",
+			positionSetpoint: "0",
+			speed:            "0",
+			speedSetpoint:    "0",
+			command:          "",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		return filepath.Join(dir, TachoMotorPath, "linear0", name)
+	}
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(attr(name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+	newActuator := func() *LinearActuator {
+		// Values for the Firgelli L12 50mm actuator.
+		return &LinearActuator{
+			id:              0,
+			countPerMeter:   20000,
+			fullTravelCount: 1000,
+			maxSpeed:        24,
+			commands:        []string{"run-to-abs-pos", "run-to-rel-pos", "stop"},
+		}
+	}
+
+	for _, test := range []struct {
+		position int
+		want     float64
+	}{
+		{position: 0, want: 0},
+		{position: 500, want: 25},
+		{position: 1, want: 0.05},
+		{position: -33, want: -1.65},
+	} {
+		err = ioutil.WriteFile(attr(position), []byte(strconv.Itoa(test.position)+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write position: %v", err)
+		}
+		got, err := newActuator().PositionMM()
+		if err != nil {
+			t.Errorf("unexpected error getting position: %v", err)
+		}
+		if math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("unexpected position for %d counts: got:%vmm want:%vmm", test.position, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		mm      float64
+		want    string
+		wantErr bool
+	}{
+		{mm: 25, want: "500"},
+		{mm: 0.024, want: "0"},
+		{mm: 0.025, want: "1"},
+		{mm: -0.025, want: "-1"},
+		{mm: 50, want: "1000"},
+		{mm: -50, want: "-1000"},
+		{mm: 50.05, wantErr: true},
+		{mm: -50.05, wantErr: true},
+		{mm: math.NaN(), wantErr: true},
+	} {
+		err = ioutil.WriteFile(attr(positionSetpoint), []byte("0\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to reset position setpoint: %v", err)
+		}
+		err := newActuator().SetPositionSetpointMM(test.mm).Err()
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %vmm: got:%v want error:%t", test.mm, err, test.wantErr)
+		}
+		if test.wantErr {
+			if got := read(positionSetpoint); got != "0" {
+				t.Errorf("unexpected position setpoint write for %vmm: got:%s", test.mm, got)
+			}
+			continue
+		}
+		if got := read(positionSetpoint); got != test.want {
+			t.Errorf("unexpected position setpoint for %vmm: got:%s want:%s", test.mm, got, test.want)
+		}
+	}
+
+	_, err = newActuator().DriveDistanceMM(-12.5, 12, false)
+	if err != nil {
+		t.Errorf("unexpected error driving: %v", err)
+	}
+	for name, want := range map[string]string{
+		positionSetpoint: "-250",
+		speedSetpoint:    "12",
+		command:          string(CommandRunToRelPos),
+	} {
+		if got := read(name); got != want {
+			t.Errorf("unexpected %s after drive: got:%s want:%s", name, got, want)
+		}
+	}
+
+	err = ioutil.WriteFile(attr(speed), []byte("-15\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write speed: %v", err)
+	}
+	got, err := newActuator().SpeedMMPerSec()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got != -0.75 {
+		t.Errorf("unexpected speed: got:%vmm/s want:-0.75mm/s", got)
+	}
+	got, err = newActuator().MaxSpeedMMPerSec()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got != 1.2 {
+		t.Errorf("unexpected max speed: got:%vmm/s want:1.2mm/s", got)
+	}
+	for _, test := range []struct {
+		mmps    float64
+		want    string
+		wantErr bool
+	}{
+		{mmps: 0, want: "0"},
+		{mmps: 1.2, want: "24"},
+		{mmps: -0.5, want: "-10"},
+		{mmps: 0.025, want: "1"},
+		{mmps: 1.25, wantErr: true},
+		{mmps: -1.25, wantErr: true},
+		{mmps: math.NaN(), wantErr: true},
+	} {
+		err = ioutil.WriteFile(attr(speedSetpoint), []byte("0\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to reset speed setpoint: %v", err)
+		}
+		err := newActuator().SetSpeedSetpointMMPerSec(test.mmps).Err()
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %vmm/s: got:%v want error:%t", test.mmps, err, test.wantErr)
+		}
+		want := test.want
+		if test.wantErr {
+			want = "0"
+		}
+		if got := read(speedSetpoint); got != want {
+			t.Errorf("unexpected speed setpoint for %vmm/s: got:%s want:%s", test.mmps, got, want)
+		}
+	}
+
+	_, err = (&LinearActuator{id: 0}).SpeedMMPerSec()
+	if err == nil {
+		t.Error("expected error for speed without count per meter")
+	}
+	err = (&LinearActuator{id: 0}).SetSpeedSetpointMMPerSec(1).Err()
+	if err == nil {
+		t.Error("expected error for speed setpoint without count per meter")
+	}
+	_, err = (&LinearActuator{id: 0}).PositionMM()
+	if err == nil {
+		t.Error("expected error for position without count per meter")
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package messaging provides EV3-G compatible mailbox messaging over
+// Bluetooth serial (RFCOMM) and Wi-Fi connections.
+//
+// Messages are sent to named mailboxes using the framing of the EV3
+// system WRITEMAILBOX command, so a brick running an ev3dev program can
+// exchange messages with bricks running the official EV3 software and
+// with phone applications that implement EV3 messaging. As in EV3-G, a
+// message holds text, a number or a logic value, and the receiver
+// interprets the message according to the type it expects.
+//
+// Wi-Fi connections use the discovery beacon and unlock handshake of the
+// stock firmware, so an ev3dev brick can both connect to a stock brick and
+// accept connections from stock bricks and the LEGO apps.
+package messaging
@@ -0,0 +1,210 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/ev3go/ev3dev"
+)
+
+func init() {
+	ev3dev.RegisterFeature("messaging")
+}
+
+// EV3 system command framing.
+const (
+	systemCommandNoReply = 0x81
+	writeMailbox         = 0x9e
+)
+
+// maxFrame is the maximum length of a frame body.
+const maxFrame = math.MaxUint16
+
+// Conn is a mailbox messaging connection. The methods of Conn are safe
+// for concurrent use, although concurrent calls to Receive will each
+// receive different messages.
+type Conn struct {
+	rw io.ReadWriteCloser
+
+	wmu     sync.Mutex
+	counter uint16
+
+	rmu sync.Mutex
+}
+
+// NewConn returns a new Conn communicating over rw. NewConn is intended
+// for use with connections other than those opened by Dial and Listener.
+func NewConn(rw io.ReadWriteCloser) *Conn {
+	return &Conn{rw: rw}
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.rw.Close()
+}
+
+// SendText sends the text message s to the named mailbox.
+func (c *Conn) SendText(mailbox, s string) error {
+	if i := bytes.IndexByte([]byte(s), 0); i >= 0 {
+		return fmt.Errorf("messaging: text message contains NUL at %d", i)
+	}
+	return c.send(mailbox, append([]byte(s), 0))
+}
+
+// SendNumber sends the number message v to the named mailbox. Numbers are
+// sent with the single precision used by the EV3.
+func (c *Conn) SendNumber(mailbox string, v float64) error {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], math.Float32bits(float32(v)))
+	return c.send(mailbox, b[:])
+}
+
+// SendLogic sends the logic message v to the named mailbox.
+func (c *Conn) SendLogic(mailbox string, v bool) error {
+	var b [1]byte
+	if v {
+		b[0] = 1
+	}
+	return c.send(mailbox, b[:])
+}
+
+// send writes a WRITEMAILBOX frame for the mailbox and payload.
+func (c *Conn) send(mailbox string, payload []byte) error {
+	if mailbox == "" || bytes.IndexByte([]byte(mailbox), 0) >= 0 || len(mailbox) > math.MaxUint8-1 {
+		return fmt.Errorf("messaging: invalid mailbox name %q", mailbox)
+	}
+	// The body holds the message counter, the command type and
+	// command, the NUL terminated mailbox name with its length
+	// and the payload with its length.
+	n := 2 + 2 + 1 + len(mailbox) + 1 + 2 + len(payload)
+	if n > maxFrame {
+		return fmt.Errorf("messaging: message too long: %d bytes", len(payload))
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	frame := make([]byte, 0, 2+n)
+	frame = appendUint16(frame, uint16(n))
+	frame = appendUint16(frame, c.counter)
+	frame = append(frame, systemCommandNoReply, writeMailbox, byte(len(mailbox)+1))
+	frame = append(frame, mailbox...)
+	frame = append(frame, 0)
+	frame = appendUint16(frame, uint16(len(payload)))
+	frame = append(frame, payload...)
+	c.counter++
+	_, err := c.rw.Write(frame)
+	return err
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+// Message is a message received from a mailbox.
+type Message struct {
+	// Mailbox is the name of the mailbox
+	// the message was sent to.
+	Mailbox string
+
+	// Payload is the raw message content.
+	Payload []byte
+}
+
+// ErrMessageType is returned when a message cannot be interpreted as the
+// requested type.
+var ErrMessageType = errors.New("messaging: message type mismatch")
+
+// Text returns the message interpreted as a text message.
+func (m Message) Text() (string, error) {
+	p := m.Payload
+	if len(p) == 0 || p[len(p)-1] != 0 {
+		return "", ErrMessageType
+	}
+	return string(p[:len(p)-1]), nil
+}
+
+// Number returns the message interpreted as a number message.
+func (m Message) Number() (float64, error) {
+	if len(m.Payload) != 4 {
+		return 0, ErrMessageType
+	}
+	return float64(math.Float32frombits(binary.LittleEndian.Uint32(m.Payload))), nil
+}
+
+// Logic returns the message interpreted as a logic message.
+func (m Message) Logic() (bool, error) {
+	if len(m.Payload) != 1 {
+		return false, ErrMessageType
+	}
+	return m.Payload[0] != 0, nil
+}
+
+// Receive waits for the next mailbox message on the connection. Frames
+// other than WRITEMAILBOX commands are discarded.
+func (c *Conn) Receive() (Message, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+	for {
+		var hdr [2]byte
+		_, err := io.ReadFull(c.rw, hdr[:])
+		if err != nil {
+			return Message{}, err
+		}
+		body := make([]byte, binary.LittleEndian.Uint16(hdr[:]))
+		_, err = io.ReadFull(c.rw, body)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return Message{}, err
+		}
+		m, ok, err := parseMailbox(body)
+		if err != nil {
+			return Message{}, err
+		}
+		if ok {
+			return m, nil
+		}
+	}
+}
+
+// parseMailbox parses the body of a frame. It returns false if the
+// frame is not a WRITEMAILBOX command.
+func parseMailbox(body []byte) (Message, bool, error) {
+	if len(body) < 4 || body[2] != systemCommandNoReply || body[3] != writeMailbox {
+		return Message{}, false, nil
+	}
+	b := body[4:]
+	if len(b) < 1 {
+		return Message{}, false, errMalformed
+	}
+	n := int(b[0])
+	b = b[1:]
+	if n < 1 || len(b) < n || b[n-1] != 0 {
+		return Message{}, false, errMalformed
+	}
+	name := string(b[:n-1])
+	b = b[n:]
+	if len(b) < 2 {
+		return Message{}, false, errMalformed
+	}
+	n = int(binary.LittleEndian.Uint16(b))
+	b = b[2:]
+	if len(b) != n {
+		return Message{}, false, errMalformed
+	}
+	return Message{Mailbox: name, Payload: b}, true, nil
+}
+
+// errMalformed is returned when a malformed WRITEMAILBOX
+// frame is received.
+var errMalformed = errors.New("messaging: malformed mailbox frame")
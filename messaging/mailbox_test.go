@@ -0,0 +1,166 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+// buffer is an io.ReadWriteCloser backed by a bytes.Buffer.
+type buffer struct {
+	bytes.Buffer
+}
+
+func (*buffer) Close() error { return nil }
+
+func TestSendFraming(t *testing.T) {
+	var buf buffer
+	c := NewConn(&buf)
+
+	err := c.SendText("abc", "hi")
+	if err != nil {
+		t.Fatalf("unexpected error sending text: %v", err)
+	}
+	err = c.SendNumber("n", 1)
+	if err != nil {
+		t.Fatalf("unexpected error sending number: %v", err)
+	}
+	err = c.SendLogic("l", true)
+	if err != nil {
+		t.Fatalf("unexpected error sending logic: %v", err)
+	}
+
+	want := []byte{
+		0x0e, 0x00, 0x00, 0x00, 0x81, 0x9e, 0x04, 'a', 'b', 'c', 0x00, 0x03, 0x00, 'h', 'i', 0x00,
+		0x0d, 0x00, 0x01, 0x00, 0x81, 0x9e, 0x02, 'n', 0x00, 0x04, 0x00, 0x00, 0x00, 0x80, 0x3f,
+		0x0a, 0x00, 0x02, 0x00, 0x81, 0x9e, 0x02, 'l', 0x00, 0x01, 0x00, 0x01,
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("unexpected frames:\ngot: % x\nwant:% x", buf.Bytes(), want)
+	}
+}
+
+func TestSendInvalid(t *testing.T) {
+	c := NewConn(&buffer{})
+	for _, box := range []string{"", "a\x00b", string(make([]byte, 255))} {
+		err := c.SendLogic(box, true)
+		if err == nil {
+			t.Errorf("expected error for mailbox %q", box)
+		}
+	}
+	err := c.SendText("box", "a\x00b")
+	if err == nil {
+		t.Error("expected error for text with NUL")
+	}
+	err = c.SendText("box", string(make([]byte, maxFrame)))
+	if err == nil {
+		t.Error("expected error for long text")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	a, b := net.Pipe()
+	src := NewConn(a)
+	dst := NewConn(b)
+	defer dst.Close()
+
+	go func() {
+		defer src.Close()
+		src.SendText("status", "ready")
+		// A frame that is not a mailbox write
+		// must be skipped by the receiver.
+		a.Write([]byte{0x03, 0x00, 0x00, 0x00, 0x01})
+		src.SendNumber("speed", -2.5)
+		src.SendLogic("go", true)
+	}()
+
+	m, err := dst.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving text: %v", err)
+	}
+	text, err := m.Text()
+	if m.Mailbox != "status" || text != "ready" || err != nil {
+		t.Errorf("unexpected text message: got:%q %q %v", m.Mailbox, text, err)
+	}
+	_, err = m.Number()
+	if err != ErrMessageType {
+		t.Errorf("unexpected error interpreting text as number: got:%v want:%v", err, ErrMessageType)
+	}
+
+	m, err = dst.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving number: %v", err)
+	}
+	num, err := m.Number()
+	if m.Mailbox != "speed" || num != -2.5 || err != nil {
+		t.Errorf("unexpected number message: got:%q %v %v", m.Mailbox, num, err)
+	}
+
+	m, err = dst.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving logic: %v", err)
+	}
+	logic, err := m.Logic()
+	if m.Mailbox != "go" || !logic || err != nil {
+		t.Errorf("unexpected logic message: got:%q %t %v", m.Mailbox, logic, err)
+	}
+
+	_, err = dst.Receive()
+	if err != io.EOF {
+		t.Errorf("unexpected error after close: got:%v want:%v", err, io.EOF)
+	}
+}
+
+func TestReceiveMalformed(t *testing.T) {
+	for _, frame := range [][]byte{
+		{0x05, 0x00, 0x00, 0x00, 0x81, 0x9e, 0x03},
+		{0x08, 0x00, 0x00, 0x00, 0x81, 0x9e, 0x02, 'a', 'b', 0x00},
+		{0x0a, 0x00, 0x00, 0x00, 0x81, 0x9e, 0x02, 'a', 0x00, 0x02, 0x00, 0x01},
+	} {
+		var buf buffer
+		buf.Write(frame)
+		_, err := NewConn(&buf).Receive()
+		if err != errMalformed {
+			t.Errorf("unexpected error for frame % x: got:%v want:%v", frame, err, errMalformed)
+		}
+	}
+
+	var buf buffer
+	buf.Write([]byte{0x08, 0x00, 0x00})
+	_, err := NewConn(&buf).Receive()
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("unexpected error for truncated frame: got:%v want:%v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	for _, test := range []struct {
+		addr string
+		want Addr
+		ok   bool
+	}{
+		{addr: "00:16:53:4F:2A:10", want: Addr{0x00, 0x16, 0x53, 0x4f, 0x2a, 0x10}, ok: true},
+		{addr: "00:16:53:4f:2a:10", want: Addr{0x00, 0x16, 0x53, 0x4f, 0x2a, 0x10}, ok: true},
+		{addr: "00:16:53:4F:2A"},
+		{addr: "00:16:53:4F:2A:1"},
+		{addr: "00:16:53:4F:2A:GG"},
+		{addr: "+1:16:53:4F:2A:10"},
+	} {
+		got, err := ParseAddr(test.addr)
+		if (err == nil) != test.ok {
+			t.Errorf("unexpected error for %q: %v", test.addr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("unexpected address for %q: got:%v want:%v", test.addr, got, test.want)
+		}
+		if test.ok && got.String() != "00:16:53:4F:2A:10" {
+			t.Errorf("unexpected string for %q: got:%s", test.addr, got)
+		}
+	}
+}
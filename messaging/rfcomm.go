@@ -0,0 +1,40 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultChannel is the RFCOMM channel used by the EV3 serial port
+// profile.
+const DefaultChannel = 1
+
+// Addr is a Bluetooth device address.
+type Addr [6]byte
+
+// ParseAddr parses a Bluetooth device address in the colon separated
+// hexadecimal form, for example "00:16:53:4F:2A:10".
+func ParseAddr(s string) (Addr, error) {
+	var a Addr
+	f := strings.Split(s, ":")
+	if len(f) != len(a) {
+		return a, fmt.Errorf("messaging: invalid Bluetooth address %q", s)
+	}
+	for i, h := range f {
+		v, err := strconv.ParseUint(h, 16, 8)
+		if err != nil || len(h) != 2 {
+			return Addr{}, fmt.Errorf("messaging: invalid Bluetooth address %q", s)
+		}
+		a[i] = byte(v)
+	}
+	return a, nil
+}
+
+func (a Addr) String() string {
+	return fmt.Sprintf("%02X:%02X:%02X:%02X:%02X:%02X", a[0], a[1], a[2], a[3], a[4], a[5])
+}
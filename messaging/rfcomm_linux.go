@@ -0,0 +1,77 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Dial opens an RFCOMM connection to the paired device at addr on the
+// given channel. The device must already be paired with the brick.
+func Dial(addr Addr, channel uint8) (*Conn, error) {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, unix.BTPROTO_RFCOMM)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	err = unix.Connect(fd, &unix.SockaddrRFCOMM{Addr: addr.reversed(), Channel: channel})
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("connect", err)
+	}
+	return NewConn(os.NewFile(uintptr(fd), "rfcomm:"+addr.String())), nil
+}
+
+// Listener is an RFCOMM listener.
+type Listener struct {
+	fd int
+}
+
+// Listen returns a Listener accepting RFCOMM connections from paired
+// devices on the given channel.
+func Listen(channel uint8) (*Listener, error) {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, unix.BTPROTO_RFCOMM)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	err = unix.Bind(fd, &unix.SockaddrRFCOMM{Channel: channel})
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+	err = unix.Listen(fd, 1)
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("listen", err)
+	}
+	return &Listener{fd: fd}, nil
+}
+
+// Accept waits for and returns the next connection to the Listener and
+// the address of the connecting device.
+func (l *Listener) Accept() (*Conn, Addr, error) {
+	nfd, sa, err := unix.Accept4(l.fd, unix.SOCK_CLOEXEC)
+	if err != nil {
+		return nil, Addr{}, os.NewSyscallError("accept", err)
+	}
+	var addr Addr
+	if rc, ok := sa.(*unix.SockaddrRFCOMM); ok {
+		addr = Addr(rc.Addr).reversed()
+	}
+	return NewConn(os.NewFile(uintptr(nfd), "rfcomm:"+addr.String())), addr, nil
+}
+
+// Close closes the Listener.
+func (l *Listener) Close() error {
+	return os.NewSyscallError("close", unix.Close(l.fd))
+}
+
+// reversed returns a with its bytes reversed. Bluetooth addresses are
+// written most significant byte first, but are held by the kernel in
+// little-endian order.
+func (a Addr) reversed() [6]byte {
+	return [6]byte{a[5], a[4], a[3], a[2], a[1], a[0]}
+}
@@ -0,0 +1,38 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package messaging
+
+import "errors"
+
+var errNotSupported = errors.New("messaging: RFCOMM is only supported on linux")
+
+// Dial opens an RFCOMM connection to the paired device at addr on the
+// given channel. Dial is only supported on linux.
+func Dial(addr Addr, channel uint8) (*Conn, error) {
+	return nil, errNotSupported
+}
+
+// Listener is an RFCOMM listener.
+type Listener struct{}
+
+// Listen returns a Listener accepting RFCOMM connections from paired
+// devices on the given channel. Listen is only supported on linux.
+func Listen(channel uint8) (*Listener, error) {
+	return nil, errNotSupported
+}
+
+// Accept waits for and returns the next connection to the Listener and
+// the address of the connecting device.
+func (l *Listener) Accept() (*Conn, Addr, error) {
+	return nil, Addr{}, errNotSupported
+}
+
+// Close closes the Listener.
+func (l *Listener) Close() error {
+	return errNotSupported
+}
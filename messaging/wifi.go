@@ -0,0 +1,270 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ports used by the stock EV3 firmware for Wi-Fi communication.
+const (
+	// WiFiPort is the TCP port on which a brick
+	// accepts connections.
+	WiFiPort = 5555
+
+	// BeaconPort is the UDP port to which a brick
+	// broadcasts its beacon.
+	BeaconPort = 3015
+)
+
+// acceptReply is the reply sent by a brick to a successful unlock request.
+const acceptReply = "Accept:EV340\r\n\r\n"
+
+// Beacon is the announcement broadcast by an EV3 brick on its Wi-Fi network.
+type Beacon struct {
+	// Serial is the serial number of the brick,
+	// its Bluetooth address in hexadecimal.
+	Serial string
+
+	// Port is the TCP port the brick
+	// accepts connections on.
+	Port int
+
+	// Name is the name of the brick.
+	Name string
+
+	// Protocol is the communication protocol,
+	// "EV3" for an EV3 brick.
+	Protocol string
+}
+
+// String returns the beacon in the form broadcast by the stock firmware.
+func (b Beacon) String() string {
+	return fmt.Sprintf("Serial-Number: %s\r\nPort: %d\r\nName: %s\r\nProtocol: %s\r\n", b.Serial, b.Port, b.Name, b.Protocol)
+}
+
+// ParseBeacon parses a beacon broadcast by an EV3 brick.
+func ParseBeacon(data []byte) (Beacon, error) {
+	var b Beacon
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			return Beacon{}, fmt.Errorf("messaging: malformed beacon line %q", line)
+		}
+		v := strings.TrimSpace(kv[1])
+		switch kv[0] {
+		case "Serial-Number":
+			b.Serial = v
+		case "Port":
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return Beacon{}, fmt.Errorf("messaging: malformed beacon port %q", v)
+			}
+			b.Port = p
+		case "Name":
+			b.Name = v
+		case "Protocol":
+			b.Protocol = v
+		}
+	}
+	if b.Serial == "" || b.Port == 0 {
+		return Beacon{}, fmt.Errorf("messaging: incomplete beacon %q", data)
+	}
+	return b, nil
+}
+
+// Discover waits for a beacon from an EV3 brick on the local network and
+// returns it with the brick's IP address. The stock firmware does not accept
+// connections until it has received a reply to its beacon, so Discover sends
+// one before returning.
+func Discover(ctx context.Context) (Beacon, net.IP, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{Port: BeaconPort})
+	if err != nil {
+		return Beacon{}, nil, err
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	buf := make([]byte, 1024)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return Beacon{}, nil, err
+		}
+		b, err := ParseBeacon(buf[:n])
+		if err != nil || b.Protocol != "EV3" {
+			continue
+		}
+		_, err = conn.WriteToUDP([]byte{0}, from)
+		if err != nil {
+			return Beacon{}, nil, err
+		}
+		return b, from.IP, nil
+	}
+}
+
+// Advertise broadcasts the beacon b on the local network every interval until
+// ctx is done, allowing the LEGO apps and bricks to discover a brick listening
+// with ListenWiFi.
+func Advertise(ctx context.Context, b Beacon, interval time.Duration) error {
+	conn, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: net.IPv4bcast, Port: BeaconPort})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	msg := []byte(b.String())
+	for {
+		_, err = conn.Write(msg)
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// DialWiFi opens a mailbox connection to the brick with the given serial
+// number at addr, a host and port such as "192.168.1.10:5555", performing
+// the unlock handshake required by the stock firmware.
+func DialWiFi(addr, serial string) (*Conn, error) {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	_, err = fmt.Fprintf(c, "GET /target?sn=%s VMTP1.0\r\nProtocol: EV3\r\n\r\n", serial)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	reply := make([]byte, len(acceptReply))
+	_, err = io.ReadFull(c, reply)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("messaging: failed to read unlock reply: %w", err)
+	}
+	if !strings.HasPrefix(string(reply), "Accept:") {
+		c.Close()
+		return nil, fmt.Errorf("messaging: connection refused by %s: %q", addr, reply)
+	}
+	return NewConn(c), nil
+}
+
+// WiFiListener accepts mailbox connections over TCP, performing the brick
+// side of the stock firmware unlock handshake.
+type WiFiListener struct {
+	l      net.Listener
+	serial string
+}
+
+// ListenWiFi returns a WiFiListener accepting connections on addr, for
+// example ":5555", for the given brick serial number. Connections that
+// request a different serial number are refused.
+func ListenWiFi(addr, serial string) (*WiFiListener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &WiFiListener{l: l, serial: serial}, nil
+}
+
+// Addr returns the network address of the WiFiListener.
+func (l *WiFiListener) Addr() net.Addr { return l.l.Addr() }
+
+// Accept waits for and returns the next unlocked connection to the
+// WiFiListener and the address of the connecting device.
+func (l *WiFiListener) Accept() (*Conn, net.Addr, error) {
+	for {
+		c, err := l.l.Accept()
+		if err != nil {
+			return nil, nil, err
+		}
+		rw, err := l.unlock(c)
+		if err != nil {
+			c.Close()
+			continue
+		}
+		return NewConn(rw), c.RemoteAddr(), nil
+	}
+}
+
+// unlock performs the brick side of the unlock handshake on c.
+func (l *WiFiListener) unlock(c net.Conn) (io.ReadWriteCloser, error) {
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+	defer c.SetReadDeadline(time.Time{})
+	r := bufio.NewReader(c)
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	f := strings.Fields(line)
+	if len(f) != 3 || f[0] != "GET" || f[1] != "/target?sn="+l.serial {
+		return nil, fmt.Errorf("messaging: invalid unlock request %q", line)
+	}
+	// Clients differ in whether the protocol line is
+	// followed by a blank line, so read only the line
+	// itself and discard a following blank line if it
+	// has already been received.
+	proto := make([]byte, len("Protocol: EV3"))
+	_, err = io.ReadFull(r, proto)
+	if err != nil {
+		return nil, err
+	}
+	if string(proto) != "Protocol: EV3" {
+		return nil, fmt.Errorf("messaging: invalid unlock protocol %q", proto)
+	}
+	for r.Buffered() != 0 {
+		b, _ := r.Peek(1)
+		if b[0] != '\r' && b[0] != '\n' {
+			break
+		}
+		r.ReadByte()
+	}
+	_, err = io.WriteString(c, acceptReply)
+	if err != nil {
+		return nil, err
+	}
+	return bufferedConn{r: r, Conn: c}, nil
+}
+
+// Close closes the WiFiListener.
+func (l *WiFiListener) Close() error {
+	return l.l.Close()
+}
+
+// bufferedConn is a net.Conn that reads through
+// a bufio.Reader holding data already received.
+type bufferedConn struct {
+	r *bufio.Reader
+	net.Conn
+}
+
+func (c bufferedConn) Read(b []byte) (int, error) { return c.r.Read(b) }
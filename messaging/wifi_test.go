@@ -0,0 +1,155 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package messaging
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+func TestParseBeacon(t *testing.T) {
+	want := Beacon{Serial: "0016533F0C1E", Port: WiFiPort, Name: "EV3", Protocol: "EV3"}
+	got, err := ParseBeacon([]byte("Serial-Number: 0016533F0C1E\r\nPort: 5555\r\nName: EV3\r\nProtocol: EV3\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("unexpected beacon: got:%+v want:%+v", got, want)
+	}
+	got, err = ParseBeacon([]byte(want.String()))
+	if err != nil {
+		t.Fatalf("unexpected error parsing formatted beacon: %v", err)
+	}
+	if got != want {
+		t.Errorf("unexpected round trip beacon: got:%+v want:%+v", got, want)
+	}
+
+	for _, data := range []string{
+		"",
+		"Serial-Number: 0016533F0C1E\r\n",
+		"Serial-Number: 0016533F0C1E\r\nPort: x\r\n",
+		"Serial-Number 0016533F0C1E\r\nPort: 5555\r\n",
+	} {
+		_, err := ParseBeacon([]byte(data))
+		if err == nil {
+			t.Errorf("expected error for beacon %q", data)
+		}
+	}
+}
+
+func TestWiFi(t *testing.T) {
+	l, err := ListenWiFi("127.0.0.1:0", "0016533F0C1E")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+
+	type accepted struct {
+		c   *Conn
+		err error
+	}
+	acc := make(chan accepted, 1)
+	go func() {
+		c, _, err := l.Accept()
+		acc <- accepted{c, err}
+	}()
+
+	// A connection requesting the wrong
+	// serial number must be refused.
+	_, err = DialWiFi(l.Addr().String(), "001653000000")
+	if err == nil {
+		t.Error("expected error for wrong serial number")
+	}
+
+	client, err := DialWiFi(l.Addr().String(), "0016533F0C1E")
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer client.Close()
+	a := <-acc
+	if a.err != nil {
+		t.Fatalf("failed to accept: %v", a.err)
+	}
+	server := a.c
+	defer server.Close()
+
+	err = client.SendText("abc", "hello")
+	if err != nil {
+		t.Fatalf("unexpected error sending: %v", err)
+	}
+	m, err := server.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+	got, err := m.Text()
+	if m.Mailbox != "abc" || got != "hello" || err != nil {
+		t.Errorf("unexpected message: got:%s=%q (%v) want:abc=%q", m.Mailbox, got, err, "hello")
+	}
+
+	err = server.SendLogic("reply", true)
+	if err != nil {
+		t.Fatalf("unexpected error sending reply: %v", err)
+	}
+	m, err = client.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving reply: %v", err)
+	}
+	ok, err := m.Logic()
+	if m.Mailbox != "reply" || !ok || err != nil {
+		t.Errorf("unexpected reply: got:%s=%t (%v) want:reply=true", m.Mailbox, ok, err)
+	}
+}
+
+func TestWiFiUnterminatedUnlock(t *testing.T) {
+	l, err := ListenWiFi("127.0.0.1:0", "0016533F0C1E")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer l.Close()
+	acc := make(chan *Conn, 1)
+	go func() {
+		c, _, _ := l.Accept()
+		acc <- c
+	}()
+
+	// Some clients do not terminate the
+	// protocol line of the unlock request.
+	c, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer c.Close()
+	_, err = io.WriteString(c, "GET /target?sn=0016533F0C1E VMTP1.0\r\nProtocol: EV3")
+	if err != nil {
+		t.Fatalf("failed to write unlock request: %v", err)
+	}
+	reply := make([]byte, len(acceptReply))
+	_, err = io.ReadFull(c, reply)
+	if err != nil {
+		t.Fatalf("failed to read unlock reply: %v", err)
+	}
+	if string(reply) != acceptReply {
+		t.Errorf("unexpected unlock reply: got:%q want:%q", reply, acceptReply)
+	}
+	server := <-acc
+	if server == nil {
+		t.Fatal("failed to accept")
+	}
+	defer server.Close()
+
+	err = NewConn(c).SendNumber("n", 2)
+	if err != nil {
+		t.Fatalf("unexpected error sending: %v", err)
+	}
+	m, err := server.Receive()
+	if err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+	v, err := m.Number()
+	if m.Mailbox != "n" || v != 2 || err != nil {
+		t.Errorf("unexpected message: got:%s=%v (%v) want:n=2", m.Mailbox, v, err)
+	}
+}
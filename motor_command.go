@@ -0,0 +1,38 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+// MotorCommand is a command that can be issued to a motor. The commands
+// supported by a particular motor are listed by its Commands method.
+type MotorCommand string
+
+// Motor commands supported by the ev3dev motor drivers.
+const (
+	// Tacho motor, linear actuator
+	// and DC motor commands.
+	CommandRunForever  MotorCommand = "run-forever"
+	CommandRunTimed    MotorCommand = "run-timed"
+	CommandRunDirect   MotorCommand = "run-direct"
+	CommandStop        MotorCommand = "stop"
+	CommandRunToAbsPos MotorCommand = "run-to-abs-pos"
+	CommandRunToRelPos MotorCommand = "run-to-rel-pos"
+	CommandReset       MotorCommand = "reset"
+
+	// Servo motor commands.
+	CommandRun   MotorCommand = "run"
+	CommandFloat MotorCommand = "float"
+)
+
+// StopAction is the action taken by a motor when a stop command is issued.
+// The stop actions supported by a particular motor are listed by its
+// StopActions method.
+type StopAction string
+
+// Stop actions supported by the ev3dev motor drivers.
+const (
+	StopActionCoast StopAction = "coast"
+	StopActionBrake StopAction = "brake"
+	StopActionHold  StopAction = "hold"
+)
@@ -0,0 +1,193 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotInControl is returned by Behavior.Do when the behavior does not
+// hold control of the Arbiter.
+var ErrNotInControl = errors.New("motorutil: behavior not in control")
+
+// Arbiter arbitrates control of a set of motors between prioritized
+// behaviors in the style of a subsumption architecture. Each behavior
+// requests control when it wants to act, and control is held by the
+// highest priority behavior with an outstanding request. Motor commands
+// issued through a Behavior's Do method only reach the motors while the
+// behavior holds control.
+//
+// A behavior that loses control to a higher priority behavior is notified
+// by its OnPreempt function, and a behavior that gains control is notified
+// by its OnGrant function. Notifications are delivered in order, without
+// the Arbiter locked, by the goroutine whose call caused the change, so
+// notification functions may call Request and Release.
+type Arbiter struct {
+	// Idle, if not nil, is called when no
+	// behavior holds control, for example
+	// to stop the motors.
+	Idle func()
+
+	mu        sync.Mutex
+	behaviors []*Behavior
+	owner     *Behavior
+
+	queue    []func()
+	flushing bool
+}
+
+// Behavior is a behavior controlling motors through an Arbiter.
+type Behavior struct {
+	// Name is the name of the behavior.
+	Name string
+
+	// Priority is the priority of the
+	// behavior. Higher values take
+	// precedence. Between behaviors of
+	// equal priority, the behavior that
+	// holds control keeps it.
+	Priority int
+
+	// OnPreempt, if not nil, is called
+	// when the behavior loses control to
+	// the behavior by while its request
+	// is outstanding.
+	OnPreempt func(by *Behavior)
+
+	// OnGrant, if not nil, is called
+	// when the behavior gains control.
+	OnGrant func()
+
+	arbiter   *Arbiter
+	requested bool
+}
+
+// NewBehavior returns a new Behavior with the given name and priority
+// arbitrated by a. The OnPreempt and OnGrant fields of the returned
+// Behavior should be set before it first requests control.
+func (a *Arbiter) NewBehavior(name string, priority int) *Behavior {
+	b := &Behavior{Name: name, Priority: priority, arbiter: a}
+	a.mu.Lock()
+	a.behaviors = append(a.behaviors, b)
+	a.mu.Unlock()
+	return b
+}
+
+// Owner returns the behavior that holds control, or nil if no behavior
+// holds control.
+func (a *Arbiter) Owner() *Behavior {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.owner
+}
+
+// Request requests control for the behavior and returns whether the
+// behavior holds control after the request.
+func (b *Behavior) Request() bool {
+	a := b.arbiter
+	a.mu.Lock()
+	b.requested = true
+	a.arbitrate()
+	ok := a.owner == b
+	a.mu.Unlock()
+	a.flush()
+	return ok
+}
+
+// Release withdraws the behavior's request for control. If the behavior
+// holds control, control passes to the highest priority behavior with an
+// outstanding request.
+func (b *Behavior) Release() {
+	a := b.arbiter
+	a.mu.Lock()
+	b.requested = false
+	a.arbitrate()
+	a.mu.Unlock()
+	a.flush()
+}
+
+// HasControl returns whether the behavior holds control.
+func (b *Behavior) HasControl() bool {
+	a := b.arbiter
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.owner == b
+}
+
+// Do calls fn if the behavior holds control and returns the error returned
+// by fn. Otherwise fn is not called and ErrNotInControl is returned. Control
+// cannot change while fn is running, so fn must not call methods of the
+// Arbiter or its behaviors.
+func (b *Behavior) Do(fn func() error) error {
+	a := b.arbiter
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.owner != b {
+		return ErrNotInControl
+	}
+	return fn()
+}
+
+// arbitrate passes control to the highest priority behavior with an
+// outstanding request, queuing notifications of the change. It must be
+// called with a.mu held.
+func (a *Arbiter) arbitrate() {
+	owner := a.owner
+	if owner != nil && !owner.requested {
+		owner = nil
+	}
+	for _, b := range a.behaviors {
+		if b.requested && (owner == nil || b.Priority > owner.Priority) {
+			owner = b
+		}
+	}
+	if owner == a.owner {
+		return
+	}
+	old := a.owner
+	a.owner = owner
+	if old != nil && old.requested && old.OnPreempt != nil {
+		a.queue = append(a.queue, func() { old.OnPreempt(owner) })
+	}
+	switch {
+	case owner != nil && owner.OnGrant != nil:
+		a.queue = append(a.queue, owner.OnGrant)
+	case owner == nil && a.Idle != nil:
+		a.queue = append(a.queue, a.Idle)
+	}
+}
+
+// flush delivers queued notifications. If another goroutine is already
+// delivering notifications, flush returns immediately and the notifications
+// are delivered by that goroutine.
+func (a *Arbiter) flush() {
+	a.mu.Lock()
+	if a.flushing {
+		a.mu.Unlock()
+		return
+	}
+	a.flushing = true
+	done := false
+	defer func() {
+		if !done {
+			// A notification panicked.
+			a.mu.Lock()
+			a.flushing = false
+			a.mu.Unlock()
+		}
+	}()
+	for len(a.queue) != 0 {
+		fn := a.queue[0]
+		a.queue[0] = nil
+		a.queue = a.queue[1:]
+		a.mu.Unlock()
+		fn()
+		a.mu.Lock()
+	}
+	a.flushing = false
+	done = true
+	a.mu.Unlock()
+}
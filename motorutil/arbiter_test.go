@@ -0,0 +1,159 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestArbiter(t *testing.T) {
+	var log []string
+	a := &Arbiter{Idle: func() { log = append(log, "idle") }}
+	notify := func(b *Behavior) {
+		b.OnGrant = func() { log = append(log, "grant "+b.Name) }
+		b.OnPreempt = func(by *Behavior) { log = append(log, "preempt "+b.Name+" by "+by.Name) }
+	}
+	wander := a.NewBehavior("wander", 0)
+	notify(wander)
+	avoid := a.NewBehavior("avoid", 1)
+	notify(avoid)
+	escape := a.NewBehavior("escape", 1)
+	notify(escape)
+
+	if a.Owner() != nil {
+		t.Errorf("unexpected initial owner: %v", a.Owner().Name)
+	}
+	if !wander.Request() {
+		t.Error("expected wander to gain control")
+	}
+	if !avoid.Request() {
+		t.Error("expected avoid to preempt wander")
+	}
+	if wander.HasControl() {
+		t.Error("expected wander to lose control")
+	}
+	if escape.Request() {
+		t.Error("expected equal priority escape not to preempt avoid")
+	}
+
+	var ran []string
+	for _, b := range []*Behavior{wander, avoid, escape} {
+		b := b
+		err := b.Do(func() error {
+			ran = append(ran, b.Name)
+			return nil
+		})
+		if b == avoid {
+			if err != nil {
+				t.Errorf("unexpected error for owner: %v", err)
+			}
+		} else if err != ErrNotInControl {
+			t.Errorf("unexpected error for %s: got:%v want:%v", b.Name, err, ErrNotInControl)
+		}
+	}
+	if !reflect.DeepEqual(ran, []string{"avoid"}) {
+		t.Errorf("unexpected commands reaching motors: got:%q want:%q", ran, []string{"avoid"})
+	}
+
+	// Releasing a behavior that does not hold
+	// control does not change the owner.
+	wander.Release()
+	if a.Owner() != avoid {
+		t.Errorf("unexpected owner: got:%v want:avoid", a.Owner().Name)
+	}
+	avoid.Release()
+	escape.Release()
+	if a.Owner() != nil {
+		t.Errorf("unexpected owner after release: %v", a.Owner().Name)
+	}
+
+	want := []string{
+		"grant wander",
+		"preempt wander by avoid",
+		"grant avoid",
+		"grant escape",
+		"idle",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("unexpected notifications:\ngot: %q\nwant:%q", log, want)
+	}
+}
+
+func TestArbiterReentrant(t *testing.T) {
+	var log []string
+	a := &Arbiter{}
+	low := a.NewBehavior("low", 0)
+	high := a.NewBehavior("high", 1)
+
+	// A preempted behavior that gives up its
+	// request is not granted control again
+	// when the preempting behavior releases.
+	low.OnPreempt = func(by *Behavior) {
+		log = append(log, "preempt low by "+by.Name)
+		low.Release()
+	}
+	low.OnGrant = func() { log = append(log, "grant low") }
+	// A behavior that is only interested
+	// in a single action releases on grant.
+	high.OnGrant = func() {
+		log = append(log, "grant high")
+		high.Release()
+	}
+
+	low.Request()
+	high.Request()
+	if a.Owner() != nil {
+		t.Errorf("unexpected owner: %v", a.Owner().Name)
+	}
+	want := []string{
+		"grant low",
+		"preempt low by high",
+		"grant high",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("unexpected notifications:\ngot: %q\nwant:%q", log, want)
+	}
+}
+
+func TestArbiterConcurrent(t *testing.T) {
+	a := &Arbiter{}
+	var (
+		mu     sync.Mutex
+		active = make(map[*Behavior]bool)
+	)
+	var behaviors []*Behavior
+	for i := 0; i < 4; i++ {
+		behaviors = append(behaviors, a.NewBehavior("", i))
+	}
+
+	var wg sync.WaitGroup
+	for _, b := range behaviors {
+		b := b
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b.Request()
+				b.Do(func() error {
+					mu.Lock()
+					defer mu.Unlock()
+					active[b] = true
+					if len(active) != 1 {
+						t.Errorf("more than one behavior in control: %d", len(active))
+					}
+					delete(active, b)
+					return nil
+				})
+				b.Release()
+			}
+		}()
+	}
+	wg.Wait()
+	if a.Owner() != nil {
+		t.Errorf("unexpected owner after all releases: %v", a.Owner().Priority)
+	}
+}
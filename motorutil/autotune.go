@@ -0,0 +1,229 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// AutoTune proposes speed regulation PID constants for a tacho motor using
+// the relay feedback method. The motor is run in run-direct mode with its
+// duty cycle switched between Bias+Amplitude and Bias-Amplitude whenever
+// its speed crosses Speed, which causes the speed to oscillate about Speed.
+// The amplitude and period of the oscillation give the ultimate gain and
+// period of the motor, from which PID constants are calculated using the
+// Ziegler–Nichols rules.
+//
+// The oscillation is measured for Cycles complete cycles after the first
+// cycle, which is discarded to allow the motor to settle.
+type AutoTune struct {
+	// Speed is the speed about which the
+	// motor oscillates in tacho counts per
+	// second.
+	Speed int
+
+	// Bias is the duty cycle about which
+	// the relay output is switched. It
+	// should be close to the duty cycle
+	// that holds the motor at Speed.
+	Bias int
+
+	// Amplitude is the relay amplitude
+	// as a duty cycle percentage.
+	Amplitude int
+
+	// Hysteresis is the speed band about
+	// Speed in tacho counts per second
+	// within which the relay does not
+	// switch. It prevents noise in the
+	// speed readings switching the relay.
+	Hysteresis int
+
+	// Cycles is the number of
+	// oscillation cycles measured.
+	Cycles int
+
+	// Sample is the interval between
+	// speed readings.
+	Sample time.Duration
+
+	// Timeout is the maximum duration
+	// of the test.
+	Timeout time.Duration
+
+	// Scale is the factor by which the
+	// calculated gains, in duty cycle
+	// percent per tacho count per second,
+	// are multiplied to give the integer
+	// constants written to the driver.
+	// The scaling used by drivers may
+	// differ. If Scale is zero, 1000
+	// is used.
+	Scale float64
+}
+
+// TuneResult is the result of an AutoTune.
+type TuneResult struct {
+	// UltimateGain is the measured ultimate
+	// gain in duty cycle percent per tacho
+	// count per second.
+	UltimateGain float64
+
+	// UltimatePeriod is the measured
+	// period of oscillation.
+	UltimatePeriod time.Duration
+
+	// PID holds the proposed speed
+	// regulation PID constants.
+	PID ev3dev.PID
+}
+
+// Apply sets the speed regulation PID constants of m to the proposed constants.
+func (r *TuneResult) Apply(m *ev3dev.TachoMotor) error {
+	return m.SetSpeedPID(r.PID).Err()
+}
+
+// tuneMotor is the motor interface used by an AutoTune. It
+// is implemented by tachoMotor.
+type tuneMotor interface {
+	speed() (int, error)
+	runDirect(duty int) error
+	setDuty(duty int) error
+	stop() error
+}
+
+func (t tachoMotor) speed() (int, error) { return t.m.Speed() }
+func (t tachoMotor) runDirect(duty int) error {
+	return t.m.SetDutyCycleSetpoint(duty).Command(ev3dev.CommandRunDirect).Err()
+}
+func (t tachoMotor) setDuty(duty int) error { return t.m.SetDutyCycleSetpoint(duty).Err() }
+
+// errNoOscillation is returned when the motor
+// does not oscillate before the test timeout.
+var errNoOscillation = errors.New("motorutil: motor did not oscillate before timeout")
+
+// Run runs the AutoTune on m and returns the proposed PID constants. The
+// constants are not written to m; call Apply on the result to write them.
+// The motor is left stopped when Run returns.
+func (a AutoTune) Run(ctx context.Context, m *ev3dev.TachoMotor) (*TuneResult, error) {
+	return a.run(ctx, tachoMotor{m}, time.Now, sleepContext)
+}
+
+func (a AutoTune) run(ctx context.Context, m tuneMotor, now func() time.Time, sleep func(context.Context, time.Duration) error) (_ *TuneResult, err error) {
+	if a.Amplitude <= 0 || 100 < abs(a.Bias)+a.Amplitude {
+		return nil, fmt.Errorf("motorutil: invalid relay: bias %d amplitude %d (duty cycle must be within [-100, 100])", a.Bias, a.Amplitude)
+	}
+	if a.Hysteresis < 0 {
+		return nil, fmt.Errorf("motorutil: invalid hysteresis: %d (must not be negative)", a.Hysteresis)
+	}
+	if a.Cycles < 1 {
+		return nil, fmt.Errorf("motorutil: invalid number of cycles: %d (must be positive)", a.Cycles)
+	}
+	if a.Sample <= 0 || a.Timeout <= a.Sample {
+		return nil, fmt.Errorf("motorutil: invalid tuning timing: sample %v with timeout %v", a.Sample, a.Timeout)
+	}
+	scale := a.Scale
+	if scale == 0 {
+		scale = 1000
+	}
+
+	high := true
+	err = m.runDirect(a.Bias + a.Amplitude)
+	if err != nil {
+		m.stop()
+		return nil, err
+	}
+	defer func() {
+		serr := m.stop()
+		if err == nil {
+			err = serr
+		}
+	}()
+
+	var (
+		start    = now()
+		rise     time.Time // Time of the last upward relay switch.
+		cycles   int       // Number of upward relay switches.
+		min, max int       // Speed extremes since the last upward switch.
+		period   time.Duration
+		height   float64 // Sum of measured peak to peak amplitudes.
+	)
+	for cycles <= a.Cycles+1 {
+		if now().Sub(start) > a.Timeout {
+			return nil, errNoOscillation
+		}
+		err = sleep(ctx, a.Sample)
+		if err != nil {
+			return nil, err
+		}
+		var s int
+		s, err = m.speed()
+		if err != nil {
+			return nil, err
+		}
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+		switch {
+		case high && s > a.Speed+a.Hysteresis:
+			high = false
+			err = m.setDuty(a.Bias - a.Amplitude)
+		case !high && s < a.Speed-a.Hysteresis:
+			high = true
+			err = m.setDuty(a.Bias + a.Amplitude)
+			t := now()
+			if cycles > 1 {
+				// The first complete cycle
+				// is discarded.
+				period += t.Sub(rise)
+				height += float64(max - min)
+			}
+			cycles++
+			rise = t
+			min, max = s, s
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	amp := height / float64(2*a.Cycles)
+	eps := float64(a.Hysteresis)
+	if amp <= eps {
+		return nil, errNoOscillation
+	}
+	tu := period / time.Duration(a.Cycles)
+	ku := 4 * float64(a.Amplitude) / (math.Pi * math.Sqrt(amp*amp-eps*eps))
+
+	// Classic Ziegler–Nichols PID rules.
+	kp := 0.6 * ku
+	ki := 1.2 * ku / tu.Seconds()
+	kd := 0.075 * ku * tu.Seconds()
+	return &TuneResult{
+		UltimateGain:   ku,
+		UltimatePeriod: tu,
+		PID: ev3dev.PID{
+			Kp: int(math.Round(kp * scale)),
+			Ki: int(math.Round(ki * scale)),
+			Kd: int(math.Round(kd * scale)),
+		},
+	}, nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,123 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeTuneMotor is a tuneMotor simulating a first order
+// system with a dead time between duty cycle and speed.
+type fakeTuneMotor struct {
+	now time.Time
+
+	gain  float64       // Steady state speed per percent duty cycle.
+	tau   time.Duration // Time constant.
+	delay time.Duration // Dead time.
+
+	duties  []float64 // Duty cycle history, one per millisecond.
+	duty    float64
+	speedV  float64
+	running bool
+}
+
+func (m *fakeTuneMotor) clock() time.Time { return m.now }
+
+func (m *fakeTuneMotor) sleep(ctx context.Context, d time.Duration) error {
+	for ; d > 0; d -= time.Millisecond {
+		m.duties = append(m.duties, m.duty)
+		var u float64
+		if lag := int(m.delay / time.Millisecond); len(m.duties) > lag {
+			u = m.duties[len(m.duties)-1-lag]
+		}
+		m.speedV += (m.gain*u - m.speedV) * time.Millisecond.Seconds() / m.tau.Seconds()
+		m.now = m.now.Add(time.Millisecond)
+	}
+	return ctx.Err()
+}
+
+func (m *fakeTuneMotor) speed() (int, error) { return int(math.Round(m.speedV)), nil }
+func (m *fakeTuneMotor) runDirect(duty int) error {
+	m.duty = float64(duty)
+	m.running = true
+	return nil
+}
+func (m *fakeTuneMotor) setDuty(duty int) error { m.duty = float64(duty); return nil }
+func (m *fakeTuneMotor) stop() error            { m.running = false; return nil }
+
+func TestAutoTune(t *testing.T) {
+	m := &fakeTuneMotor{now: time.Unix(0, 0), gain: 10, tau: 100 * time.Millisecond, delay: 30 * time.Millisecond}
+	a := AutoTune{
+		Speed:      400,
+		Bias:       40,
+		Amplitude:  20,
+		Hysteresis: 2,
+		Cycles:     5,
+		Sample:     time.Millisecond,
+		Timeout:    10 * time.Second,
+	}
+	r, err := a.run(context.Background(), m, m.clock, m.sleep)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.running {
+		t.Error("motor not stopped")
+	}
+
+	// The ultimate frequency of the simulated motor is the
+	// frequency at which its phase lag is π, and the ultimate
+	// gain is the inverse of its gain at that frequency.
+	// The relay method approximates these values.
+	lo, hi := 0.0, 1000.0
+	for i := 0; i < 100; i++ {
+		w := (lo + hi) / 2
+		if math.Atan(w*m.tau.Seconds())+w*m.delay.Seconds() < math.Pi {
+			lo = w
+		} else {
+			hi = w
+		}
+	}
+	wu := lo
+	tu := 2 * math.Pi / wu
+	ku := math.Hypot(1, wu*m.tau.Seconds()) / m.gain
+	if got := r.UltimatePeriod.Seconds(); math.Abs(got-tu)/tu > 0.25 {
+		t.Errorf("unexpected ultimate period: got:%v want:~%vs", r.UltimatePeriod, tu)
+	}
+	if math.Abs(r.UltimateGain-ku)/ku > 0.25 {
+		t.Errorf("unexpected ultimate gain: got:%v want:~%v", r.UltimateGain, ku)
+	}
+	if r.PID.Kp <= 0 || r.PID.Ki <= 0 || r.PID.Kd <= 0 {
+		t.Errorf("unexpected PID constants: %+v", r.PID)
+	}
+	if want := int(math.Round(600 * r.UltimateGain)); r.PID.Kp != want {
+		t.Errorf("unexpected proportional constant: got:%d want:%d", r.PID.Kp, want)
+	}
+
+	// The motor cannot reach the oscillation speed.
+	m = &fakeTuneMotor{now: time.Unix(0, 0), gain: 1, tau: 100 * time.Millisecond, delay: 30 * time.Millisecond}
+	_, err = a.run(context.Background(), m, m.clock, m.sleep)
+	if err != errNoOscillation {
+		t.Errorf("unexpected error: got:%v want:%v", err, errNoOscillation)
+	}
+	if m.running {
+		t.Error("motor not stopped after failure")
+	}
+
+	for _, bad := range []AutoTune{
+		{Amplitude: 0, Cycles: 1, Sample: 1, Timeout: 2},
+		{Bias: 90, Amplitude: 20, Cycles: 1, Sample: 1, Timeout: 2},
+		{Amplitude: 10, Hysteresis: -1, Cycles: 1, Sample: 1, Timeout: 2},
+		{Amplitude: 10, Cycles: 0, Sample: 1, Timeout: 2},
+		{Amplitude: 10, Cycles: 1, Sample: 2, Timeout: 2},
+	} {
+		_, err = bad.run(context.Background(), m, m.clock, m.sleep)
+		if err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
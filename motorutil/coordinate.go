@@ -0,0 +1,130 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Move is the target of one motor in a coordinated move.
+type Move struct {
+	// Motor is the motor to move.
+	Motor *ev3dev.TachoMotor
+
+	// Position is the absolute
+	// target position of Motor.
+	Position int
+}
+
+// Coordinated moves several motors to different targets so that they
+// arrive simultaneously. Each motor's speed setpoint is scaled by the
+// distance it must travel, so that the motor with the longest move runs
+// at Speed, unless that would require another motor to exceed its
+// MaxSpeed, in which case all speeds are reduced together.
+type Coordinated struct {
+	// Speed is the speed of the
+	// motor with the longest move
+	// in tacho counts per second.
+	Speed int
+
+	// Ramp is the time taken by each
+	// motor to accelerate to its speed
+	// and to decelerate to rest. The
+	// ramp setpoints of the motors are
+	// scaled so that all motors share
+	// the same ramp duration. If Ramp
+	// is zero, the ramp setpoints are
+	// left unaltered.
+	Ramp time.Duration
+}
+
+// RunTo sets the speed setpoints and position setpoints of all the motors
+// before issuing the run-to-abs-pos command to each of them. Motors that
+// are already at their target are not run. RunTo does not wait for the
+// motors to arrive; ev3dev.WaitAll may be used to wait for the move.
+func (c Coordinated) RunTo(moves ...Move) error {
+	if c.Speed <= 0 {
+		return fmt.Errorf("motorutil: invalid coordinated speed: %d (must be positive)", c.Speed)
+	}
+	if c.Ramp < 0 {
+		return fmt.Errorf("motorutil: invalid coordinated ramp: %v (must not be negative)", c.Ramp)
+	}
+	dists := make([]int, len(moves))
+	maxSpeeds := make([]int, len(moves))
+	for i, mv := range moves {
+		pos, err := mv.Motor.Position()
+		if err != nil {
+			return err
+		}
+		dists[i] = abs(mv.Position - pos)
+		maxSpeeds[i] = mv.Motor.MaxSpeed()
+	}
+	speeds := coordinatedSpeeds(dists, maxSpeeds, c.Speed)
+
+	for i, mv := range moves {
+		if speeds[i] == 0 {
+			continue
+		}
+		m := mv.Motor.SetSpeedSetpoint(speeds[i]).SetPositionSetpoint(mv.Position)
+		if c.Ramp != 0 {
+			// Ramp setpoints are the time taken
+			// to change speed by MaxSpeed.
+			sp := c.Ramp * time.Duration(maxSpeeds[i]) / time.Duration(speeds[i])
+			m.SetRampUpSetpoint(sp).SetRampDownSetpoint(sp)
+		}
+		err := m.Err()
+		if err != nil {
+			return err
+		}
+	}
+	for i, mv := range moves {
+		if speeds[i] == 0 {
+			continue
+		}
+		err := mv.Motor.Command(ev3dev.CommandRunToAbsPos).Err()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coordinatedSpeeds returns the speeds at which to travel the given
+// distances so that all travel ends at the same time. The longest
+// distance is travelled at speed unless another distance would then
+// need a speed greater than its corresponding maximum speed. Speeds
+// are rounded to the nearest integer, but are at least one for
+// non-zero distances.
+func coordinatedSpeeds(dists, maxSpeeds []int, speed int) []int {
+	// Find the shortest time
+	// in which all moves can
+	// be completed.
+	var t float64
+	for i, d := range dists {
+		if d == 0 {
+			continue
+		}
+		t = math.Max(t, float64(d)/math.Min(float64(speed), float64(maxSpeeds[i])))
+	}
+	speeds := make([]int, len(dists))
+	if t == 0 {
+		return speeds
+	}
+	for i, d := range dists {
+		if d == 0 {
+			continue
+		}
+		s := int(math.Round(float64(d) / t))
+		if s < 1 {
+			s = 1
+		}
+		speeds[i] = s
+	}
+	return speeds
+}
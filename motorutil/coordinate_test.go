@@ -0,0 +1,65 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+var coordinatedSpeedsTests = []struct {
+	dists, maxSpeeds []int
+	speed            int
+	want             []int
+}{
+	{
+		dists: []int{360, 180, 90}, maxSpeeds: []int{1050, 1050, 1050}, speed: 600,
+		want: []int{600, 300, 150},
+	},
+	{
+		dists: []int{360, 0, 720}, maxSpeeds: []int{1050, 1050, 1050}, speed: 500,
+		want: []int{250, 0, 500},
+	},
+	{
+		// The second motor limits the move.
+		dists: []int{360, 350}, maxSpeeds: []int{1050, 350}, speed: 720,
+		want: []int{360, 350},
+	},
+	{
+		// The requested speed exceeds the
+		// maximum speed of the longest move.
+		dists: []int{1000, 500}, maxSpeeds: []int{800, 1050}, speed: 1000,
+		want: []int{800, 400},
+	},
+	{
+		dists: []int{10000, 1}, maxSpeeds: []int{1050, 1050}, speed: 100,
+		want: []int{100, 1},
+	},
+	{
+		dists: []int{0, 0}, maxSpeeds: []int{1050, 1050}, speed: 100,
+		want: []int{0, 0},
+	},
+}
+
+func TestCoordinatedSpeeds(t *testing.T) {
+	for _, test := range coordinatedSpeedsTests {
+		got := coordinatedSpeeds(test.dists, test.maxSpeeds, test.speed)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected speeds for distances %v with max speeds %v at %d: got:%v want:%v",
+				test.dists, test.maxSpeeds, test.speed, got, test.want)
+		}
+	}
+}
+
+func TestCoordinatedInvalid(t *testing.T) {
+	// The moves are nil, so any operation
+	// that reached them would panic.
+	for _, c := range []Coordinated{{Speed: 0}, {Speed: -10}, {Speed: 100, Ramp: -1}} {
+		err := c.RunTo(Move{})
+		if err == nil {
+			t.Errorf("expected error for %+v", c)
+		}
+	}
+}
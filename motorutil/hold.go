@@ -0,0 +1,89 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// PositionHolder emulates the hold stop action in software for motors
+// whose drivers do not provide it. While holding, the motor's position is
+// polled and, when the shaft has been moved further than Tolerance from
+// the held position, a run-to-abs-pos command is issued to return it.
+// The motor's own stop action is used at the end of each corrective move,
+// so the shaft is free to move within the tolerance.
+type PositionHolder struct {
+	// Motor is the motor to hold.
+	Motor *ev3dev.TachoMotor
+
+	// Tolerance is the distance in
+	// tacho counts the shaft may be
+	// moved before it is corrected.
+	Tolerance int
+
+	// Speed is the speed of corrective
+	// moves in tacho counts per second.
+	Speed int
+
+	// Interval is the time between
+	// position reads.
+	Interval time.Duration
+}
+
+// holdMotor is the motor interface used by a PositionHolder.
+// It is implemented by tachoMotor.
+type holdMotor interface {
+	position() (int, error)
+	state() (ev3dev.MotorState, error)
+	runTo(pos, speed int) error
+}
+
+// Hold holds the motor at the absolute position pos until ctx is done or
+// an error occurs, returning the error. Hold is typically called after a
+// move has completed, with the position setpoint of the move. A corrective
+// move in progress when ctx is done is allowed to complete.
+func (h PositionHolder) Hold(ctx context.Context, pos int) error {
+	return h.hold(ctx, tachoMotor{h.Motor}, pos, sleepContext)
+}
+
+func (h PositionHolder) hold(ctx context.Context, m holdMotor, pos int, sleep func(context.Context, time.Duration) error) error {
+	if h.Tolerance < 0 {
+		return fmt.Errorf("motorutil: invalid hold tolerance: %d (must not be negative)", h.Tolerance)
+	}
+	if h.Speed <= 0 {
+		return fmt.Errorf("motorutil: invalid hold speed: %d (must be positive)", h.Speed)
+	}
+	if h.Interval <= 0 {
+		return fmt.Errorf("motorutil: invalid hold interval: %v (must be positive)", h.Interval)
+	}
+	for {
+		stat, err := m.state()
+		if err != nil {
+			return err
+		}
+		// Leave a corrective move
+		// to complete.
+		if stat&ev3dev.Running == 0 {
+			p, err := m.position()
+			if err != nil {
+				return err
+			}
+			if abs(p-pos) > h.Tolerance {
+				err = m.runTo(pos, h.Speed)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		err = sleep(ctx, h.Interval)
+		if err != nil {
+			return err
+		}
+	}
+}
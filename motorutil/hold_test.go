@@ -0,0 +1,89 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// fakeHoldMotor is a holdMotor whose position follows a
+// script of disturbances and whose corrective moves take
+// two position reads to complete.
+type fakeHoldMotor struct {
+	pos     int
+	target  int
+	moving  int
+	moves   []int
+	disturb map[int]int
+}
+
+func (m *fakeHoldMotor) position() (int, error) { return m.pos, nil }
+func (m *fakeHoldMotor) state() (ev3dev.MotorState, error) {
+	if m.moving == 0 {
+		return 0, nil
+	}
+	m.moving--
+	if m.moving == 0 {
+		m.pos = m.target
+	}
+	return ev3dev.Running, nil
+}
+func (m *fakeHoldMotor) runTo(pos, speed int) error {
+	m.moves = append(m.moves, pos)
+	m.target = pos
+	m.moving = 2
+	return nil
+}
+
+func TestPositionHolder(t *testing.T) {
+	m := &fakeHoldMotor{
+		pos: 100,
+		disturb: map[int]int{
+			3:  104, // Within tolerance.
+			6:  120, // Corrected.
+			12: 80,  // Corrected.
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var n int
+	sleep := func(ctx context.Context, d time.Duration) error {
+		n++
+		if p, ok := m.disturb[n]; ok {
+			m.pos = p
+		}
+		if n == 20 {
+			cancel()
+		}
+		return ctx.Err()
+	}
+	h := PositionHolder{Tolerance: 5, Speed: 200, Interval: 10 * time.Millisecond}
+	err := h.hold(ctx, m, 100, sleep)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.Canceled)
+	}
+	if want := []int{100, 100}; !reflect.DeepEqual(m.moves, want) {
+		t.Errorf("unexpected corrective moves: got:%v want:%v", m.moves, want)
+	}
+	if m.pos != 100 {
+		t.Errorf("unexpected final position: got:%d want:100", m.pos)
+	}
+
+	for _, h := range []PositionHolder{
+		{Tolerance: -1, Speed: 200, Interval: time.Millisecond},
+		{Tolerance: 5, Speed: 0, Interval: time.Millisecond},
+		{Tolerance: 5, Speed: 200, Interval: 0},
+	} {
+		err := h.hold(context.Background(), m, 0, sleepContext)
+		if err == nil {
+			t.Errorf("expected error for %+v", h)
+		}
+	}
+}
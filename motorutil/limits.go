@@ -0,0 +1,99 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"math"
+	"time"
+)
+
+// MotionLimits specifies limits on the motion of a Steering. A single
+// MotionLimits value may be shared between several Steering values and
+// swapped at run time to change how aggressively a robot moves, for
+// example between practice and competition profiles.
+//
+// The ev3dev tacho-motor driver only provides linear speed ramps, so
+// acceleration is limited by ramp durations unless Jerk is set. When Jerk
+// is set, timed steering steps the motors' speed setpoints through an
+// S-curve profile in software.
+type MotionLimits struct {
+	// MaxSpeed is the maximum absolute speed
+	// in tacho counts per second. Requested
+	// speeds with a greater magnitude are
+	// reduced to MaxSpeed. If MaxSpeed is
+	// zero, speeds are not limited.
+	MaxSpeed int
+
+	// RampUp and RampDown are the ramp up
+	// and ramp down setpoints applied to
+	// both motors before each command.
+	//
+	// See the ev3dev.SetRampUpSetpoint and
+	// ev3dev.SetRampDownSetpoint documentation
+	// for ramp behaviour.
+	RampUp, RampDown time.Duration
+
+	// Jerk is the maximum rate of change of
+	// acceleration in tacho counts per second
+	// cubed. If Jerk is non-zero, SteerDuration
+	// blocks while it accelerates and
+	// decelerates the motors along an S-curve
+	// speed profile, and RampUp and RampDown
+	// are not used. SteerCounts does not
+	// support jerk-limited motion and fails
+	// if Jerk is non-zero.
+	Jerk int
+
+	// Accel is the maximum acceleration in
+	// tacho counts per second squared used
+	// with Jerk. If Accel is zero, acceleration
+	// is limited only by Jerk.
+	Accel int
+}
+
+// profileInterval is the interval between speed
+// setpoint updates of a jerk-limited profile.
+const profileInterval = 20 * time.Millisecond
+
+// sCurve returns the speed setpoints at each interval dt for a jerk-limited
+// acceleration from zero to speed, ending with speed. Acceleration increases
+// at the jerk limit to at most accel, holds, and then decreases at the jerk
+// limit so that speed is reached with zero acceleration. If accel is zero,
+// acceleration is not limited.
+func sCurve(speed, jerk, accel int, dt time.Duration) []int {
+	v := math.Abs(float64(speed))
+	if v == 0 || jerk <= 0 || dt <= 0 {
+		return []int{speed}
+	}
+	r := newRamp(v, float64(jerk), float64(accel))
+	total := r.duration()
+
+	sign := 1.0
+	if speed < 0 {
+		sign = -1
+	}
+	step := dt.Seconds()
+	var profile []int
+	for t := step; t < total; t += step {
+		_, sp := r.at(t)
+		profile = append(profile, int(math.Round(sign*sp)))
+	}
+	return append(profile, speed)
+}
+
+// limit returns speed limited to the maximum speed of l.
+// If l is nil, speed is returned unaltered.
+func (l *MotionLimits) limit(speed int) int {
+	if l == nil || l.MaxSpeed == 0 {
+		return speed
+	}
+	switch {
+	case speed > l.MaxSpeed:
+		return l.MaxSpeed
+	case speed < -l.MaxSpeed:
+		return -l.MaxSpeed
+	}
+	return speed
+}
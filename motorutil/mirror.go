@@ -0,0 +1,150 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import "github.com/ev3go/ev3dev"
+
+// MirroredPair is a pair of tacho motors mounted in opposition, for example
+// on either side of a lifting arm, that must turn in lockstep. Setpoints
+// given to a MirroredPair are applied to Primary as given and to Mirror
+// negated, so that both motors drive the mechanism in the same direction.
+// Alternatively, Invert sets the polarity of Mirror opposite to that of
+// Primary so that setpoints are applied to both motors as given.
+//
+// Errors occurring during MirroredPair operations are sticky. They are
+// returned by a call to Err.
+type MirroredPair struct {
+	// Primary and Mirror are the motors
+	// of the pair. Setpoints are given
+	// in the sense of Primary.
+	Primary, Mirror *ev3dev.TachoMotor
+
+	inverted bool
+
+	err error
+}
+
+// Invert sets the polarity of Mirror to the opposite of the polarity of
+// Primary. After a successful call to Invert, setpoints are applied to
+// both motors as given.
+func (p *MirroredPair) Invert() *MirroredPair {
+	if p.err != nil {
+		return p
+	}
+	pol, err := p.Primary.Polarity()
+	if err != nil {
+		p.err = err
+		return p
+	}
+	switch pol {
+	case ev3dev.Normal:
+		pol = ev3dev.Inversed
+	case ev3dev.Inversed:
+		pol = ev3dev.Normal
+	}
+	p.err = p.Mirror.SetPolarity(pol).Err()
+	if p.err == nil {
+		p.inverted = true
+	}
+	return p
+}
+
+// mirror returns the setpoint v for the Mirror motor.
+func (p *MirroredPair) mirror(v int) int {
+	if p.inverted {
+		return v
+	}
+	return -v
+}
+
+// SetSpeed sets the speed setpoints of the motors to speed in the sense of
+// Primary. The new setpoints take effect with the next run command.
+func (p *MirroredPair) SetSpeed(speed int) *MirroredPair {
+	if p.err != nil {
+		return p
+	}
+	p.err = p.Primary.SetSpeedSetpoint(speed).Err()
+	if p.err != nil {
+		return p
+	}
+	p.err = p.Mirror.SetSpeedSetpoint(p.mirror(speed)).Err()
+	return p
+}
+
+// Forward runs the motors forward in the sense of Primary at the
+// magnitude of speed until another command is issued.
+func (p *MirroredPair) Forward(speed int) *MirroredPair {
+	if speed < 0 {
+		speed = -speed
+	}
+	return p.SetSpeed(speed).Command(ev3dev.CommandRunForever)
+}
+
+// Reverse runs the motors in reverse in the sense of Primary at the
+// magnitude of speed until another command is issued.
+func (p *MirroredPair) Reverse(speed int) *MirroredPair {
+	if speed > 0 {
+		speed = -speed
+	}
+	return p.SetSpeed(speed).Command(ev3dev.CommandRunForever)
+}
+
+// RunCounts runs the motors by counts tacho counts relative to their
+// current positions in the sense of Primary, at the magnitude of speed.
+func (p *MirroredPair) RunCounts(speed, counts int) *MirroredPair {
+	if p.err != nil {
+		return p
+	}
+	if speed < 0 {
+		speed = -speed
+	}
+	p.err = p.Primary.SetSpeedSetpoint(speed).SetPositionSetpoint(counts).Err()
+	if p.err != nil {
+		return p
+	}
+	p.err = p.Mirror.SetSpeedSetpoint(speed).SetPositionSetpoint(p.mirror(counts)).Err()
+	if p.err != nil {
+		return p
+	}
+	return p.Command(ev3dev.CommandRunToRelPos)
+}
+
+// Stop stops both motors using their stop actions.
+func (p *MirroredPair) Stop() *MirroredPair {
+	return p.Command(ev3dev.CommandStop)
+}
+
+// Command issues comm to both motors. If the command cannot be issued to
+// Mirror, Primary is stopped. A stop command is always issued to both
+// motors and the first error encountered is retained.
+func (p *MirroredPair) Command(comm ev3dev.MotorCommand) *MirroredPair {
+	if p.err != nil {
+		return p
+	}
+	if comm == ev3dev.CommandStop {
+		p.err = p.Primary.Command(comm).Err()
+		err := p.Mirror.Command(comm).Err()
+		if p.err == nil {
+			p.err = err
+		}
+		return p
+	}
+	p.err = p.Primary.Command(comm).Err()
+	if p.err != nil {
+		return p
+	}
+	p.err = p.Mirror.Command(comm).Err()
+	if p.err != nil {
+		p.Primary.Command(ev3dev.CommandStop).Err()
+	}
+	return p
+}
+
+// Err returns the error state of the MirroredPair and clears it.
+func (p *MirroredPair) Err() error {
+	err := p.err
+	p.err = nil
+	return err
+}
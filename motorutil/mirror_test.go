@@ -0,0 +1,39 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMirroredPairMirror(t *testing.T) {
+	var p MirroredPair
+	for _, v := range []int{-500, 0, 360} {
+		if got := p.mirror(v); got != -v {
+			t.Errorf("unexpected mirrored setpoint for %d: got:%d want:%d", v, got, -v)
+		}
+	}
+	p.inverted = true
+	for _, v := range []int{-500, 0, 360} {
+		if got := p.mirror(v); got != v {
+			t.Errorf("unexpected setpoint for inverted %d: got:%d want:%d", v, got, v)
+		}
+	}
+}
+
+func TestMirroredPairStickyError(t *testing.T) {
+	errTest := errors.New("test error")
+	// The motors are nil, so any operation that
+	// reached them would panic.
+	p := MirroredPair{err: errTest}
+	err := p.Invert().Forward(100).Reverse(100).RunCounts(100, 360).Stop().Err()
+	if err != errTest {
+		t.Errorf("unexpected error: got:%v want:%v", err, errTest)
+	}
+	if err := p.Err(); err != nil {
+		t.Errorf("error not cleared: %v", err)
+	}
+}
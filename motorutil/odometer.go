@@ -0,0 +1,186 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Odometer accumulates the total signed travel of a motor in tacho counts.
+// The position of the motor is sampled by each call to Update and the
+// Total methods, and the change in position since the previous sample is
+// added to the total. Resets and position writes made through the Odometer
+// do not change the total, so the total is the travel of the motor since
+// the Odometer was created.
+//
+// Resets and position writes made other than through the Odometer are
+// seen as travel. Travel made between the last sample and a reset or
+// position write made through the Odometer is counted.
+//
+// An Odometer is safe for concurrent use.
+type Odometer struct {
+	dev odometerMotor
+
+	// tacho and linear hold the motor for
+	// unit conversions. Only one is set.
+	tacho  *ev3dev.TachoMotor
+	linear *ev3dev.LinearActuator
+
+	mu    sync.Mutex
+	last  int
+	total int
+}
+
+// odometerMotor is the motor interface used by an Odometer. It
+// is implemented by tachoMotor and linearActuator.
+type odometerMotor interface {
+	position() (int, error)
+	setPosition(pos int) error
+	reset() error
+}
+
+func (t tachoMotor) setPosition(pos int) error { return t.m.SetPosition(pos).Err() }
+func (t tachoMotor) reset() error              { return t.m.Command(ev3dev.CommandReset).Err() }
+
+// linearActuator adapts an *ev3dev.LinearActuator
+// to the odometerMotor interface.
+type linearActuator struct {
+	m *ev3dev.LinearActuator
+}
+
+func (l linearActuator) position() (int, error)    { return l.m.Position() }
+func (l linearActuator) setPosition(pos int) error { return l.m.SetPosition(pos).Err() }
+func (l linearActuator) reset() error              { return l.m.Command(ev3dev.CommandReset).Err() }
+
+// NewOdometer returns an Odometer for the tacho motor m with a total of
+// zero, starting from the current position of m.
+func NewOdometer(m *ev3dev.TachoMotor) (*Odometer, error) {
+	o, err := newOdometer(tachoMotor{m})
+	if err != nil {
+		return nil, err
+	}
+	o.tacho = m
+	return o, nil
+}
+
+// NewLinearOdometer returns an Odometer for the linear actuator m with a
+// total of zero, starting from the current position of m.
+func NewLinearOdometer(m *ev3dev.LinearActuator) (*Odometer, error) {
+	o, err := newOdometer(linearActuator{m})
+	if err != nil {
+		return nil, err
+	}
+	o.linear = m
+	return o, nil
+}
+
+func newOdometer(m odometerMotor) (*Odometer, error) {
+	pos, err := m.position()
+	if err != nil {
+		return nil, err
+	}
+	return &Odometer{dev: m, last: pos}, nil
+}
+
+// Update samples the position of the motor, adding the
+// travel since the previous sample to the total.
+func (o *Odometer) Update() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.update()
+}
+
+func (o *Odometer) update() error {
+	pos, err := o.dev.position()
+	if err != nil {
+		return err
+	}
+	o.total += pos - o.last
+	o.last = pos
+	return nil
+}
+
+// Reset samples the position of the motor and then sends it the reset
+// command, which stops the motor and sets its position to zero.
+func (o *Odometer) Reset() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	err := o.update()
+	if err != nil {
+		return err
+	}
+	err = o.dev.reset()
+	if err != nil {
+		return err
+	}
+	// Read back the position rather than assuming
+	// zero since the motor may still be coasting.
+	pos, err := o.dev.position()
+	if err != nil {
+		return err
+	}
+	o.last = pos
+	return nil
+}
+
+// SetPosition samples the position of the motor and then sets
+// its position to pos.
+func (o *Odometer) SetPosition(pos int) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	err := o.update()
+	if err != nil {
+		return err
+	}
+	err = o.dev.setPosition(pos)
+	if err != nil {
+		return err
+	}
+	o.last = pos
+	return nil
+}
+
+// TotalCounts samples the position of the motor and returns
+// the total travel in tacho counts.
+func (o *Odometer) TotalCounts() (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	err := o.update()
+	return o.total, err
+}
+
+// TotalDegrees samples the position of the motor and returns the total
+// travel of the motor's output shaft in degrees, taking into account the
+// motor's gear ratio. It returns an error if the Odometer was not created
+// by NewOdometer.
+func (o *Odometer) TotalDegrees() (float64, error) {
+	if o.tacho == nil {
+		return 0, fmt.Errorf("motorutil: degrees not available for linear actuator odometer")
+	}
+	perRot := o.tacho.CountPerRot()
+	if perRot <= 0 {
+		return 0, fmt.Errorf("motorutil: no count per rotation for %s", o.tacho)
+	}
+	counts, err := o.TotalCounts()
+	return float64(counts) * 360 / (float64(perRot) * o.tacho.GearRatio()), err
+}
+
+// TotalMeters samples the position of the actuator and returns the total
+// travel in metres. It returns an error if the Odometer was not created by
+// NewLinearOdometer.
+func (o *Odometer) TotalMeters() (float64, error) {
+	if o.linear == nil {
+		return 0, fmt.Errorf("motorutil: metres not available for tacho motor odometer")
+	}
+	perMeter := o.linear.CountPerMeter()
+	if perMeter <= 0 {
+		return 0, fmt.Errorf("motorutil: no count per meter for %s", o.linear)
+	}
+	counts, err := o.TotalCounts()
+	return float64(counts) / float64(perMeter), err
+}
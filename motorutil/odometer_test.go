@@ -0,0 +1,79 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeOdometerMotor is an odometerMotor whose
+// position is set directly by the test.
+type fakeOdometerMotor struct {
+	pos int
+	err error
+}
+
+func (m *fakeOdometerMotor) position() (int, error)    { return m.pos, m.err }
+func (m *fakeOdometerMotor) setPosition(pos int) error { m.pos = pos; return m.err }
+func (m *fakeOdometerMotor) reset() error              { m.pos = 0; return m.err }
+
+func TestOdometer(t *testing.T) {
+	m := &fakeOdometerMotor{pos: 100}
+	o, err := newOdometer(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	check := func(want int) {
+		t.Helper()
+		got, err := o.TotalCounts()
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("unexpected total: got:%d want:%d", got, want)
+		}
+	}
+
+	check(0)
+	m.pos = 460
+	check(360)
+	m.pos = 400
+	err = o.Update()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	m.pos = 500
+	err = o.Reset()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	check(400)
+	m.pos = -100
+	err = o.SetPosition(1000)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	check(300)
+	m.pos = 1050
+	check(350)
+
+	errTest := errors.New("test error")
+	m.err = errTest
+	if err := o.Reset(); err != errTest {
+		t.Errorf("unexpected error: got:%v want:%v", err, errTest)
+	}
+	m.pos, m.err = 1100, nil
+	check(400)
+
+	_, err = o.TotalDegrees()
+	if err == nil {
+		t.Error("expected error for degrees without tacho motor")
+	}
+	_, err = o.TotalMeters()
+	if err == nil {
+		t.Error("expected error for metres without linear actuator")
+	}
+}
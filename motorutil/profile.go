@@ -0,0 +1,251 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Trapezoid is a trapezoidal velocity profile. A motor following the
+// profile accelerates at Accel to MaxSpeed, cruises and then decelerates
+// at Decel to stop after moving Distance. If Distance is too short to reach
+// MaxSpeed, the profile is triangular with a lower peak speed.
+//
+// Distances are in tacho counts, speeds in tacho counts per second and
+// accelerations in tacho counts per second per second.
+type Trapezoid struct {
+	// Distance is the signed distance
+	// moved by the profile.
+	Distance float64
+
+	// MaxSpeed is the cruise speed
+	// of the profile. It must be
+	// positive.
+	MaxSpeed float64
+
+	// Accel and Decel are the magnitudes
+	// of the acceleration and deceleration.
+	// Accel must be positive. If Decel is
+	// zero, Accel is used.
+	Accel, Decel float64
+}
+
+// validate returns an error if the profile's parameters are invalid.
+func (p Trapezoid) validate() error {
+	for _, v := range []float64{p.Distance, p.MaxSpeed, p.Accel, p.Decel} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("motorutil: invalid profile: %+v (values must be finite)", p)
+		}
+	}
+	if p.MaxSpeed <= 0 || p.Accel <= 0 || p.Decel < 0 {
+		return fmt.Errorf("motorutil: invalid profile: %+v (speed and acceleration must be positive)", p)
+	}
+	return nil
+}
+
+// phases returns the durations of the acceleration, cruise and
+// deceleration phases of the profile, in seconds, and its peak speed.
+func (p Trapezoid) phases() (accel, cruise, decel, peak float64) {
+	a, d := p.Accel, p.Decel
+	if d == 0 {
+		d = a
+	}
+	dist := math.Abs(p.Distance)
+	peak = p.MaxSpeed
+	if ramps := peak*peak/(2*a) + peak*peak/(2*d); ramps > dist {
+		// The profile is triangular.
+		peak = math.Sqrt(2 * dist * a * d / (a + d))
+	} else {
+		cruise = (dist - ramps) / peak
+	}
+	return peak / a, cruise, peak / d, peak
+}
+
+// Duration returns the time taken to follow the profile. It returns zero
+// if the profile is invalid.
+func (p Trapezoid) Duration() time.Duration {
+	if p.validate() != nil {
+		return 0
+	}
+	accel, cruise, decel, _ := p.phases()
+	return seconds(accel + cruise + decel)
+}
+
+// At returns the signed position and speed of the profile at time t after
+// its start. Before the start, the position and speed are zero and after
+// the end the position is Distance and the speed is zero. At returns zero
+// values if the profile is invalid.
+func (p Trapezoid) At(t time.Duration) (pos, speed float64) {
+	if p.validate() != nil || t <= 0 {
+		return 0, 0
+	}
+	accel, cruise, decel, peak := p.phases()
+	a := peak / accel
+	d := peak / decel
+	s := t.Seconds()
+	switch {
+	case s < accel:
+		pos, speed = a*s*s/2, a*s
+	case s < accel+cruise:
+		pos, speed = peak*accel/2+peak*(s-accel), peak
+	case s < accel+cruise+decel:
+		r := accel + cruise + decel - s
+		pos, speed = math.Abs(p.Distance)-d*r*r/2, d*r
+	default:
+		pos = math.Abs(p.Distance)
+	}
+	if p.Distance < 0 {
+		pos, speed = -pos, -speed
+	}
+	return pos, speed
+}
+
+// seconds returns s seconds as a time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
+
+// ProfileMode specifies how a profile is streamed to a motor.
+type ProfileMode int
+
+const (
+	// StreamSpeed streams speed setpoints
+	// to the motor running with the
+	// run-forever command. The motor is
+	// stopped using its stop action at the
+	// end of the profile.
+	StreamSpeed ProfileMode = iota
+
+	// StreamPosition streams position
+	// setpoints to the motor running with
+	// the run-to-abs-pos command. At the end
+	// of the profile the motor is left
+	// running to the final position.
+	StreamPosition
+)
+
+// Profile is a motion profile that can be followed by a motor. Distances
+// are in tacho counts and speeds in tacho counts per second.
+type Profile interface {
+	// Duration returns the time
+	// taken to follow the profile.
+	Duration() time.Duration
+
+	// At returns the signed position
+	// and speed of the profile at time
+	// t after its start.
+	At(t time.Duration) (pos, speed float64)
+}
+
+// profileMotor is the motor interface used by Follow. It is
+// implemented by tachoMotor.
+type profileMotor interface {
+	position() (int, error)
+	runSpeed(speed int) error
+	runTo(pos, speed int) error
+	stop() error
+}
+
+func (t tachoMotor) runSpeed(speed int) error {
+	return t.m.SetSpeedSetpoint(speed).Command(ev3dev.CommandRunForever).Err()
+}
+func (t tachoMotor) runTo(pos, speed int) error {
+	return t.m.SetSpeedSetpoint(speed).SetPositionSetpoint(pos).Command(ev3dev.CommandRunToAbsPos).Err()
+}
+
+// Follow streams the profile p, for example a Trapezoid or an SCurve, to
+// m as setpoints every tick, relative to the position of m when Follow is
+// called. Each setpoint is the state of the profile at the end of the
+// current tick and the speed is the average speed of the profile over the
+// tick. Ticks that are missed because writing setpoints took longer than
+// tick are skipped.
+//
+// Follow returns when the profile is complete or ctx is done. If ctx is
+// done before the profile is complete, the motor is stopped and the
+// context's error is returned.
+func Follow(ctx context.Context, m *ev3dev.TachoMotor, p Profile, tick time.Duration, mode ProfileMode) error {
+	return follow(ctx, tachoMotor{m}, p, tick, mode, time.Now, sleepContext)
+}
+
+func follow(ctx context.Context, m profileMotor, p Profile, tick time.Duration, mode ProfileMode, now func() time.Time, sleep func(context.Context, time.Duration) error) error {
+	var err error
+	if v, ok := p.(interface{ validate() error }); ok {
+		err = v.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if tick <= 0 {
+		return fmt.Errorf("motorutil: invalid profile tick: %v (must be positive)", tick)
+	}
+	if mode != StreamSpeed && mode != StreamPosition {
+		return fmt.Errorf("motorutil: invalid profile mode: %d", mode)
+	}
+	origin, err := m.position()
+	if err != nil {
+		return err
+	}
+	end := p.Duration()
+	start := now()
+	for n := time.Duration(1); ; n++ {
+		elapsed := now().Sub(start)
+		if elapsed >= end {
+			break
+		}
+		// Skip ticks that have already passed.
+		if next := n * tick; next <= elapsed {
+			n = elapsed/tick + 1
+		}
+		from, _ := p.At(elapsed)
+		to, _ := p.At(n * tick)
+		speed := int(math.Round((to - from) / (n*tick - elapsed).Seconds()))
+		switch mode {
+		case StreamSpeed:
+			err = m.runSpeed(speed)
+		case StreamPosition:
+			if speed < 0 {
+				speed = -speed
+			}
+			if speed == 0 {
+				speed = 1
+			}
+			err = m.runTo(origin+int(math.Round(to)), speed)
+		}
+		if err != nil {
+			m.stop()
+			return err
+		}
+		err = sleep(ctx, start.Add(n*tick).Sub(now()))
+		if err != nil {
+			m.stop()
+			return err
+		}
+	}
+	if mode == StreamSpeed {
+		return m.stop()
+	}
+	return nil
+}
+
+// sleepContext sleeps for d, returning the context's error if ctx is
+// done before d has elapsed.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+var trapezoidTests = []struct {
+	name string
+	p    Trapezoid
+	dur  time.Duration
+	at   []time.Duration
+	pos  []float64
+	spd  []float64
+}{
+	{
+		name: "trapezoid",
+		p:    Trapezoid{Distance: 1000, MaxSpeed: 500, Accel: 1000},
+		dur:  2500 * time.Millisecond,
+		at:   []time.Duration{-time.Second, 250 * time.Millisecond, time.Second, 2250 * time.Millisecond, 3 * time.Second},
+		pos:  []float64{0, 31.25, 375, 968.75, 1000},
+		spd:  []float64{0, 250, 500, 250, 0},
+	},
+	{
+		name: "reverse asymmetric",
+		p:    Trapezoid{Distance: -1000, MaxSpeed: 500, Accel: 1000, Decel: 500},
+		dur:  2750 * time.Millisecond,
+		at:   []time.Duration{250 * time.Millisecond, time.Second, 2250 * time.Millisecond},
+		pos:  []float64{-31.25, -375, -937.5},
+		spd:  []float64{-250, -500, -250},
+	},
+	{
+		name: "triangle",
+		p:    Trapezoid{Distance: 100, MaxSpeed: 500, Accel: 1000},
+		dur:  seconds(2 * math.Sqrt(0.1)),
+		at:   []time.Duration{seconds(math.Sqrt(0.1))},
+		pos:  []float64{50},
+		spd:  []float64{1000 * math.Sqrt(0.1)},
+	},
+	{
+		name: "invalid",
+		p:    Trapezoid{Distance: 100, MaxSpeed: 0, Accel: 1000},
+		at:   []time.Duration{time.Second},
+		pos:  []float64{0},
+		spd:  []float64{0},
+	},
+}
+
+func TestTrapezoid(t *testing.T) {
+	const tol = 1e-6
+	for _, test := range trapezoidTests {
+		if got := test.p.Duration(); got != test.dur {
+			t.Errorf("unexpected duration for %s: got:%v want:%v", test.name, got, test.dur)
+		}
+		for i, at := range test.at {
+			pos, spd := test.p.At(at)
+			if math.Abs(pos-test.pos[i]) > tol || math.Abs(spd-test.spd[i]) > tol {
+				t.Errorf("unexpected state for %s at %v: got:(%v, %v) want:(%v, %v)",
+					test.name, at, pos, spd, test.pos[i], test.spd[i])
+			}
+		}
+	}
+}
+
+// fakeProfileMotor is a simulated motor that follows speed commands
+// exactly, moves to position commands within a tick and records the
+// commands it receives. Each command advances its clock by cost.
+type fakeProfileMotor struct {
+	now     time.Time
+	cost    time.Duration
+	pos     float64
+	speed   float64
+	target  int
+	stopped bool
+
+	commands int
+}
+
+func (m *fakeProfileMotor) clock() time.Time { return m.now }
+
+func (m *fakeProfileMotor) sleep(ctx context.Context, d time.Duration) error {
+	if d > 0 {
+		m.advance(d)
+	}
+	return ctx.Err()
+}
+
+func (m *fakeProfileMotor) advance(d time.Duration) {
+	m.pos += m.speed * d.Seconds()
+	m.now = m.now.Add(d)
+}
+
+func (m *fakeProfileMotor) position() (int, error) { return int(math.Round(m.pos)), nil }
+
+func (m *fakeProfileMotor) runSpeed(speed int) error {
+	m.advance(m.cost)
+	m.speed = float64(speed)
+	m.commands++
+	return nil
+}
+
+func (m *fakeProfileMotor) runTo(pos, speed int) error {
+	m.advance(m.cost)
+	m.target = pos
+	m.commands++
+	return nil
+}
+
+func (m *fakeProfileMotor) stop() error {
+	m.speed = 0
+	m.stopped = true
+	return nil
+}
+
+func TestFollow(t *testing.T) {
+	p := Trapezoid{Distance: 1000, MaxSpeed: 500, Accel: 1000}
+	const tick = 10 * time.Millisecond
+
+	for _, cost := range []time.Duration{0, 3 * time.Millisecond, 25 * time.Millisecond} {
+		m := &fakeProfileMotor{now: time.Unix(0, 0), pos: 100, cost: cost}
+		err := follow(context.Background(), m, p, tick, StreamSpeed, m.clock, m.sleep)
+		if err != nil {
+			t.Fatalf("unexpected error for cost %v: %v", cost, err)
+		}
+		if !m.stopped {
+			t.Errorf("motor not stopped at end of profile for cost %v", cost)
+		}
+		// Speeds are rounded to whole counts per second.
+		if math.Abs(m.pos-1100) > 2 {
+			t.Errorf("unexpected final position for cost %v: got:%v want:1100", cost, m.pos)
+		}
+		elapsed := m.now.Sub(time.Unix(0, 0))
+		if elapsed < p.Duration() || elapsed > p.Duration()+tick+cost {
+			t.Errorf("unexpected profile time for cost %v: got:%v want:%v", cost, elapsed, p.Duration())
+		}
+		if want := int(p.Duration() / tick); cost < tick && m.commands != want {
+			t.Errorf("unexpected number of commands for cost %v: got:%d want:%d", cost, m.commands, want)
+		}
+		if cost > tick && m.commands >= int(p.Duration()/tick) {
+			t.Errorf("expected missed ticks to be skipped for cost %v: %d commands", cost, m.commands)
+		}
+	}
+
+	m := &fakeProfileMotor{now: time.Unix(0, 0), pos: 100}
+	err := follow(context.Background(), m, p, tick, StreamPosition, m.clock, m.sleep)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.target != 1100 {
+		t.Errorf("unexpected final position setpoint: got:%d want:1100", m.target)
+	}
+	if m.stopped {
+		t.Error("unexpected stop in position mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m = &fakeProfileMotor{now: time.Unix(0, 0)}
+	err = follow(ctx, m, p, tick, StreamSpeed, m.clock, m.sleep)
+	if err != context.Canceled {
+		t.Errorf("unexpected error for cancelled context: got:%v want:%v", err, context.Canceled)
+	}
+	if !m.stopped {
+		t.Error("motor not stopped after cancellation")
+	}
+
+	for _, bad := range []struct {
+		p    Trapezoid
+		tick time.Duration
+		mode ProfileMode
+	}{
+		{p: Trapezoid{Distance: 1, MaxSpeed: 1, Accel: math.Inf(1)}, tick: tick},
+		{p: p, tick: 0},
+		{p: p, tick: tick, mode: -1},
+	} {
+		m := &fakeProfileMotor{now: time.Unix(0, 0)}
+		err := follow(context.Background(), m, bad.p, bad.tick, bad.mode, m.clock, m.sleep)
+		if err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+		if m.commands != 0 {
+			t.Errorf("unexpected commands for invalid parameters %+v", bad)
+		}
+	}
+}
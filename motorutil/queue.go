@@ -0,0 +1,276 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Action is a step of a CommandQueue.
+type Action struct {
+	kind  actionKind
+	pos   int
+	speed int
+	d     time.Duration
+	done  func(error)
+}
+
+type actionKind int
+
+const (
+	runToAction actionKind = iota
+	runTimedAction
+	pauseAction
+	stopAction
+)
+
+// RunTo returns an Action that runs the motor to the absolute position pos
+// at the magnitude of speed and waits until the motor is no longer running.
+func RunTo(pos, speed int) Action {
+	return Action{kind: runToAction, pos: pos, speed: speed}
+}
+
+// RunTimed returns an Action that runs the motor at speed for the duration
+// d and waits until the motor is no longer running.
+func RunTimed(d time.Duration, speed int) Action {
+	return Action{kind: runTimedAction, speed: speed, d: d}
+}
+
+// Pause returns an Action that waits for the duration d.
+func Pause(d time.Duration) Action {
+	return Action{kind: pauseAction, d: d}
+}
+
+// Stop returns an Action that stops the motor using its stop action.
+func Stop() Action {
+	return Action{kind: stopAction}
+}
+
+// Then returns a copy of the Action that calls fn with the result of the
+// action when it completes. If the action is abandoned because an earlier
+// action failed or the queue was cancelled, fn is called with the error
+// that ended the queue.
+func (a Action) Then(fn func(error)) Action {
+	a.done = fn
+	return a
+}
+
+// queuePoll is the interval between motor state reads
+// made while waiting for an action to complete.
+const queuePoll = 10 * time.Millisecond
+
+// errStalled is returned when a motor stalls
+// during a CommandQueue action.
+var errStalled = errors.New("motorutil: motor stalled")
+
+// CommandQueue executes a sequence of actions on a motor in order on a
+// background goroutine. Actions added with Enqueue are executed after
+// those already queued. Completion callbacks set with Action.Then are
+// called on the queue's goroutine.
+//
+// If an action fails, for example because the motor stalls, the motor is
+// stopped and the queue ends with the action's error. The queue also ends
+// when the context it was created with is done or Cancel is called, in
+// which case a running action is interrupted and the motor is stopped.
+// Actions that have not completed when the queue ends are abandoned.
+type CommandQueue struct {
+	m      queueMotor
+	sleep  func(context.Context, time.Duration) error
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	idle    *sync.Cond
+	pending []Action
+	busy    bool
+	err     error
+
+	wake chan struct{}
+	done chan struct{}
+}
+
+// queueMotor is the motor interface used by a CommandQueue.
+// It is implemented by tachoMotor.
+type queueMotor interface {
+	runTo(pos, speed int) error
+	runTimed(d time.Duration, speed int) error
+	stop() error
+	state() (ev3dev.MotorState, error)
+}
+
+func (t tachoMotor) runTimed(d time.Duration, speed int) error {
+	return t.m.SetTimeSetpoint(d).SetSpeedSetpoint(speed).Command(ev3dev.CommandRunTimed).Err()
+}
+
+// NewCommandQueue returns a new CommandQueue for m. The queue's goroutine
+// runs until ctx is done or Cancel is called.
+func NewCommandQueue(ctx context.Context, m *ev3dev.TachoMotor) *CommandQueue {
+	return newCommandQueue(ctx, tachoMotor{m}, sleepContext)
+}
+
+func newCommandQueue(ctx context.Context, m queueMotor, sleep func(context.Context, time.Duration) error) *CommandQueue {
+	ctx, cancel := context.WithCancel(ctx)
+	q := &CommandQueue{
+		m:      m,
+		sleep:  sleep,
+		ctx:    ctx,
+		cancel: cancel,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	q.idle = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+// Enqueue adds actions to the end of the queue. Enqueue returns the error
+// that ended the queue if it has ended.
+func (q *CommandQueue) Enqueue(actions ...Action) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err != nil {
+		return q.err
+	}
+	q.pending = append(q.pending, actions...)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Wait waits until all queued actions have completed or the queue has
+// ended, and returns the error that ended the queue, if any.
+func (q *CommandQueue) Wait() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.busy || len(q.pending) != 0 {
+		q.idle.Wait()
+	}
+	return q.err
+}
+
+// Cancel ends the queue, interrupting any running action, and waits
+// for the queue's goroutine to return.
+func (q *CommandQueue) Cancel() {
+	q.cancel()
+	<-q.done
+}
+
+func (q *CommandQueue) run() {
+	defer close(q.done)
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			q.busy = false
+			q.idle.Broadcast()
+			q.mu.Unlock()
+			select {
+			case <-q.wake:
+				continue
+			case <-q.ctx.Done():
+				q.end(q.ctx.Err())
+				return
+			}
+		}
+		a := q.pending[0]
+		q.pending = q.pending[1:]
+		q.busy = true
+		q.mu.Unlock()
+
+		err := q.do(a)
+		if err == nil {
+			// Do not start another action
+			// once the queue is cancelled.
+			err = q.ctx.Err()
+		}
+		if err != nil {
+			q.m.stop()
+		}
+		if a.done != nil {
+			a.done(err)
+		}
+		if err != nil {
+			q.end(err)
+			return
+		}
+	}
+}
+
+// do performs the action a.
+func (q *CommandQueue) do(a Action) error {
+	switch a.kind {
+	case runToAction:
+		speed := a.speed
+		if speed < 0 {
+			speed = -speed
+		}
+		err := q.m.runTo(a.pos, speed)
+		if err != nil {
+			return err
+		}
+		return q.waitStopped()
+	case runTimedAction:
+		err := q.m.runTimed(a.d, a.speed)
+		if err != nil {
+			return err
+		}
+		return q.waitStopped()
+	case pauseAction:
+		return q.sleep(q.ctx, a.d)
+	case stopAction:
+		return q.m.stop()
+	default:
+		panic("motorutil: invalid action")
+	}
+}
+
+// waitStopped polls the motor state until the motor is
+// no longer running, returning an error if it stalls.
+func (q *CommandQueue) waitStopped() error {
+	for {
+		stat, err := q.m.state()
+		if err != nil {
+			return err
+		}
+		if stat&ev3dev.Stalled != 0 {
+			return errStalled
+		}
+		if stat&ev3dev.Running == 0 {
+			return nil
+		}
+		err = q.sleep(q.ctx, queuePoll)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// end records err as the error that ended the queue and
+// abandons the pending actions.
+func (q *CommandQueue) end(err error) {
+	q.mu.Lock()
+	q.err = err
+	pending := q.pending
+	q.pending = nil
+	q.busy = true
+	q.mu.Unlock()
+
+	for _, a := range pending {
+		if a.done != nil {
+			a.done(err)
+		}
+	}
+
+	q.mu.Lock()
+	q.busy = false
+	q.idle.Broadcast()
+	q.mu.Unlock()
+}
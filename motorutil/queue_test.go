@@ -0,0 +1,177 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// fakeQueueMotor is a queueMotor that runs for a fixed
+// number of state reads after each run command.
+type fakeQueueMotor struct {
+	mu      sync.Mutex
+	log     []string
+	running int
+	stall   bool
+}
+
+func (m *fakeQueueMotor) record(s string) {
+	m.mu.Lock()
+	m.log = append(m.log, s)
+	m.mu.Unlock()
+}
+
+func (m *fakeQueueMotor) runTo(pos, speed int) error {
+	m.record(fmt.Sprintf("run-to %d@%d", pos, speed))
+	m.mu.Lock()
+	m.running = 3
+	m.mu.Unlock()
+	return nil
+}
+func (m *fakeQueueMotor) runTimed(d time.Duration, speed int) error {
+	m.record(fmt.Sprintf("run-timed %v@%d", d, speed))
+	m.mu.Lock()
+	m.running = 3
+	m.mu.Unlock()
+	return nil
+}
+func (m *fakeQueueMotor) stop() error {
+	m.record("stop")
+	m.mu.Lock()
+	m.running = 0
+	m.mu.Unlock()
+	return nil
+}
+func (m *fakeQueueMotor) state() (ev3dev.MotorState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.running == 0 {
+		return 0, nil
+	}
+	if m.stall {
+		return ev3dev.Running | ev3dev.Stalled, nil
+	}
+	m.running--
+	return ev3dev.Running, nil
+}
+
+// fakeQueueSleep records pauses and blocks until
+// ctx is done for pauses of an hour or more.
+func fakeQueueSleep(m *fakeQueueMotor) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		if d != queuePoll {
+			m.record(fmt.Sprintf("pause %v", d))
+		}
+		if d >= time.Hour {
+			<-ctx.Done()
+		}
+		return ctx.Err()
+	}
+}
+
+func TestCommandQueue(t *testing.T) {
+	m := &fakeQueueMotor{}
+	q := newCommandQueue(context.Background(), m, fakeQueueSleep(m))
+	defer q.Cancel()
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	note := func(name string) func(error) {
+		return func(err error) {
+			mu.Lock()
+			order = append(order, fmt.Sprintf("%s:%v", name, err))
+			mu.Unlock()
+		}
+	}
+	err := q.Enqueue(
+		RunTo(360, -500).Then(note("first")),
+		Pause(time.Second),
+		RunTimed(2*time.Second, -200).Then(note("second")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = q.Enqueue(Stop().Then(note("stop")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = q.Wait()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := []string{"run-to 360@500", "pause 1s", "run-timed 2s@-200", "stop"}
+	if !reflect.DeepEqual(m.log, want) {
+		t.Errorf("unexpected motor operations: got:%q want:%q", m.log, want)
+	}
+	if want := []string{"first:<nil>", "second:<nil>", "stop:<nil>"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("unexpected callbacks: got:%q want:%q", order, want)
+	}
+
+	// The queue remains usable after it is drained.
+	m.log = nil
+	order = nil
+	m.stall = true
+	err = q.Enqueue(RunTo(0, 100).Then(note("stall")), Pause(time.Second).Then(note("pause")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = q.Wait()
+	if err != errStalled {
+		t.Errorf("unexpected error: got:%v want:%v", err, errStalled)
+	}
+	if want := []string{"run-to 0@100", "stop"}; !reflect.DeepEqual(m.log, want) {
+		t.Errorf("unexpected motor operations after stall: got:%q want:%q", m.log, want)
+	}
+	if want := []string{"stall:" + errStalled.Error(), "pause:" + errStalled.Error()}; !reflect.DeepEqual(order, want) {
+		t.Errorf("unexpected callbacks after stall: got:%q want:%q", order, want)
+	}
+	err = q.Enqueue(Stop())
+	if err != errStalled {
+		t.Errorf("unexpected error enqueuing after failure: got:%v want:%v", err, errStalled)
+	}
+}
+
+func TestCommandQueueCancel(t *testing.T) {
+	m := &fakeQueueMotor{}
+	q := newCommandQueue(context.Background(), m, fakeQueueSleep(m))
+
+	abandoned := make(chan error, 2)
+	started := make(chan struct{})
+	err := q.Enqueue(
+		Stop().Then(func(error) { close(started) }),
+		Pause(time.Hour).Then(func(err error) { abandoned <- err }),
+		RunTo(100, 100).Then(func(err error) { abandoned <- err }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+	q.Cancel()
+	for i := 0; i < 2; i++ {
+		if err := <-abandoned; err != context.Canceled {
+			t.Errorf("unexpected callback error: got:%v want:%v", err, context.Canceled)
+		}
+	}
+	if err := q.Wait(); err != context.Canceled {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.Canceled)
+	}
+	for _, op := range m.log {
+		if op == "run-to 100@100" {
+			t.Error("action run after cancellation")
+		}
+	}
+	if last := m.log[len(m.log)-1]; last != "stop" {
+		t.Errorf("motor not stopped after cancellation: %q", m.log)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// SpeedRegulator holds the speed of a DC motor using a PID controller
+// running in Go. DC motors have no speed feedback of their own, so the
+// speed is measured by Source, for example the Speed method of a tacho
+// motor or the rate of a rotation sensor on the same axle, and the
+// controller output is written to the motor's duty cycle setpoint.
+//
+// The speed units are those of Source, and the controller gains are in
+// percent duty cycle per unit of speed error. The controller uses
+// derivative on measurement, so changes of the target speed do not kick
+// the output, and integration is suspended while the output is
+// saturated to prevent windup.
+type SpeedRegulator struct {
+	// Motor is the regulated motor.
+	Motor *ev3dev.DCMotor
+
+	// Source returns the measured
+	// speed of the motor.
+	Source func() (float64, error)
+
+	// Kp, Ki and Kd are the
+	// proportional, integral and
+	// derivative gains. The integral
+	// and derivative terms are in
+	// units of seconds.
+	Kp, Ki, Kd float64
+
+	// Interval is the time between
+	// controller updates.
+	Interval time.Duration
+
+	mu     sync.Mutex
+	target float64
+}
+
+// SetSpeed sets the target speed of the SpeedRegulator. SetSpeed may be
+// called while the regulator is running.
+func (r *SpeedRegulator) SetSpeed(speed float64) {
+	r.mu.Lock()
+	r.target = speed
+	r.mu.Unlock()
+}
+
+// Speed returns the target speed of the SpeedRegulator.
+func (r *SpeedRegulator) Speed() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.target
+}
+
+// regulatedMotor is the motor interface used by a SpeedRegulator.
+// It is implemented by dcMotor.
+type regulatedMotor interface {
+	runDirect(duty int) error
+	setDuty(duty int) error
+	stop() error
+}
+
+// dcMotor adapts an *ev3dev.DCMotor to the regulatedMotor interface.
+type dcMotor struct {
+	m *ev3dev.DCMotor
+}
+
+func (d dcMotor) runDirect(duty int) error {
+	return d.m.SetDutyCycleSetpoint(duty).Command(ev3dev.CommandRunDirect).Err()
+}
+func (d dcMotor) setDuty(duty int) error { return d.m.SetDutyCycleSetpoint(duty).Err() }
+func (d dcMotor) stop() error            { return d.m.Command(ev3dev.CommandStop).Err() }
+
+// Run runs the motor in run-direct mode and regulates its speed every
+// Interval until ctx is done or an error occurs. The motor is left
+// stopped when Run returns. If ctx is done, the context's error is
+// returned.
+func (r *SpeedRegulator) Run(ctx context.Context) error {
+	return r.run(ctx, dcMotor{r.Motor}, time.Now, sleepContext)
+}
+
+func (r *SpeedRegulator) run(ctx context.Context, m regulatedMotor, now func() time.Time, sleep func(context.Context, time.Duration) error) (err error) {
+	if r.Interval <= 0 {
+		return fmt.Errorf("motorutil: invalid regulator interval: %v (must be positive)", r.Interval)
+	}
+	if r.Source == nil {
+		return fmt.Errorf("motorutil: no regulator speed source")
+	}
+	err = m.runDirect(0)
+	if err != nil {
+		m.stop()
+		return err
+	}
+	defer func() {
+		serr := m.stop()
+		if err == nil {
+			err = serr
+		}
+	}()
+
+	var c pidController
+	last := now()
+	for {
+		err = sleep(ctx, r.Interval)
+		if err != nil {
+			return err
+		}
+		var speed float64
+		speed, err = r.Source()
+		if err != nil {
+			return err
+		}
+		t := now()
+		duty := c.update(r.Kp, r.Ki, r.Kd, r.Speed(), speed, t.Sub(last))
+		last = t
+		err = m.setDuty(duty)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// pidController is the state of a PID controller with an output
+// limited to the duty cycle range.
+type pidController struct {
+	integral float64
+	last     float64
+	started  bool
+}
+
+// update returns the controller output for the target and measured
+// values after dt has elapsed since the previous update.
+func (c *pidController) update(kp, ki, kd, target, measured float64, dt time.Duration) int {
+	e := target - measured
+	var deriv float64
+	if c.started && dt > 0 {
+		deriv = -(measured - c.last) / dt.Seconds()
+	}
+	c.last = measured
+	c.started = true
+
+	out := kp*e + ki*c.integral + kd*deriv
+	// Only integrate when the output is not
+	// saturated in the direction of the error.
+	if !(out >= 100 && e > 0) && !(out <= -100 && e < 0) {
+		c.integral += e * dt.Seconds()
+		out = kp*e + ki*c.integral + kd*deriv
+	}
+	return int(math.Round(math.Max(-100, math.Min(100, out))))
+}
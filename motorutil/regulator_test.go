@@ -0,0 +1,128 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// simDCMotor is a first order simulation of a DC motor
+// driven by duty cycle with a speed measurement.
+type simDCMotor struct {
+	now     time.Time
+	duty    int
+	speed   float64
+	running bool
+	stopped bool
+}
+
+func (m *simDCMotor) runDirect(duty int) error {
+	m.duty = duty
+	m.running = true
+	return nil
+}
+func (m *simDCMotor) setDuty(duty int) error {
+	if duty < -100 || 100 < duty {
+		panic("duty cycle out of range")
+	}
+	m.duty = duty
+	return nil
+}
+func (m *simDCMotor) stop() error {
+	m.running = false
+	m.stopped = true
+	return nil
+}
+
+// advance advances the simulation by dt. The motor reaches
+// 10 units of speed per percent duty cycle with a time
+// constant of 100ms, and is slowed by a constant load.
+func (m *simDCMotor) advance(dt time.Duration) {
+	const (
+		gain = 10
+		tau  = 0.1
+		load = 150
+	)
+	m.now = m.now.Add(dt)
+	if !m.running {
+		return
+	}
+	steady := gain*float64(m.duty) - load
+	m.speed += (steady - m.speed) * (1 - math.Exp(-dt.Seconds()/tau))
+}
+
+func TestSpeedRegulator(t *testing.T) {
+	m := &simDCMotor{now: time.Unix(0, 0)}
+	r := &SpeedRegulator{
+		Source:   func() (float64, error) { return m.speed, nil },
+		Kp:       0.05,
+		Ki:       0.5,
+		Interval: 10 * time.Millisecond,
+	}
+	r.SetSpeed(400)
+
+	const steps = 300
+	var n int
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sleep := func(ctx context.Context, d time.Duration) error {
+		n++
+		if n == steps/2 {
+			// Check the first target
+			// and change it while
+			// the regulator runs.
+			if math.Abs(m.speed-400) > 5 {
+				t.Errorf("speed not regulated: got:%v want:400", m.speed)
+			}
+			r.SetSpeed(-250)
+		}
+		if n == steps {
+			cancel()
+		}
+		m.advance(d)
+		return ctx.Err()
+	}
+	err := r.run(ctx, m, func() time.Time { return m.now }, sleep)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.Canceled)
+	}
+	if math.Abs(m.speed-(-250)) > 5 {
+		t.Errorf("speed not regulated after change: got:%v want:-250", m.speed)
+	}
+	if !m.stopped {
+		t.Error("motor not stopped")
+	}
+
+	for _, r := range []*SpeedRegulator{
+		{Source: r.Source},
+		{Interval: time.Millisecond},
+	} {
+		err := r.run(context.Background(), m, time.Now, sleepContext)
+		if err == nil {
+			t.Errorf("expected error for %+v", r)
+		}
+	}
+}
+
+func TestPIDControllerWindup(t *testing.T) {
+	var c pidController
+	// Drive the output into saturation
+	// for a long time.
+	for i := 0; i < 1000; i++ {
+		if got := c.update(1, 1, 0, 1000, 0, 10*time.Millisecond); got != 100 {
+			t.Fatalf("unexpected saturated output: got:%d want:100", got)
+		}
+	}
+	// Once the target is reached the output
+	// must recover without first unwinding
+	// a large integral.
+	got := c.update(1, 1, 0, 1000, 1000, 10*time.Millisecond)
+	if got >= 100 {
+		t.Errorf("integral wound up: output %d at target", got)
+	}
+}
@@ -85,7 +85,7 @@ func ResetAll() error {
 				errors = append(errors, err)
 				continue
 			}
-			err = t.Command("reset").Err()
+			err = t.Command(ev3dev.CommandReset).Err()
 			if err != nil {
 				errors = append(errors, err)
 			}
@@ -95,7 +95,7 @@ func ResetAll() error {
 				errors = append(errors, err)
 				continue
 			}
-			err = s.Command("float").Err()
+			err = s.Command(ev3dev.CommandFloat).Err()
 			if err != nil {
 				errors = append(errors, err)
 			}
@@ -105,7 +105,7 @@ func ResetAll() error {
 				errors = append(errors, err)
 				continue
 			}
-			err = d.Command("stop").Err()
+			err = d.Command(ev3dev.CommandStop).Err()
 			if err != nil {
 				errors = append(errors, err)
 			}
@@ -0,0 +1,178 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SCurve is a jerk-limited velocity profile. Unlike a Trapezoid, the
+// acceleration of an SCurve changes at a rate limited to Jerk, so a motor
+// following the profile does not apply sudden changes in torque at the
+// start and end of its acceleration and deceleration. The acceleration and
+// deceleration phases are symmetric.
+//
+// If Distance is too short to reach MaxSpeed, the peak speed is reduced,
+// and if the peak speed is too low to reach MaxAccel, the peak
+// acceleration is reduced.
+//
+// Distances are in tacho counts, speeds in tacho counts per second,
+// accelerations in tacho counts per second per second and jerk in tacho
+// counts per second per second per second.
+type SCurve struct {
+	// Distance is the signed distance
+	// moved by the profile.
+	Distance float64
+
+	// MaxSpeed and Jerk are the magnitudes
+	// of the limits of the profile's speed
+	// and jerk. They must be positive.
+	MaxSpeed float64
+	Jerk     float64
+
+	// MaxAccel is the magnitude of the
+	// limit of the profile's acceleration.
+	// If MaxAccel is zero, acceleration is
+	// limited only by Jerk.
+	MaxAccel float64
+}
+
+// validate returns an error if the profile's parameters are invalid.
+func (p SCurve) validate() error {
+	for _, v := range []float64{p.Distance, p.MaxSpeed, p.MaxAccel, p.Jerk} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("motorutil: invalid profile: %+v (values must be finite)", p)
+		}
+	}
+	if p.MaxSpeed <= 0 || p.Jerk <= 0 || p.MaxAccel < 0 {
+		return fmt.Errorf("motorutil: invalid profile: %+v (limits must be positive)", p)
+	}
+	return nil
+}
+
+// ramp describes a jerk-limited acceleration from rest to a
+// speed. The acceleration has a period of increasing
+// acceleration, a period of constant acceleration and a period
+// of decreasing acceleration.
+type ramp struct {
+	jerk     float64 // jerk is the jerk of the ramp.
+	jerkTime float64 // jerkTime is the length of each jerk period.
+	constant float64 // constant is the length of the constant acceleration period.
+}
+
+// newRamp returns the ramp to the speed v with the jerk limit j and
+// acceleration limit a. If a is zero, acceleration is not limited.
+func newRamp(v, j, a float64) ramp {
+	tj := math.Sqrt(v / j)
+	if a <= 0 || j*tj <= a {
+		// The acceleration limit is not reached.
+		return ramp{jerk: j, jerkTime: tj}
+	}
+	return ramp{jerk: j, jerkTime: a / j, constant: v/a - a/j}
+}
+
+// rampTo returns the ramp to the speed v with the acceleration
+// and jerk limits of the profile.
+func (p SCurve) rampTo(v float64) ramp {
+	return newRamp(v, p.Jerk, p.MaxAccel)
+}
+
+// duration returns the duration of the ramp in seconds.
+func (r ramp) duration() float64 {
+	return 2*r.jerkTime + r.constant
+}
+
+// at returns the position and speed of the ramp at time t
+// seconds after its start, for t within the ramp.
+func (r ramp) at(t float64) (pos, speed float64) {
+	j, tj := r.jerk, r.jerkTime
+	if t < tj {
+		return j * t * t * t / 6, j * t * t / 2
+	}
+	// State at the end of the increasing
+	// acceleration period.
+	acc := j * tj
+	pos, speed = j*tj*tj*tj/6, j*tj*tj/2
+	t -= tj
+	if t < r.constant {
+		return pos + speed*t + acc*t*t/2, speed + acc*t
+	}
+	pos, speed = pos+speed*r.constant+acc*r.constant*r.constant/2, speed+acc*r.constant
+	t -= r.constant
+	return pos + speed*t + acc*t*t/2 - j*t*t*t/6, speed + acc*t - j*t*t/2
+}
+
+// phases returns the acceleration ramp of the profile and the
+// duration of its cruise phase in seconds.
+func (p SCurve) phases() (r ramp, cruise, peak float64) {
+	dist := math.Abs(p.Distance)
+	if dist == 0 {
+		return ramp{jerk: p.Jerk}, 0, 0
+	}
+	// The distance moved by symmetric acceleration
+	// and deceleration ramps to a peak speed v is
+	// v times the duration of one ramp.
+	peak = p.MaxSpeed
+	r = p.rampTo(peak)
+	if ramps := peak * r.duration(); ramps <= dist {
+		return r, (dist - ramps) / peak, peak
+	}
+	// The distance moved by the ramps increases
+	// monotonically with the peak speed, so find
+	// the peak speed that moves dist by bisection.
+	lo, hi := 0.0, peak
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if mid*p.rampTo(mid).duration() > dist {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return p.rampTo(lo), 0, lo
+}
+
+// Duration returns the time taken to follow the profile. It returns zero
+// if the profile is invalid.
+func (p SCurve) Duration() time.Duration {
+	if p.validate() != nil {
+		return 0
+	}
+	r, cruise, _ := p.phases()
+	return seconds(2*r.duration() + cruise)
+}
+
+// At returns the signed position and speed of the profile at time t after
+// its start. Before the start, the position and speed are zero and after
+// the end the position is Distance and the speed is zero. At returns zero
+// values if the profile is invalid.
+func (p SCurve) At(t time.Duration) (pos, speed float64) {
+	if p.validate() != nil || t <= 0 {
+		return 0, 0
+	}
+	r, cruise, peak := p.phases()
+	ramp := r.duration()
+	dist := math.Abs(p.Distance)
+	s := t.Seconds()
+	switch {
+	case s < ramp:
+		pos, speed = r.at(s)
+	case s < ramp+cruise:
+		pos, speed = peak*ramp/2+peak*(s-ramp), peak
+	case s < 2*ramp+cruise:
+		// The deceleration ramp is the
+		// acceleration ramp reversed.
+		pos, speed = r.at(2*ramp + cruise - s)
+		pos = dist - pos
+	default:
+		pos = dist
+	}
+	if p.Distance < 0 {
+		pos, speed = -pos, -speed
+	}
+	return pos, speed
+}
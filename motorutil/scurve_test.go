@@ -0,0 +1,99 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSCurveProfile(t *testing.T) {
+	const tol = 1e-6
+	p := SCurve{Distance: 1000, MaxSpeed: 500, MaxAccel: 1000, Jerk: 10000}
+	if got, want := p.Duration(), 2600*time.Millisecond; got != want {
+		t.Errorf("unexpected duration: got:%v want:%v", got, want)
+	}
+	for _, test := range []struct {
+		at         time.Duration
+		pos, speed float64
+	}{
+		{at: -time.Second, pos: 0, speed: 0},
+		{at: 100 * time.Millisecond, pos: 10.0 / 6, speed: 50},
+		{at: 300 * time.Millisecond, pos: 10.0/6 + 30, speed: 250},
+		{at: 600 * time.Millisecond, pos: 150, speed: 500},
+		{at: 1300 * time.Millisecond, pos: 500, speed: 500},
+		{at: 2300 * time.Millisecond, pos: 1000 - 10.0/6 - 30, speed: 250},
+		{at: 3 * time.Second, pos: 1000, speed: 0},
+	} {
+		pos, speed := p.At(test.at)
+		if math.Abs(pos-test.pos) > tol || math.Abs(speed-test.speed) > tol {
+			t.Errorf("unexpected state at %v: got:(%v, %v) want:(%v, %v)", test.at, pos, speed, test.pos, test.speed)
+		}
+	}
+
+	if d := (SCurve{Distance: 100, MaxSpeed: 0, MaxAccel: 1, Jerk: 1}).Duration(); d != 0 {
+		t.Errorf("unexpected duration for invalid profile: %v", d)
+	}
+}
+
+func TestSCurveProfileLimits(t *testing.T) {
+	for _, p := range []SCurve{
+		{Distance: 1000, MaxSpeed: 500, MaxAccel: 1000, Jerk: 10000},
+		// The acceleration limit is not reached.
+		{Distance: -1000, MaxSpeed: 500, MaxAccel: 1000, Jerk: 2000},
+		// The speed limit is not reached.
+		{Distance: 20, MaxSpeed: 500, MaxAccel: 1000, Jerk: 10000},
+		{Distance: 0.5, MaxSpeed: 500, MaxAccel: 1000, Jerk: 10000},
+		// The acceleration is limited only by jerk.
+		{Distance: 1000, MaxSpeed: 500, Jerk: 2000},
+	} {
+		const step = time.Millisecond
+		dt := step.Seconds()
+		end := p.Duration()
+		var lastPos, lastSpeed, lastAcc float64
+		for at := step; at <= end+step; at += step {
+			pos, speed := p.At(at)
+			if math.Abs(speed) > p.MaxSpeed*(1+1e-9) {
+				t.Fatalf("speed limit exceeded for %+v at %v: %v", p, at, speed)
+			}
+			// Check the position is the integral of the speed.
+			if diff := pos - lastPos; math.Abs(diff-(speed+lastSpeed)/2*dt) > 1e-3 {
+				t.Fatalf("position inconsistent with speed for %+v at %v: moved %v at speed %v", p, at, diff, speed)
+			}
+			acc := (speed - lastSpeed) / dt
+			if p.MaxAccel != 0 && math.Abs(acc) > p.MaxAccel*(1+1e-6)+p.Jerk*dt {
+				t.Fatalf("acceleration limit exceeded for %+v at %v: %v", p, at, acc)
+			}
+			if jerk := (acc - lastAcc) / dt; at < end && math.Abs(jerk) > p.Jerk*(1+1e-3) {
+				t.Fatalf("jerk limit exceeded for %+v at %v: %v", p, at, jerk)
+			}
+			lastPos, lastSpeed, lastAcc = pos, speed, acc
+		}
+		if lastPos != p.Distance || lastSpeed != 0 {
+			t.Errorf("unexpected final state for %+v: got:(%v, %v) want:(%v, 0)", p, lastPos, lastSpeed, p.Distance)
+		}
+	}
+}
+
+func TestFollowSCurve(t *testing.T) {
+	p := SCurve{Distance: -1000, MaxSpeed: 500, MaxAccel: 1000, Jerk: 10000}
+	m := &fakeProfileMotor{now: time.Unix(0, 0)}
+	err := follow(context.Background(), m, p, 10*time.Millisecond, StreamSpeed, m.clock, m.sleep)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Speeds are rounded to whole counts per second.
+	if math.Abs(m.pos+1000) > 2 {
+		t.Errorf("unexpected final position: got:%v want:-1000", m.pos)
+	}
+
+	m = &fakeProfileMotor{now: time.Unix(0, 0)}
+	err = follow(context.Background(), m, SCurve{Distance: 1}, 10*time.Millisecond, StreamSpeed, m.clock, m.sleep)
+	if err == nil {
+		t.Error("expected error for invalid profile")
+	}
+}
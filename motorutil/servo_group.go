@@ -0,0 +1,140 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Pose is a set of servo targets keyed by servo name.
+type Pose struct {
+	// Positions holds position
+	// setpoints in [-100, 100].
+	Positions map[string]int
+
+	// Angles holds angles in degrees
+	// for servos that have been
+	// calibrated with Calibrate.
+	Angles map[string]float64
+}
+
+// ServoGroup is a group of named servo motors that are moved to poses
+// together, for example the joints of an arm. A pose is applied by writing
+// the setpoints of all the servos in the pose before issuing the run
+// command to each of them, so that the servos move in a coordinated way.
+//
+// Errors occurring during ServoGroup operations are sticky. They are
+// returned by a call to Err.
+type ServoGroup struct {
+	// Servos holds the servos
+	// of the group by name.
+	Servos map[string]*ev3dev.ServoMotor
+
+	// Poses holds named poses
+	// applied by ApplyPose.
+	Poses map[string]Pose
+
+	err error
+}
+
+// ApplyPose applies the pose held in Poses with the given name.
+func (g *ServoGroup) ApplyPose(name string) *ServoGroup {
+	if g.err != nil {
+		return g
+	}
+	p, ok := g.Poses[name]
+	if !ok {
+		g.err = fmt.Errorf("motorutil: no pose named %q", name)
+		return g
+	}
+	return g.SetPose(p)
+}
+
+// SetPose applies the pose p. The pose is checked against the servos of
+// the group before any setpoint is written. If a setpoint cannot be
+// written, no run command is issued, but setpoints already written are
+// not reverted.
+func (g *ServoGroup) SetPose(p Pose) *ServoGroup {
+	if g.err != nil {
+		return g
+	}
+	servos := make(map[string]poseServo, len(g.Servos))
+	for name, m := range g.Servos {
+		servos[name] = servoMotor{m}
+	}
+	g.err = setPose(servos, p)
+	return g
+}
+
+// Err returns the error state of the ServoGroup and clears it.
+func (g *ServoGroup) Err() error {
+	err := g.err
+	g.err = nil
+	return err
+}
+
+// poseServo is the servo interface used by a ServoGroup.
+// It is implemented by servoMotor.
+type poseServo interface {
+	setPosition(sp int) error
+	setAngle(deg float64) error
+	run() error
+}
+
+// servoMotor adapts an *ev3dev.ServoMotor to the poseServo interface.
+type servoMotor struct {
+	m *ev3dev.ServoMotor
+}
+
+func (s servoMotor) setPosition(sp int) error   { return s.m.SetPositionSetpoint(sp).Err() }
+func (s servoMotor) setAngle(deg float64) error { return s.m.SetAngle(deg).Err() }
+func (s servoMotor) run() error                 { return s.m.Command(ev3dev.CommandRun).Err() }
+
+func setPose(servos map[string]poseServo, p Pose) error {
+	// Check the pose before writing anything and
+	// order the servos so that writes are repeatable.
+	names := make([]string, 0, len(p.Positions)+len(p.Angles))
+	for name, sp := range p.Positions {
+		if _, ok := servos[name]; !ok {
+			return fmt.Errorf("motorutil: no servo named %q", name)
+		}
+		if _, ok := p.Angles[name]; ok {
+			return fmt.Errorf("motorutil: servo %q has both a position and an angle", name)
+		}
+		if sp < -100 || 100 < sp {
+			return fmt.Errorf("motorutil: position setpoint for servo %q out of range: %d", name, sp)
+		}
+		names = append(names, name)
+	}
+	for name := range p.Angles {
+		if _, ok := servos[name]; !ok {
+			return fmt.Errorf("motorutil: no servo named %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var err error
+		if sp, ok := p.Positions[name]; ok {
+			err = servos[name].setPosition(sp)
+		} else {
+			err = servos[name].setAngle(p.Angles[name])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		err := servos[name].run()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeServo is a poseServo that logs its operations.
+type fakeServo struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (s fakeServo) setPosition(sp int) error {
+	*s.log = append(*s.log, fmt.Sprintf("%s=%d", s.name, sp))
+	return s.err
+}
+func (s fakeServo) setAngle(deg float64) error {
+	*s.log = append(*s.log, fmt.Sprintf("%s=%v°", s.name, deg))
+	return s.err
+}
+func (s fakeServo) run() error {
+	*s.log = append(*s.log, s.name+" run")
+	return nil
+}
+
+func TestSetPose(t *testing.T) {
+	var log []string
+	servos := map[string]poseServo{
+		"shoulder": fakeServo{name: "shoulder", log: &log},
+		"elbow":    fakeServo{name: "elbow", log: &log},
+		"wrist":    fakeServo{name: "wrist", log: &log},
+	}
+	err := setPose(servos, Pose{
+		Positions: map[string]int{"shoulder": 40, "wrist": -100},
+		Angles:    map[string]float64{"elbow": 22.5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"elbow=22.5°", "shoulder=40", "wrist=-100", "elbow run", "shoulder run", "wrist run"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("unexpected operations: got:%q want:%q", log, want)
+	}
+
+	for _, p := range []Pose{
+		{Positions: map[string]int{"hip": 0}},
+		{Angles: map[string]float64{"hip": 0}},
+		{Positions: map[string]int{"elbow": 101}},
+		{Positions: map[string]int{"elbow": 0}, Angles: map[string]float64{"elbow": 0}},
+	} {
+		log = nil
+		err = setPose(servos, p)
+		if err == nil {
+			t.Errorf("expected error for pose %+v", p)
+		}
+		if log != nil {
+			t.Errorf("unexpected operations for invalid pose %+v: %q", p, log)
+		}
+	}
+
+	errTest := errors.New("test error")
+	log = nil
+	servos["elbow"] = fakeServo{name: "elbow", log: &log, err: errTest}
+	err = setPose(servos, Pose{Positions: map[string]int{"elbow": 10, "wrist": 10}})
+	if err != errTest {
+		t.Errorf("unexpected error: got:%v want:%v", err, errTest)
+	}
+	if want := []string{"elbow=10"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("unexpected operations after failure: got:%q want:%q", log, want)
+	}
+}
+
+func TestServoGroupStickyError(t *testing.T) {
+	g := ServoGroup{}
+	err := g.ApplyPose("rest").Err()
+	if err == nil {
+		t.Error("expected error for missing pose")
+	}
+	if err := g.Err(); err != nil {
+		t.Errorf("error not cleared: %v", err)
+	}
+}
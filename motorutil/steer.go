@@ -29,13 +29,20 @@ type Steering struct {
 	// See ev3dev.Wait documentation for timeout behaviour.
 	Timeout time.Duration
 
+	// Limits specifies the motion limits
+	// applied by all steering operations.
+	// If Limits is nil, no limits are
+	// applied and the motors' ramp
+	// setpoints are left unaltered.
+	Limits *MotionLimits
+
 	err error
 }
 
 // StopAction returns the stop action used when a stop command is issued
 // to the TachoMotor devices held by the Steering. StopAction returns an
 // error if the two motors do not agree on the stop action.
-func (s *Steering) StopAction() (string, error) {
+func (s *Steering) StopAction() (ev3dev.StopAction, error) {
 	err := s.Err()
 	if err != nil {
 		return "", err
@@ -56,7 +63,7 @@ func (s *Steering) StopAction() (string, error) {
 }
 
 type actionMismatch struct {
-	left, right string
+	left, right ev3dev.StopAction
 }
 
 func (e actionMismatch) Error() string {
@@ -129,7 +136,7 @@ func (e actionsMismatch) Error() string {
 // SetStopAction sets the stop action to be used when a stop command is
 // issued to the TachoMotor. SetStopAction returns on the first error
 // encountered.
-func (s *Steering) SetStopAction(action string) *Steering {
+func (s *Steering) SetStopAction(action ev3dev.StopAction) *Steering {
 	if s.err != nil {
 		return s
 	}
@@ -147,7 +154,8 @@ func (s *Steering) SetStopAction(action string) *Steering {
 // If the product of counts and speed is negative, the turn will be made in reverse.
 //
 // See the ev3dev.SetSpeedSetPoint and ev3dev.SetPositionSetPoint documentation for
-// speed and count behaviour.
+// speed and count behaviour. Jerk-limited motion is not available for position
+// moves, so SteerCounts sets an error if the Steering's Limits has a non-zero Jerk.
 func (s *Steering) SteerCounts(speed, turn, counts int) *Steering {
 	if s.err != nil {
 		return s
@@ -157,6 +165,16 @@ func (s *Steering) SteerCounts(speed, turn, counts int) *Steering {
 		s.err = directionError(turn)
 		return s
 	}
+	if s.Limits != nil && s.Limits.Jerk != 0 {
+		s.err = fmt.Errorf("motorutil: jerk limit not supported for counted steering: %d", s.Limits.Jerk)
+		return s
+	}
+
+	speed = s.Limits.limit(speed)
+	s.applyRamps()
+	if s.err != nil {
+		return s
+	}
 
 	// Make speed a velocity relative to the counts vector.
 	if speed < 0 {
@@ -184,9 +202,9 @@ func (s *Steering) SteerCounts(speed, turn, counts int) *Steering {
 	// TODO(kortschak): Remove conditional stop when the
 	// driver handles zero relative position change as a no-op.
 	if leftCounts == 0 {
-		s.err = s.Left.Command("stop").Err()
+		s.err = s.Left.Command(ev3dev.CommandStop).Err()
 	} else {
-		s.err = s.Left.Command("run-to-rel-pos").Err()
+		s.err = s.Left.Command(ev3dev.CommandRunToRelPos).Err()
 	}
 	if s.err != nil {
 		return s
@@ -194,12 +212,12 @@ func (s *Steering) SteerCounts(speed, turn, counts int) *Steering {
 	// TODO(kortschak): Remove conditional stop when the
 	// driver handles zero relative position change as a no-op.
 	if rightCounts == 0 {
-		s.err = s.Right.Command("stop").Err()
+		s.err = s.Right.Command(ev3dev.CommandStop).Err()
 	} else {
-		s.err = s.Right.Command("run-to-rel-pos").Err()
+		s.err = s.Right.Command(ev3dev.CommandRunToRelPos).Err()
 	}
 	if s.err != nil {
-		s.Left.Command("stop").Err()
+		s.Left.Command(ev3dev.CommandStop).Err()
 	}
 	return s
 }
@@ -209,7 +227,8 @@ func (s *Steering) SteerCounts(speed, turn, counts int) *Steering {
 // If speed is negative, the turn will be made in reverse.
 //
 // See the ev3dev.SetSpeedSetpoint and ev3dev.SetTimeSetpoint documentation for speed
-// and duration behaviour.
+// and duration behaviour. If the Steering's Limits has a non-zero Jerk, SteerDuration
+// blocks until the jerk-limited motion is complete and the motors are stopped.
 func (s *Steering) SteerDuration(speed, turn int, d time.Duration) *Steering {
 	if s.err != nil {
 		return s
@@ -224,6 +243,15 @@ func (s *Steering) SteerDuration(speed, turn int, d time.Duration) *Steering {
 		return s
 	}
 
+	speed = s.Limits.limit(speed)
+	if s.Limits != nil && s.Limits.Jerk != 0 {
+		return s.steerProfile(speed, turn, d, time.Sleep)
+	}
+	s.applyRamps()
+	if s.err != nil {
+		return s
+	}
+
 	leftSpeed, _, rightSpeed, _ := motorRates(speed, turn, 0)
 
 	s.err = s.Left.
@@ -241,17 +269,131 @@ func (s *Steering) SteerDuration(speed, turn int, d time.Duration) *Steering {
 		return s
 	}
 
-	s.err = s.Left.Command("run-timed").Err()
+	s.err = s.Left.Command(ev3dev.CommandRunTimed).Err()
 	if s.err != nil {
 		return s
 	}
-	s.err = s.Right.Command("run-timed").Err()
+	s.err = s.Right.Command(ev3dev.CommandRunTimed).Err()
 	if s.err != nil {
-		s.Left.Command("stop").Err()
+		s.Left.Command(ev3dev.CommandStop).Err()
 	}
 	return s
 }
 
+// steerProfile steers in the given turn for the duration d, stepping the
+// speed setpoints through the jerk-limited profile for speed. The motors
+// are stopped and their ramp setpoints restored when steerProfile returns.
+// If d is too short for the full profile, the acceleration is cut short and
+// mirrored for deceleration.
+func (s *Steering) steerProfile(speed, turn int, d time.Duration, sleep func(time.Duration)) *Steering {
+	var ramps [2][2]time.Duration
+	for i, m := range []*ev3dev.TachoMotor{s.Left, s.Right} {
+		ramps[i][0], s.err = m.RampUpSetpoint()
+		if s.err != nil {
+			return s
+		}
+		ramps[i][1], s.err = m.RampDownSetpoint()
+		if s.err != nil {
+			return s
+		}
+	}
+	defer func() {
+		for i, m := range []*ev3dev.TachoMotor{s.Left, s.Right} {
+			err := m.SetRampUpSetpoint(ramps[i][0]).SetRampDownSetpoint(ramps[i][1]).Err()
+			if s.err == nil {
+				s.err = err
+			}
+		}
+	}()
+
+	s.err = s.Left.SetRampUpSetpoint(0).SetRampDownSetpoint(0).Err()
+	if s.err != nil {
+		return s
+	}
+	s.err = s.Right.SetRampUpSetpoint(0).SetRampDownSetpoint(0).Err()
+	if s.err != nil {
+		return s
+	}
+
+	up := sCurve(speed, s.Limits.Jerk, s.Limits.Accel, profileInterval)
+	if n := int(d / profileInterval / 2); n < len(up) {
+		up = up[:n]
+	}
+	hold := d - 2*time.Duration(len(up))*profileInterval
+
+	set := func(speed int) {
+		leftSpeed, _, rightSpeed, _ := motorRates(speed, turn, 0)
+		s.err = s.Left.SetSpeedSetpoint(leftSpeed).Err()
+		if s.err != nil {
+			return
+		}
+		s.err = s.Right.SetSpeedSetpoint(rightSpeed).Err()
+	}
+	defer func() {
+		err := s.Left.Command(ev3dev.CommandStop).Err()
+		if s.err == nil {
+			s.err = err
+		}
+		err = s.Right.Command(ev3dev.CommandStop).Err()
+		if s.err == nil {
+			s.err = err
+		}
+	}()
+
+	set(0)
+	if s.err != nil {
+		return s
+	}
+	s.err = s.Left.Command(ev3dev.CommandRunForever).Err()
+	if s.err != nil {
+		return s
+	}
+	s.err = s.Right.Command(ev3dev.CommandRunForever).Err()
+	if s.err != nil {
+		return s
+	}
+	for _, sp := range up {
+		set(sp)
+		if s.err != nil {
+			return s
+		}
+		sleep(profileInterval)
+	}
+	if hold > 0 {
+		sleep(hold)
+	}
+	for i := len(up) - 2; i >= 0; i-- {
+		set(up[i])
+		if s.err != nil {
+			return s
+		}
+		sleep(profileInterval)
+	}
+	if len(up) != 0 {
+		sleep(profileInterval)
+	}
+	return s
+}
+
+// applyRamps sets the ramp setpoints of the motors from the Steering's
+// motion limits if they are not nil.
+func (s *Steering) applyRamps() {
+	if s.Limits == nil {
+		return
+	}
+	s.err = s.Left.
+		SetRampUpSetpoint(s.Limits.RampUp).
+		SetRampDownSetpoint(s.Limits.RampDown).
+		Err()
+	if s.err != nil {
+		return
+	}
+	s.err = s.Right.
+		SetRampUpSetpoint(s.Limits.RampUp).
+		SetRampDownSetpoint(s.Limits.RampDown).
+		Err()
+}
+
 func motorRates(speed, turn, counts int) (leftSpeed, leftCounts, rightSpeed, rightCounts int) {
 	switch {
 	case turn == 0:
@@ -275,6 +417,12 @@ func motorRates(speed, turn, counts int) (leftSpeed, leftCounts, rightSpeed, rig
 	return leftSpeed, leftCounts, rightSpeed, rightCounts
 }
 
+// AddToTree adds the Steering's motors to t as a group with the given
+// name, so that the drive base membership is shown when t is exported.
+func (s *Steering) AddToTree(t *ev3dev.DeviceTree, name string) {
+	t.AddGroup(name, s.Left, s.Right)
+}
+
 // Err returns the error state of the Steering and clears it.
 func (s *Steering) Err() error {
 	err := s.err
@@ -5,6 +5,7 @@
 package motorutil
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -147,3 +148,79 @@ func TestIntersect(t *testing.T) {
 		}
 	}
 }
+
+var limitTests = []struct {
+	limits *MotionLimits
+	speed  int
+	want   int
+}{
+	{limits: nil, speed: 1000, want: 1000},
+	{limits: &MotionLimits{}, speed: -1000, want: -1000},
+	{limits: &MotionLimits{MaxSpeed: 500}, speed: 400, want: 400},
+	{limits: &MotionLimits{MaxSpeed: 500}, speed: 600, want: 500},
+	{limits: &MotionLimits{MaxSpeed: 500}, speed: -600, want: -500},
+	{limits: &MotionLimits{MaxSpeed: 500}, speed: -500, want: -500},
+}
+
+func TestLimit(t *testing.T) {
+	for _, test := range limitTests {
+		got := test.limits.limit(test.speed)
+		if got != test.want {
+			t.Errorf("unexpected limited speed for %+v at %d: got:%d want:%d",
+				test.limits, test.speed, got, test.want)
+		}
+	}
+}
+
+func TestSteerCountsJerk(t *testing.T) {
+	// The motors are nil, so the jerk limit must
+	// be rejected before they are reached.
+	s := Steering{Limits: &MotionLimits{Jerk: 10000}}
+	err := s.SteerCounts(500, 0, 360).Err()
+	if err == nil {
+		t.Error("expected error for jerk-limited counted steering")
+	}
+}
+
+var sCurveTests = []struct {
+	speed, jerk, accel int
+}{
+	{speed: 1000, jerk: 10000, accel: 0},
+	{speed: 1000, jerk: 10000, accel: 2000},
+	{speed: -800, jerk: 20000, accel: 1500},
+	{speed: 50, jerk: 100000, accel: 0},
+}
+
+func TestSCurve(t *testing.T) {
+	const dt = profileInterval
+	step := dt.Seconds()
+	for _, test := range sCurveTests {
+		profile := sCurve(test.speed, test.jerk, test.accel, dt)
+		if len(profile) == 0 || profile[len(profile)-1] != test.speed {
+			t.Errorf("unexpected final speed for %+v: got:%v", test, profile)
+			continue
+		}
+		prev, prevAccel := 0.0, 0.0
+		for i, sp := range profile {
+			v := math.Abs(float64(sp))
+			if v < prev {
+				t.Errorf("non-monotonic profile for %+v at %d: %v", test, i, profile)
+				break
+			}
+			accel := (v - prev) / step
+			// Allow for setpoint rounding.
+			tol := 2 / step
+			if test.accel > 0 && accel > float64(test.accel)+tol {
+				t.Errorf("acceleration limit exceeded for %+v at %d: %v > %d", test, i, accel, test.accel)
+			}
+			if jerk := math.Abs(accel-prevAccel) / step; jerk > float64(test.jerk)+tol/step {
+				t.Errorf("jerk limit exceeded for %+v at %d: %v > %d", test, i, jerk, test.jerk)
+			}
+			prev, prevAccel = v, accel
+		}
+	}
+
+	if got := sCurve(500, 0, 0, profileInterval); !reflect.DeepEqual(got, []int{500}) {
+		t.Errorf("unexpected profile without jerk: got:%v want:[500]", got)
+	}
+}
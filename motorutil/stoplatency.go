@@ -0,0 +1,221 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// StopTest measures the latency between issuing a stop command to a
+// motor and the motor stopping. Each trial runs the motor at Speed for
+// SpinUp, samples the motor's position, issues a stop command and then
+// samples the motor's state and position as quickly as possible to
+// measure
+//
+//   - the state latency: the time from the start of the stop command
+//     write until the motor's state no longer includes running, and
+//   - the wheel latency: the time from the start of the stop command
+//     write until the last change in the motor's position before it
+//     remained unchanged for StableFor.
+//
+// Both latencies include the time taken to write the stop command.
+//
+// The measured latencies include the sampling period, which depends on
+// system load, so measurements should be made under conditions similar
+// to those of the robot's normal operation. Stopping distances can be
+// derived from the Overrun counts of each trial.
+type StopTest struct {
+	// Speed is the speed setpoint used for
+	// each trial in tacho counts per second.
+	Speed int
+
+	// Trials is the number of trials.
+	Trials int
+
+	// SpinUp is the time the motor is run
+	// before each stop command.
+	SpinUp time.Duration
+
+	// StableFor is the time the position
+	// must remain unchanged for the wheel
+	// to be considered stopped.
+	StableFor time.Duration
+
+	// Timeout is the maximum time to wait
+	// for the wheel to stop in each trial.
+	Timeout time.Duration
+}
+
+// StopTrial is the result of a single StopTest trial.
+type StopTrial struct {
+	// StateLatency and WheelLatency are the
+	// state and wheel latencies of the trial.
+	StateLatency, WheelLatency time.Duration
+
+	// Overrun is the number of tacho counts
+	// the motor turned from the position
+	// sampled immediately before the stop
+	// command was written, so it includes
+	// motion during the write.
+	Overrun int
+
+	// Samples is the number of state and
+	// position samples taken in the trial.
+	Samples int
+}
+
+// Percentiles holds summary statistics for a set of durations.
+type Percentiles struct {
+	P50, P90, P99, Max time.Duration
+}
+
+func (p Percentiles) String() string {
+	return fmt.Sprintf("p50=%v p90=%v p99=%v max=%v", p.P50, p.P90, p.P99, p.Max)
+}
+
+// StopReport is the result of a StopTest.
+type StopReport struct {
+	// Trials holds the result of
+	// each trial in order.
+	Trials []StopTrial
+
+	// StateLatency and WheelLatency
+	// summarize the latencies of the
+	// trials.
+	StateLatency, WheelLatency Percentiles
+}
+
+// stopMotor is the motor interface used by a StopTest.
+type stopMotor interface {
+	run(speed int) error
+	stop() error
+	state() (ev3dev.MotorState, error)
+	position() (int, error)
+}
+
+// tachoMotor adapts an *ev3dev.TachoMotor to the stopMotor interface.
+type tachoMotor struct {
+	m *ev3dev.TachoMotor
+}
+
+func (t tachoMotor) run(speed int) error {
+	return t.m.SetSpeedSetpoint(speed).Command(ev3dev.CommandRunForever).Err()
+}
+func (t tachoMotor) stop() error                       { return t.m.Command(ev3dev.CommandStop).Err() }
+func (t tachoMotor) state() (ev3dev.MotorState, error) { return t.m.State() }
+func (t tachoMotor) position() (int, error)            { return t.m.Position() }
+
+// errNotStopped is returned when the wheel
+// does not stop within the test timeout.
+var errNotStopped = errors.New("motorutil: motor did not stop before timeout")
+
+// Run runs the StopTest on m using m's current stop action. The motor is
+// left stopped when Run returns.
+func (t StopTest) Run(m *ev3dev.TachoMotor) (*StopReport, error) {
+	return t.run(tachoMotor{m}, time.Now, time.Sleep)
+}
+
+func (t StopTest) run(m stopMotor, now func() time.Time, sleep func(time.Duration)) (*StopReport, error) {
+	if t.Trials < 1 {
+		return nil, fmt.Errorf("motorutil: invalid number of trials: %d (must be positive)", t.Trials)
+	}
+	if t.StableFor <= 0 || t.Timeout <= t.StableFor {
+		return nil, fmt.Errorf("motorutil: invalid stop test timing: stable for %v with timeout %v", t.StableFor, t.Timeout)
+	}
+	var r StopReport
+	for i := 0; i < t.Trials; i++ {
+		trial, err := t.trial(m, now, sleep)
+		if err != nil {
+			m.stop()
+			return nil, err
+		}
+		r.Trials = append(r.Trials, trial)
+	}
+	state := make([]time.Duration, len(r.Trials))
+	wheel := make([]time.Duration, len(r.Trials))
+	for i, trial := range r.Trials {
+		state[i] = trial.StateLatency
+		wheel[i] = trial.WheelLatency
+	}
+	r.StateLatency = percentiles(state)
+	r.WheelLatency = percentiles(wheel)
+	return &r, nil
+}
+
+// trial performs a single stop latency trial.
+func (t StopTest) trial(m stopMotor, now func() time.Time, sleep func(time.Duration)) (StopTrial, error) {
+	err := m.run(t.Speed)
+	if err != nil {
+		return StopTrial{}, err
+	}
+	sleep(t.SpinUp)
+	// Sample the position and time immediately
+	// before the stop write so that the overrun
+	// and latencies include the write.
+	start, err := m.position()
+	if err != nil {
+		return StopTrial{}, err
+	}
+	t0 := now()
+	err = m.stop()
+	if err != nil {
+		return StopTrial{}, err
+	}
+
+	var (
+		trial     StopTrial
+		stateDone bool
+		last      = start
+		moved     = t0
+	)
+	for {
+		stat, err := m.state()
+		if err != nil {
+			return StopTrial{}, err
+		}
+		pos, err := m.position()
+		if err != nil {
+			return StopTrial{}, err
+		}
+		ts := now()
+		trial.Samples++
+		if !stateDone && stat&ev3dev.Running == 0 {
+			trial.StateLatency = ts.Sub(t0)
+			stateDone = true
+		}
+		if pos != last {
+			last = pos
+			moved = ts
+		}
+		if stateDone && ts.Sub(moved) >= t.StableFor {
+			trial.WheelLatency = moved.Sub(t0)
+			trial.Overrun = last - start
+			return trial, nil
+		}
+		if ts.Sub(t0) >= t.Timeout {
+			return StopTrial{}, errNotStopped
+		}
+	}
+}
+
+// percentiles returns the summary statistics of d using the nearest
+// rank method. The order of elements in d is altered.
+func percentiles(d []time.Duration) Percentiles {
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p*float64(len(d)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return d[i]
+	}
+	return Percentiles{P50: rank(0.5), P90: rank(0.9), P99: rank(0.99), Max: d[len(d)-1]}
+}
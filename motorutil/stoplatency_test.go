@@ -0,0 +1,114 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// fakeStopMotor is a simulated motor that takes writeDelay to accept
+// a stop command, clears its running state stateDelay after accepting
+// the command and turns at one count per millisecond until wheelDelay
+// after accepting the command. Each sample of its state advances its
+// clock by a millisecond.
+type fakeStopMotor struct {
+	now        time.Time
+	stopped    time.Time
+	running    bool
+	writeDelay time.Duration
+	stateDelay time.Duration
+	wheelDelay time.Duration
+	pos        int
+}
+
+func (m *fakeStopMotor) clock() time.Time { return m.now }
+
+func (m *fakeStopMotor) sleep(d time.Duration) {
+	m.advance(d)
+}
+
+func (m *fakeStopMotor) advance(d time.Duration) {
+	for end := m.now.Add(d); m.now.Before(end); m.now = m.now.Add(time.Millisecond) {
+		if m.running || m.now.Sub(m.stopped) < m.wheelDelay {
+			m.pos++
+		}
+	}
+}
+
+func (m *fakeStopMotor) run(int) error { m.running = true; return nil }
+
+func (m *fakeStopMotor) stop() error {
+	m.advance(m.writeDelay)
+	m.running = false
+	m.stopped = m.now
+	return nil
+}
+
+func (m *fakeStopMotor) state() (ev3dev.MotorState, error) {
+	m.advance(time.Millisecond)
+	if m.running || m.now.Sub(m.stopped) < m.stateDelay {
+		return ev3dev.Running, nil
+	}
+	return 0, nil
+}
+
+func (m *fakeStopMotor) position() (int, error) { return m.pos, nil }
+
+func TestStopTest(t *testing.T) {
+	m := &fakeStopMotor{now: time.Unix(0, 0), writeDelay: 3 * time.Millisecond, stateDelay: 5 * time.Millisecond, wheelDelay: 20 * time.Millisecond}
+	test := StopTest{Speed: 500, Trials: 3, SpinUp: 100 * time.Millisecond, StableFor: 10 * time.Millisecond, Timeout: time.Second}
+	r, err := test.run(m, m.clock, m.sleep)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The latencies and overrun include the
+	// time taken to write the stop command.
+	want := StopTrial{StateLatency: 8 * time.Millisecond, WheelLatency: 23 * time.Millisecond, Overrun: 23, Samples: 30}
+	for i, got := range r.Trials {
+		if got != want {
+			t.Errorf("unexpected result for trial %d: got:%+v want:%+v", i, got, want)
+		}
+	}
+	wantState := Percentiles{P50: 8 * time.Millisecond, P90: 8 * time.Millisecond, P99: 8 * time.Millisecond, Max: 8 * time.Millisecond}
+	if r.StateLatency != wantState {
+		t.Errorf("unexpected state latency: got:%v want:%v", r.StateLatency, wantState)
+	}
+
+	m.wheelDelay = 2 * time.Second
+	_, err = test.run(m, m.clock, m.sleep)
+	if err != errNotStopped {
+		t.Errorf("unexpected error for motor that does not stop: got:%v want:%v", err, errNotStopped)
+	}
+
+	_, err = StopTest{Trials: 0, StableFor: time.Millisecond, Timeout: time.Second}.run(m, m.clock, m.sleep)
+	if err == nil {
+		t.Errorf("expected error for invalid trial count")
+	}
+	_, err = StopTest{Trials: 1, StableFor: time.Second, Timeout: time.Second}.run(m, m.clock, m.sleep)
+	if err == nil {
+		t.Errorf("expected error for invalid timing")
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	d := make([]time.Duration, 100)
+	for i := range d {
+		d[len(d)-1-i] = time.Duration(i+1) * time.Millisecond
+	}
+	got := percentiles(d)
+	want := Percentiles{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected percentiles: got:%v want:%v", got, want)
+	}
+	got = percentiles([]time.Duration{3})
+	want = Percentiles{P50: 3, P90: 3, P99: 3, Max: 3}
+	if got != want {
+		t.Errorf("unexpected percentiles for single value: got:%v want:%v", got, want)
+	}
+}
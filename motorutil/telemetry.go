@@ -0,0 +1,168 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// Recorder samples the position, speed, duty cycle and state of a set of
+// tacho motors at a fixed interval and writes them to an io.Writer as CSV.
+// The first row is a header,
+//
+//	time,motor,position,speed,duty_cycle,state
+//
+// followed by a row for each motor at each sample. The time column holds
+// the seconds elapsed since the recording started, and the motor column
+// holds the motor's name, for example "motor0". States are formatted as
+// described for ev3dev.MotorState.
+//
+// Rows are flushed to the writer after each sample, so a recording can be
+// followed while it is being made. Samples that are missed because reading
+// the motors took longer than the interval are skipped.
+type Recorder struct {
+	w        *csv.Writer
+	interval time.Duration
+	motors   []telemetryMotor
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+	err  error
+}
+
+// telemetryMotor is the motor interface used by a Recorder.
+// It is implemented by tachoMotor.
+type telemetryMotor interface {
+	String() string
+	position() (int, error)
+	speed() (int, error)
+	dutyCycle() (int, error)
+	state() (ev3dev.MotorState, error)
+}
+
+func (t tachoMotor) String() string          { return t.m.String() }
+func (t tachoMotor) dutyCycle() (int, error) { return t.m.DutyCycle() }
+
+// NewRecorder returns a Recorder that writes samples of motors taken every
+// interval to w.
+func NewRecorder(w io.Writer, interval time.Duration, motors ...*ev3dev.TachoMotor) *Recorder {
+	tm := make([]telemetryMotor, len(motors))
+	for i, m := range motors {
+		tm[i] = tachoMotor{m}
+	}
+	return newRecorder(w, interval, tm)
+}
+
+func newRecorder(w io.Writer, interval time.Duration, motors []telemetryMotor) *Recorder {
+	return &Recorder{w: csv.NewWriter(w), interval: interval, motors: motors}
+}
+
+// errRecording is returned when Start is called
+// on a Recorder that is already recording.
+var errRecording = errors.New("motorutil: recorder already started")
+
+// Start writes the header and starts recording on a new goroutine.
+// A Recorder may be started again after it has been stopped, in which
+// case a new header is written and the time column restarts from zero.
+func (r *Recorder) Start() error {
+	if r.interval <= 0 {
+		return fmt.Errorf("motorutil: invalid recording interval: %v (must be positive)", r.interval)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return errRecording
+	}
+	r.w.Write([]string{"time", "motor", "position", "speed", "duty_cycle", "state"})
+	r.w.Flush()
+	err := r.w.Error()
+	if err != nil {
+		return err
+	}
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	r.err = nil
+	go r.run(r.stop, r.done)
+	return nil
+}
+
+// Stop stops recording and returns the first error that occurred while
+// sampling the motors or writing the samples. Recording ends on the first
+// error, so a non-nil error indicates that the recording is incomplete.
+// Stop returns nil if the Recorder is not recording.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop == nil {
+		return nil
+	}
+	close(r.stop)
+	<-r.done
+	r.stop, r.done = nil, nil
+	return r.err
+}
+
+// run samples the motors until stop is closed, closing
+// done when it returns. An error is recorded in r.err.
+func (r *Recorder) run(stop, done chan struct{}) {
+	defer close(done)
+	start := time.Now()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		err := r.sample(time.Since(start))
+		if err != nil {
+			r.err = err
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// sample writes a row for each motor at the elapsed time t.
+func (r *Recorder) sample(t time.Duration) error {
+	ts := strconv.FormatFloat(t.Seconds(), 'f', 3, 64)
+	for _, m := range r.motors {
+		pos, err := m.position()
+		if err != nil {
+			return err
+		}
+		speed, err := m.speed()
+		if err != nil {
+			return err
+		}
+		duty, err := m.dutyCycle()
+		if err != nil {
+			return err
+		}
+		stat, err := m.state()
+		if err != nil {
+			return err
+		}
+		r.w.Write([]string{
+			ts,
+			m.String(),
+			strconv.Itoa(pos),
+			strconv.Itoa(speed),
+			strconv.Itoa(duty),
+			stat.String(),
+		})
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
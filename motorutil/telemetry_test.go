@@ -0,0 +1,105 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package motorutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ev3go/ev3dev"
+)
+
+// fakeTelemetryMotor is a telemetryMotor that returns fixed values.
+type fakeTelemetryMotor struct {
+	name          string
+	pos, spd, dty int
+	stat          ev3dev.MotorState
+	err           error
+}
+
+func (m fakeTelemetryMotor) String() string                    { return m.name }
+func (m fakeTelemetryMotor) position() (int, error)            { return m.pos, m.err }
+func (m fakeTelemetryMotor) speed() (int, error)               { return m.spd, nil }
+func (m fakeTelemetryMotor) dutyCycle() (int, error)           { return m.dty, nil }
+func (m fakeTelemetryMotor) state() (ev3dev.MotorState, error) { return m.stat, nil }
+
+func TestRecorder(t *testing.T) {
+	var buf bytes.Buffer
+	r := newRecorder(&buf, 5*time.Millisecond, []telemetryMotor{
+		fakeTelemetryMotor{name: "motor0", pos: 360, spd: 500, dty: 48, stat: ev3dev.Running},
+		fakeTelemetryMotor{name: "motor1", pos: -20, spd: 0, dty: 0, stat: ev3dev.Running | ev3dev.Stalled},
+	})
+	err := r.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Start(); err != errRecording {
+		t.Errorf("unexpected error starting twice: got:%v want:%v", err, errRecording)
+	}
+	time.Sleep(30 * time.Millisecond)
+	err = r.Stop()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.Stop(); err != nil {
+		t.Errorf("unexpected error stopping twice: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read recording: %v", err)
+	}
+	if want := []string{"time", "motor", "position", "speed", "duty_cycle", "state"}; !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("unexpected header: got:%q want:%q", rows[0], want)
+	}
+	rows = rows[1:]
+	if len(rows) < 4 || len(rows)%2 != 0 {
+		t.Fatalf("unexpected number of rows: %d", len(rows))
+	}
+	last := -1.0
+	for i, row := range rows {
+		ts, err := strconv.ParseFloat(row[0], 64)
+		if err != nil {
+			t.Errorf("invalid time in row %d: %q", i, row[0])
+		}
+		if i%2 == 0 {
+			if ts <= last {
+				t.Errorf("time not increasing at row %d: %v after %v", i, ts, last)
+			}
+			last = ts
+		} else if row[0] != rows[i-1][0] {
+			t.Errorf("rows of a sample have different times: %q and %q", rows[i-1][0], row[0])
+		}
+		want := []string{"motor0", "360", "500", "48", "running"}
+		if i%2 != 0 {
+			want = []string{"motor1", "-20", "0", "0", "running|stalled"}
+		}
+		if !reflect.DeepEqual(row[1:], want) {
+			t.Errorf("unexpected row %d: got:%q want:%q", i, row[1:], want)
+		}
+	}
+
+	errTest := errors.New("test error")
+	buf.Reset()
+	r = newRecorder(&buf, 5*time.Millisecond, []telemetryMotor{fakeTelemetryMotor{name: "motor0", err: errTest}})
+	err = r.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := r.Stop(); err != errTest {
+		t.Errorf("unexpected error: got:%v want:%v", err, errTest)
+	}
+
+	r = newRecorder(&buf, 0, nil)
+	if err := r.Start(); err == nil {
+		t.Error("expected error for zero interval")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+// Must panics if err is not nil. It is intended for short programs that
+// cannot usefully handle errors, for example
+//
+//	ev3dev.Must(m.SetSpeedSetpoint(500).Command(ev3dev.CommandRunForever).Err())
+func Must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Must panics with the error state of the TachoMotor if it is set and
+// otherwise returns the receiver. Must ends a fluent call chain at the
+// point an error occurs in programs that do not check errors:
+//
+//	m.SetSpeedSetpoint(500).Command(ev3dev.CommandRunForever).Must()
+func (m *TachoMotor) Must() *TachoMotor {
+	Must(m.Err())
+	return m
+}
+
+// Must panics with the error state of the DCMotor if it is set and
+// otherwise returns the receiver. See TachoMotor.Must for details.
+func (m *DCMotor) Must() *DCMotor {
+	Must(m.Err())
+	return m
+}
+
+// Must panics with the error state of the ServoMotor if it is set and
+// otherwise returns the receiver. See TachoMotor.Must for details.
+func (m *ServoMotor) Must() *ServoMotor {
+	Must(m.Err())
+	return m
+}
+
+// Must panics with the error state of the LinearActuator if it is set and
+// otherwise returns the receiver. See TachoMotor.Must for details.
+func (m *LinearActuator) Must() *LinearActuator {
+	Must(m.Err())
+	return m
+}
@@ -0,0 +1,42 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "testing"
+
+func TestMust(t *testing.T) {
+	panics := func(fn func()) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = r.(error)
+			}
+		}()
+		fn()
+		return nil
+	}
+
+	if err := panics(func() { Must(nil) }); err != nil {
+		t.Errorf("unexpected panic: %v", err)
+	}
+	if err := panics(func() { Must(ErrTornRead) }); err != ErrTornRead {
+		t.Errorf("unexpected panic: got:%v want:%v", err, ErrTornRead)
+	}
+
+	m := &TachoMotor{id: 0}
+	if err := panics(func() {
+		if got := m.Must(); got != m {
+			t.Error("receiver not returned")
+		}
+	}); err != nil {
+		t.Errorf("unexpected panic: %v", err)
+	}
+	m.err = ErrTornRead
+	if err := panics(func() { m.Must() }); err != ErrTornRead {
+		t.Errorf("unexpected panic: got:%v want:%v", err, ErrTornRead)
+	}
+	if m.err != nil {
+		t.Errorf("sticky error not cleared: %v", m.err)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Sensor multiplexers such as the mindsensors EV3 SensorMux are I2C
+// sensors that provide lego-ports for the sensors attached to them. The
+// ports of a multiplexer have addresses formed from the multiplexer's
+// address and the port name, for example "ev3-ports:in1:i2c80:mux2" for
+// the second port of a multiplexer at I2C address 80 on input port 1.
+//
+// The functions in this package that find a device for a port accept the
+// short form of these addresses without the I2C element, for example
+// "ev3-ports:in1:mux2", so that programs do not need to know the I2C
+// address of the multiplexer.
+
+// MuxPorts returns the lego-ports provided by the sensor multiplexer mux,
+// ordered by address.
+func MuxPorts(mux *Sensor) ([]*LegoPort, error) {
+	addr, err := AddressOf(mux)
+	if err != nil {
+		return nil, err
+	}
+	d := (*LegoPort)(nil)
+	names, err := devicesIn(d.Path())
+	if err != nil {
+		return nil, err
+	}
+	devices, err := sortedDevices(names, d.Type())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ports []*LegoPort
+		addrs = make(map[*LegoPort]string)
+	)
+	for _, device := range devices {
+		b, err := probeAttributeFor(d, device.name, address)
+		if os.IsNotExist(cause(err)) {
+			// If the port disappeared
+			// try the next one.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		a := string(chomp(b))
+		if !strings.HasPrefix(a, addr+":mux") {
+			continue
+		}
+		var p LegoPort
+		err = p.setID(device.id)
+		if err != nil {
+			return nil, err
+		}
+		setStrictFinalizer(&p)
+		ports = append(ports, &p)
+		addrs[&p] = a
+	}
+	sort.Slice(ports, func(i, j int) bool { return addrs[ports[i]] < addrs[ports[j]] })
+	return ports, nil
+}
+
+// ShortMuxAddress returns the short form of a multiplexer port address,
+// removing the I2C element before the mux element. Addresses that are not
+// multiplexer port addresses are returned unaltered.
+func ShortMuxAddress(addr string) string {
+	return string(shortMuxAddress([]byte(addr)))
+}
+
+// addressMatches returns whether the device address addr matches the
+// requested port, either exactly or by the short form of a multiplexer
+// port address.
+func addressMatches(port, addr []byte) bool {
+	if bytes.Equal(port, addr) {
+		return true
+	}
+	if !bytes.Contains(port, []byte(":mux")) {
+		return false
+	}
+	return bytes.Equal(port, shortMuxAddress(addr))
+}
+
+// shortMuxAddress returns addr with the I2C element of a multiplexer
+// port address removed.
+func shortMuxAddress(addr []byte) []byte {
+	i := bytes.LastIndex(addr, []byte(":mux"))
+	if i < 0 {
+		return addr
+	}
+	j := bytes.LastIndex(addr[:i], []byte(":i2c"))
+	if j < 0 || j+len(":i2c") == i {
+		return addr
+	}
+	for _, c := range addr[j+len(":i2c") : i] {
+		if c < '0' || '9' < c {
+			return addr
+		}
+	}
+	short := make([]byte, 0, len(addr)-(i-j))
+	short = append(short, addr[:j]...)
+	return append(short, addr[i:]...)
+}
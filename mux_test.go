@@ -0,0 +1,109 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMux(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	sensor := func(addr, driver string) map[string]string {
+		return map[string]string{
+			address:         addr,
+			driverName:      driver,
+			firmwareVersion: "",
+			commands:        "",
+			modes:           "MUX",
+			mode:            "MUX",
+			decimals:        "0",
+			numValues:       "0",
+			units:           "",
+			binDataFormat:   "u8",
+		}
+	}
+	port := func(addr string) map[string]string {
+		return map[string]string{
+			address:    addr,
+			driverName: "ms-ev3-smux-port",
+			modes:      "uart analog",
+			mode:       "uart",
+		}
+	}
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"):   sensor("ev3-ports:in1:i2c80", "ms-ev3-smux"),
+		filepath.Join(SensorPath, "sensor1"):   sensor("ev3-ports:in1:i2c80:mux2", "lego-ev3-touch"),
+		filepath.Join(LegoPortPath, "port0"):   port("ev3-ports:in1"),
+		filepath.Join(LegoPortPath, "port1"):   port("ev3-ports:in1:i2c80:mux2"),
+		filepath.Join(LegoPortPath, "port2"):   port("ev3-ports:in1:i2c80:mux1"),
+		filepath.Join(LegoPortPath, "port3"):   port("ev3-ports:in2:i2c81:mux1"),
+		filepath.Join(LegoPortPath, "port10"):  port("ev3-ports:in1:i2c80:mux3"),
+		filepath.Join(LegoPortPath, "port100"): port("ev3-ports:in1:i2c8"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	mux, err := SensorFor("ev3-ports:in1:i2c80", "ms-ev3-smux")
+	if err != nil {
+		t.Fatalf("unexpected error finding mux: %v", err)
+	}
+	defer mux.Close()
+	ports, err := MuxPorts(mux)
+	if err != nil {
+		t.Fatalf("unexpected error finding mux ports: %v", err)
+	}
+	var got []string
+	for _, p := range ports {
+		got = append(got, p.String())
+	}
+	want := []string{"port2", "port1", "port10"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected mux ports: got:%v want:%v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("unexpected mux ports: got:%v want:%v", got, want)
+			break
+		}
+	}
+
+	s, err := SensorFor("ev3-ports:in1:mux2", "lego-ev3-touch")
+	if err != nil {
+		t.Fatalf("unexpected error finding sensor by short mux address: %v", err)
+	}
+	defer s.Close()
+	if s.String() != "sensor1" {
+		t.Errorf("unexpected sensor for short mux address: got:%s want:sensor1", s)
+	}
+	_, err = SensorFor("ev3-ports:in1:mux1", "lego-ev3-touch")
+	if err == nil {
+		t.Error("expected error for empty mux port")
+	}
+}
+
+func TestShortMuxAddress(t *testing.T) {
+	for _, test := range []struct {
+		addr, want string
+	}{
+		{addr: "ev3-ports:in1:i2c80:mux2", want: "ev3-ports:in1:mux2"},
+		{addr: "ev3-ports:in1:mux2", want: "ev3-ports:in1:mux2"},
+		{addr: "ev3-ports:in1:i2c80", want: "ev3-ports:in1:i2c80"},
+		{addr: "ev3-ports:in1:i2c:mux2", want: "ev3-ports:in1:i2c:mux2"},
+		{addr: "ev3-ports:in1:i2cx:mux2", want: "ev3-ports:in1:i2cx:mux2"},
+		{addr: "ev3-ports:in1", want: "ev3-ports:in1"},
+	} {
+		if got := ShortMuxAddress(test.addr); got != test.want {
+			t.Errorf("unexpected short address for %q: got:%q want:%q", test.addr, got, test.want)
+		}
+	}
+}
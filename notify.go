@@ -0,0 +1,197 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NotifyAtPosition returns a channel that receives a value when the
+// position of the TachoMotor crosses pos or is found to be equal to pos.
+// The channel is closed after the value is sent, or without a value being
+// sent if ctx is done, the TachoMotor is closed or the position can no
+// longer be read before pos is reached. A receive on the channel therefore
+// reports whether the threshold was reached:
+//
+//	_, ok := <-m.NotifyAtPosition(ctx, 720)
+//
+// The positions of the TachoMotor for all pending notifications are read
+// by a single goroutine that polls the position every 5ms while any
+// notification is pending. The goroutine does not use the TachoMotor's
+// error state, so the TachoMotor may continue to be used.
+func (m *TachoMotor) NotifyAtPosition(ctx context.Context, pos int) <-chan struct{} {
+	return notifierOf(&m.notifier, m, &m.onClose).add(ctx, pos)
+}
+
+// NotifyAtPosition returns a channel that receives a value when the
+// position of the LinearActuator crosses pos or is found to be equal to
+// pos. See TachoMotor.NotifyAtPosition for details.
+func (m *LinearActuator) NotifyAtPosition(ctx context.Context, pos int) <-chan struct{} {
+	return notifierOf(&m.notifier, m, &m.onClose).add(ctx, pos)
+}
+
+// positionPoll is the interval between position
+// reads made for NotifyAtPosition.
+const positionPoll = 5 * time.Millisecond
+
+// notifierLock guards the creation of position notifiers.
+var notifierLock sync.Mutex
+
+// notifierOf returns the position notifier held in *n, creating
+// it for the device d with close hooks held in hooks if needed.
+func notifierOf(n **positionNotifier, d Device, hooks *[]*closeHook) *positionNotifier {
+	notifierLock.Lock()
+	defer notifierLock.Unlock()
+	if *n == nil {
+		*n = &positionNotifier{dev: errlessDevice{d}, hooks: hooks}
+	}
+	return *n
+}
+
+// positionNotifier polls the position of a device on behalf of
+// all the pending thresholds registered with it. Its goroutine
+// runs only while thresholds are pending.
+type positionNotifier struct {
+	dev   Device
+	hooks *[]*closeHook
+
+	mu      sync.Mutex
+	pending []*threshold
+
+	// stop and done are closed to stop
+	// the running polling goroutine and
+	// by the goroutine when it returns.
+	stop, done chan struct{}
+}
+
+// threshold is a pending position notification.
+type threshold struct {
+	ctx context.Context
+	pos int
+
+	// side is the sign of the difference
+	// between the first position read and
+	// pos. It is zero until the first read.
+	side int
+
+	c chan struct{}
+}
+
+// add registers a threshold at pos, starting the
+// polling goroutine if it is not running.
+func (n *positionNotifier) add(ctx context.Context, pos int) <-chan struct{} {
+	t := &threshold{ctx: ctx, pos: pos, c: make(chan struct{}, 1)}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.pending = append(n.pending, t)
+	if n.stop == nil {
+		n.stop = make(chan struct{})
+		n.done = make(chan struct{})
+		go n.run(n.stop, n.done, addCloseHook(n.hooks, n.close))
+	}
+	return t.c
+}
+
+// close abandons all pending thresholds and waits
+// for the polling goroutine to return.
+func (n *positionNotifier) close() {
+	n.mu.Lock()
+	done := n.done
+	n.abandon()
+	n.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+// abandon closes the channels of all pending thresholds without
+// sending and signals the polling goroutine to stop. It must be
+// called with n.mu held.
+func (n *positionNotifier) abandon() {
+	for _, t := range n.pending {
+		close(t.c)
+	}
+	n.pending = nil
+	if n.stop != nil {
+		close(n.stop)
+		n.stop, n.done = nil, nil
+	}
+}
+
+// run polls the position of the device until no threshold is
+// pending or stop is closed, calling unhook and closing done
+// when it returns.
+func (n *positionNotifier) run(stop, done chan struct{}, unhook func()) {
+	defer close(done)
+	defer unhook()
+	ticker := time.NewTicker(positionPoll)
+	defer ticker.Stop()
+	for {
+		pos, err := intFrom(attributeOf(n.dev, position))
+		n.mu.Lock()
+		select {
+		case <-stop:
+			n.mu.Unlock()
+			return
+		default:
+		}
+		if err != nil {
+			n.abandon()
+			n.mu.Unlock()
+			return
+		}
+		n.check(pos)
+		if len(n.pending) == 0 {
+			n.stop, n.done = nil, nil
+			n.mu.Unlock()
+			return
+		}
+		n.mu.Unlock()
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// check notifies the pending thresholds that have been reached at
+// pos and drops those whose contexts are done. It must be called
+// with n.mu held.
+func (n *positionNotifier) check(pos int) {
+	pending := n.pending[:0]
+	for _, t := range n.pending {
+		if t.ctx.Err() != nil {
+			close(t.c)
+			continue
+		}
+		side := sign(pos - t.pos)
+		if side == 0 || (t.side != 0 && side != t.side) {
+			t.c <- struct{}{}
+			close(t.c)
+			continue
+		}
+		t.side = side
+		pending = append(pending, t)
+	}
+	for i := len(pending); i < len(n.pending); i++ {
+		n.pending[i] = nil
+	}
+	n.pending = pending
+}
+
+// sign returns the sign of v.
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotifyAtPosition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, TachoMotorPath, "motor0", position)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	setPosition := func(pos int) {
+		// Write atomically to avoid the notifier
+		// reading a partially written position.
+		tmp := path + ".tmp"
+		err := ioutil.WriteFile(tmp, []byte(strconv.Itoa(pos)+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write position: %v", err)
+		}
+		err = os.Rename(tmp, path)
+		if err != nil {
+			t.Fatalf("failed to rename position: %v", err)
+		}
+	}
+	setPosition(0)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	wait := func(c <-chan struct{}, reached bool) {
+		t.Helper()
+		select {
+		case _, ok := <-c:
+			if ok != reached {
+				t.Errorf("unexpected notification: got:%t want:%t", ok, reached)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for notification")
+		}
+	}
+	pending := func(c <-chan struct{}) {
+		t.Helper()
+		select {
+		case <-c:
+			t.Error("unexpected notification")
+		case <-time.After(10 * positionPoll):
+		}
+	}
+
+	m := &TachoMotor{id: 0}
+	// A sticky error on the handle must
+	// not affect the notifier.
+	m.err = ErrTornRead
+
+	ctx := context.Background()
+	wait(m.NotifyAtPosition(ctx, 0), true)
+
+	forward := m.NotifyAtPosition(ctx, 100)
+	backward := m.NotifyAtPosition(ctx, -100)
+	cctx, cancel := context.WithCancel(ctx)
+	cancelled := m.NotifyAtPosition(cctx, 200)
+	pending(forward)
+	cancel()
+	wait(cancelled, false)
+
+	setPosition(150)
+	wait(forward, true)
+	pending(backward)
+	setPosition(-150)
+	wait(backward, true)
+
+	closed := m.NotifyAtPosition(ctx, 1000)
+	pending(closed)
+	m.Close()
+	wait(closed, false)
+
+	if m.err != ErrTornRead {
+		t.Errorf("handle error state altered: got:%v", m.err)
+	}
+
+	os.RemoveAll(filepath.Dir(path))
+	wait(m.NotifyAtPosition(ctx, 0), false)
+}
@@ -0,0 +1,177 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// FindOption is an option specifying the device
+// returned by the NewXxx device constructors.
+type FindOption func(*findOptions)
+
+// findOptions holds the search criteria set by FindOptions.
+type findOptions struct {
+	port      string
+	driver    string
+	timeout   time.Duration
+	exclusive bool
+}
+
+// WithPort specifies the ev3 port name of the device. If WithPort is not
+// given, the first device satisfying the driver is found.
+func WithPort(port string) FindOption {
+	return func(o *findOptions) { o.port = port }
+}
+
+// WithDriver specifies the driver of the device. The driver is a pattern
+// with the syntax of path.Match, so "lego-ev3-?-motor" matches both the
+// large and medium EV3 motors. If a port is also specified and the device
+// on the port does not match the driver, the handle is returned with a
+// DriverMismatch error as for the XxxFor functions. If WithDriver is not
+// given, any driver is matched.
+func WithDriver(driver string) FindOption {
+	return func(o *findOptions) { o.driver = driver }
+}
+
+// WithTimeout specifies the time to wait for a matching device to appear,
+// for example while a sensor is being detected after it is plugged in. The
+// devices are searched every 100ms until a matching device is found or the
+// timeout has elapsed. If WithTimeout is not given, the devices are searched
+// once.
+func WithTimeout(d time.Duration) FindOption {
+	return func(o *findOptions) { o.timeout = d }
+}
+
+// WithExclusive specifies that the device is claimed by the returned
+// handle. Devices claimed by another handle are skipped when searching
+// and a device on a claimed port is not returned. The claim is held
+// until the returned handle is closed. If WithExclusive is not given,
+// the device is not claimed.
+func WithExclusive() FindOption {
+	return func(o *findOptions) { o.exclusive = true }
+}
+
+// findPoll is the interval between device searches made
+// by the NewXxx device constructors when waiting.
+const findPoll = 100 * time.Millisecond
+
+// NewTachoMotor returns a TachoMotor for the device specified by opts. If
+// no options are given, the first tacho-motor is returned. As for
+// TachoMotorFor, if a port is specified and the driver of the device on
+// the port does not match, the TachoMotor is returned with a
+// DriverMismatch error.
+func NewTachoMotor(opts ...FindOption) (*TachoMotor, error) {
+	var m TachoMotor
+	ok, err := findWith(&m, opts)
+	if !ok {
+		return nil, err
+	}
+	return &m, err
+}
+
+// NewDCMotor returns a DCMotor for the device specified by opts.
+// See NewTachoMotor for details.
+func NewDCMotor(opts ...FindOption) (*DCMotor, error) {
+	var m DCMotor
+	ok, err := findWith(&m, opts)
+	if !ok {
+		return nil, err
+	}
+	return &m, err
+}
+
+// NewServoMotor returns a ServoMotor for the device specified by opts.
+// See NewTachoMotor for details.
+func NewServoMotor(opts ...FindOption) (*ServoMotor, error) {
+	var m ServoMotor
+	ok, err := findWith(&m, opts)
+	if !ok {
+		return nil, err
+	}
+	return &m, err
+}
+
+// NewLinearActuator returns a LinearActuator for the device specified by
+// opts. See NewTachoMotor for details.
+func NewLinearActuator(opts ...FindOption) (*LinearActuator, error) {
+	var m LinearActuator
+	ok, err := findWith(&m, opts)
+	if !ok {
+		return nil, err
+	}
+	return &m, err
+}
+
+// NewSensor returns a Sensor for the device specified by opts. See
+// NewTachoMotor for details.
+func NewSensor(opts ...FindOption) (*Sensor, error) {
+	var s Sensor
+	ok, err := findWith(&s, opts)
+	if !ok {
+		return nil, err
+	}
+	return &s, err
+}
+
+// NewLegoPort returns a LegoPort for the device specified by opts. See
+// NewTachoMotor for details.
+func NewLegoPort(opts ...FindOption) (*LegoPort, error) {
+	var p LegoPort
+	ok, err := findWith(&p, opts)
+	if !ok {
+		return nil, err
+	}
+	return &p, err
+}
+
+// findWith initializes d with the device specified by opts, returning
+// whether a device was found. A device may be found with an error if its
+// driver does not match.
+func findWith(d idSetter, opts []FindOption) (ok bool, err error) {
+	o := findOptions{driver: "*"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	_, err = path.Match(o.driver, "")
+	if err != nil {
+		return false, fmt.Errorf("ev3dev: invalid driver pattern %q: %w", o.driver, err)
+	}
+
+	end := time.Now().Add(o.timeout)
+	var id int
+	for {
+		id, err = deviceIDMatching(o.port, o.driver, true, nilOf(d), -1)
+		if id != -1 || !time.Now().Before(end) {
+			break
+		}
+		time.Sleep(findPoll)
+	}
+	if id == -1 {
+		return false, err
+	}
+	_err := d.setID(id)
+	if _err != nil {
+		return true, _err
+	}
+	if o.exclusive {
+		addr, _err := AddressOf(d)
+		if _err != nil {
+			return false, _err
+		}
+		// The claim is checked again since another
+		// handle may have claimed the device after
+		// the search.
+		if inUse(d, []byte(addr)) {
+			return false, fmt.Errorf("ev3dev: port %s in use", addr)
+		}
+	}
+	if u, ok := d.(unreader); ok {
+		setStrictFinalizer(u)
+	}
+	return true, err
+}
@@ -0,0 +1,128 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewTachoMotor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+		filepath.Join(TachoMotorPath, "motor1"): tachoMotorAttrs("ev3-ports:outB", "lego-ev3-m-motor"),
+		// The staged motor is moved into place
+		// while NewTachoMotor is waiting.
+		"staged/motor2": tachoMotorAttrs("ev3-ports:outC", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	for _, test := range []struct {
+		opts    []FindOption
+		wantID  int
+		wantErr bool
+	}{
+		{opts: nil, wantID: 0},
+		{opts: []FindOption{WithDriver("lego-ev3-m-motor")}, wantID: 1},
+		{opts: []FindOption{WithPort("ev3-ports:outB"), WithDriver("lego-ev3-?-motor")}, wantID: 1},
+		{opts: []FindOption{WithPort("ev3-ports:outA"), WithDriver("lego-nxt-motor")}, wantID: 0, wantErr: true},
+		{opts: []FindOption{WithDriver("lego-nxt-motor")}, wantID: -1, wantErr: true},
+		{opts: []FindOption{WithDriver("[")}, wantID: -1, wantErr: true},
+	} {
+		m, err := NewTachoMotor(test.opts...)
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if test.wantID == -1 {
+			if m != nil {
+				t.Errorf("unexpected handle: %v", m)
+			}
+			continue
+		}
+		if m == nil || m.id != test.wantID {
+			t.Errorf("unexpected handle: got:%v want:motor%d", m, test.wantID)
+		}
+	}
+	_, err = NewTachoMotor(WithPort("ev3-ports:outA"), WithDriver("lego-nxt-motor"))
+	if _, ok := err.(DriverMismatch); !ok {
+		t.Errorf("unexpected error type: %T", err)
+	}
+
+	go func() {
+		time.Sleep(2 * findPoll)
+		os.Rename(filepath.Join(dir, "staged/motor2"), filepath.Join(dir, TachoMotorPath, "motor2"))
+	}()
+	m, err := NewTachoMotor(WithPort("ev3-ports:outC"), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error waiting for motor: %v", err)
+	}
+	if m.id != 2 {
+		t.Errorf("unexpected handle: got:%v want:motor2", m)
+	}
+}
+
+func TestNewTachoMotorExclusive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+		filepath.Join(TachoMotorPath, "motor1"): tachoMotorAttrs("ev3-ports:outB", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m0, err := NewTachoMotor(WithExclusive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer m0.Close()
+	if m0.id != 0 {
+		t.Errorf("unexpected handle: got:%v want:motor0", m0)
+	}
+
+	// A non-exclusive search skips the
+	// claimed motor but makes no claim.
+	m, err := NewTachoMotor()
+	if err != nil || m.id != 1 {
+		t.Errorf("unexpected result for non-exclusive search: got:(%v, %v) want:(motor1, nil)", m, err)
+	}
+
+	m1, err := NewTachoMotor(WithExclusive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m1.id != 1 {
+		t.Errorf("unexpected handle: got:%v want:motor1", m1)
+	}
+	_, err = NewTachoMotor(WithExclusive())
+	if err == nil {
+		t.Error("expected error with all motors claimed")
+	}
+	_, err = NewTachoMotor(WithPort("ev3-ports:outB"), WithExclusive())
+	if err == nil {
+		t.Error("expected error for claimed port")
+	}
+
+	m1.Close()
+	m1, err = NewTachoMotor(WithPort("ev3-ports:outB"), WithExclusive())
+	if err != nil {
+		t.Fatalf("unexpected error after release: %v", err)
+	}
+	m1.Close()
+}
@@ -0,0 +1,183 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+// RunProtected calls fn and returns its error. If fn panics, the panic is
+// recovered and returned as a PanicError. RunProtected is intended to wrap
+// the body of a robot program's main function so that the robot is left in
+// a safe state when the program fails.
+//
+// The trigger and brightness of each LED are recorded before fn is called.
+// If fn panics or returns a non-nil error, all attached motors are stopped
+// using StopAll, the LEDs are restored to their recorded state and then
+// each restore function is called in order. Restore functions can be used
+// to restore other state, for example clearing the display. Errors during
+// clean up are ignored.
+//
+// If fn returns without error, no clean up is performed.
+func RunProtected(fn func() error, restore ...func()) (err error) {
+	leds := ledStates()
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = PanicError{Value: r, Stack: debug.Stack()}
+		}
+		if err == nil {
+			return
+		}
+		StopAll()
+		restoreLEDs(leds)
+		for _, f := range restore {
+			func() {
+				// Ensure that a failing restore
+				// function does not prevent the
+				// remaining functions from running.
+				defer func() { recover() }()
+				f()
+			}()
+		}
+	}()
+	return fn()
+}
+
+// PanicError is the error returned by RunProtected when the function it
+// calls panics.
+type PanicError struct {
+	// Value is the value
+	// passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace
+	// of the panicking goroutine.
+	Stack []byte
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("ev3dev: recovered panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// StopAll stops all attached tacho motors, linear actuators and DC motors
+// and floats all attached servo motors. Every motor device present in the
+// sysfs motor classes is stopped, whether or not the program holds a handle
+// for it. Motors with a stop action registered by WatchMotor are stopped
+// using that action. Motors that are removed while StopAll runs are ignored.
+// StopAll attempts to stop every motor and returns the first error
+// encountered.
+func StopAll() error {
+	var first error
+	note := func(err error) {
+		if err != nil && !os.IsNotExist(cause(err)) && first == nil {
+			first = err
+		}
+	}
+	for _, m := range motorDevices() {
+		if _, ok := m.(*ServoMotor); ok {
+			note(setAttributeOf(m, command, string(CommandFloat)))
+			continue
+		}
+		if action := watchedAction(m); action != "" {
+			// The action is not validated against the
+			// motor's stop actions since the motor must
+			// be stopped even if the action is invalid.
+			note(setAttributeOf(m, stopAction, string(action)))
+		}
+		note(setAttributeOf(m, command, string(CommandStop)))
+	}
+	return first
+}
+
+// motorDevices returns bare handles for all motor devices present in
+// the sysfs motor classes. Unlike attachedMotors, motorDevices does not
+// read the devices' attributes, so a device that cannot be fully read is
+// still returned.
+func motorDevices() []Device {
+	var motors []Device
+	for _, class := range []func(id int) Device{
+		func(id int) Device { return &TachoMotor{id: id} },
+		func(id int) Device { return &LinearActuator{id: id} },
+		func(id int) Device { return &DCMotor{id: id} },
+		func(id int) Device { return &ServoMotor{id: id} },
+	} {
+		d := class(-1)
+		names, err := devicesIn(d.Path())
+		if err != nil {
+			continue
+		}
+		devices, err := sortedDevices(names, d.Type())
+		if err != nil {
+			continue
+		}
+		for _, dev := range devices {
+			motors = append(motors, class(dev.id))
+		}
+	}
+	return motors
+}
+
+// ledName is a fmt.Stringer LED name.
+type ledName string
+
+func (n ledName) String() string { return string(n) }
+
+// ledState is the recorded state of an LED.
+type ledState struct {
+	led        *LED
+	trigger    string
+	brightness int
+}
+
+// ledStates returns the current state of all LEDs. LEDs
+// whose state cannot be read are not included.
+func ledStates() []ledState {
+	names, err := devicesIn((*LED)(nil).Path())
+	if err != nil {
+		return nil
+	}
+	states := make([]ledState, 0, len(names))
+	for _, n := range names {
+		l := &LED{Name: ledName(n)}
+		trig, _, err := l.Trigger()
+		if err != nil {
+			continue
+		}
+		bright, err := l.Brightness()
+		if err != nil {
+			continue
+		}
+		states = append(states, ledState{led: l, trigger: trig, brightness: bright})
+	}
+	return states
+}
+
+// restoreLEDs restores the LEDs to the given states, returning
+// the first error encountered. The brightness of an LED is only
+// restored when it has no trigger since a trigger controls the
+// LED's brightness.
+func restoreLEDs(states []ledState) error {
+	var first error
+	for _, s := range states {
+		s.led.SetTrigger(s.trigger)
+		if s.trigger == "none" {
+			s.led.SetBrightness(s.brightness)
+		}
+		err := s.led.Err()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
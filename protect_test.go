@@ -0,0 +1,151 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunProtected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	errTest := errors.New("test error")
+	for _, test := range []struct {
+		name        string
+		fn          func() error
+		wantErr     error
+		wantPanic   interface{}
+		wantRestore bool
+	}{
+		{
+			name: "success",
+			fn:   func() error { return nil },
+		},
+		{
+			name:        "error",
+			fn:          func() error { return errTest },
+			wantErr:     errTest,
+			wantRestore: true,
+		},
+		{
+			name:        "panic",
+			fn:          func() error { panic("test panic") },
+			wantPanic:   "test panic",
+			wantRestore: true,
+		},
+		{
+			name:        "error panic",
+			fn:          func() error { panic(errTest) },
+			wantPanic:   errTest,
+			wantErr:     errTest,
+			wantRestore: true,
+		},
+	} {
+		var restored []int
+		err := RunProtected(test.fn,
+			func() { restored = append(restored, 1) },
+			func() { panic("restore panic") },
+			func() { restored = append(restored, 3) },
+		)
+		if test.wantPanic != nil {
+			p, ok := err.(PanicError)
+			if !ok {
+				t.Errorf("unexpected error type for %s: got:%T want:%T", test.name, err, p)
+				continue
+			}
+			if p.Value != test.wantPanic {
+				t.Errorf("unexpected panic value for %s: got:%v want:%v", test.name, p.Value, test.wantPanic)
+			}
+			if len(p.Stack) == 0 {
+				t.Errorf("missing stack for %s", test.name)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("unexpected unwrapped error for %s: got:%v want:%v", test.name, errors.Unwrap(err), test.wantErr)
+			}
+		} else if err != test.wantErr {
+			t.Errorf("unexpected error for %s: got:%v want:%v", test.name, err, test.wantErr)
+		}
+		if gotRestore := len(restored) != 0; gotRestore != test.wantRestore {
+			t.Errorf("unexpected restore for %s: got:%t want:%t", test.name, gotRestore, test.wantRestore)
+		}
+		if test.wantRestore && len(restored) != 2 {
+			t.Errorf("unexpected restore calls for %s: got:%v want:[1 3]", test.name, restored)
+		}
+	}
+}
+
+func TestRunProtectedCleanUp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	led := filepath.Join(LEDPath, "led0:green:brick-status")
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+		filepath.Join(TachoMotorPath, "motor1"): {address: "ev3-ports:outB", command: ""},
+		filepath.Join(ServoMotorPath, "motor2"): {address: "ev3-ports:outC", command: ""},
+		filepath.Join(DCMotorPath, "motor3"):    {address: "ev3-ports:outD", command: ""},
+		led:                                     {trigger: "[none] timer", brightness: "128", maxBrightness: "255"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	// motor0 is held by a handle from TachoMotorFor.
+	// The other motors are attached but not held.
+	m, err := TachoMotorFor("ev3-ports:outA", "lego-ev3-l-motor")
+	if err != nil {
+		t.Fatalf("unexpected error getting motor: %v", err)
+	}
+	defer m.Close()
+
+	err = RunProtected(func() error {
+		// Simulate the program changing the LED.
+		for attr, data := range map[string]string{trigger: "none [timer]", brightness: "0"} {
+			err := ioutil.WriteFile(filepath.Join(dir, led, attr), []byte(data+"\n"), 0644)
+			if err != nil {
+				t.Fatalf("failed to write attribute: %v", err)
+			}
+		}
+		panic("test panic")
+	})
+	if _, ok := err.(PanicError); !ok {
+		t.Fatalf("unexpected error: got:%v want:%T", err, PanicError{})
+	}
+
+	for _, test := range []struct {
+		path string
+		want string
+	}{
+		{path: filepath.Join(TachoMotorPath, "motor0", command), want: "stop"},
+		{path: filepath.Join(TachoMotorPath, "motor1", command), want: "stop"},
+		{path: filepath.Join(ServoMotorPath, "motor2", command), want: "float"},
+		{path: filepath.Join(DCMotorPath, "motor3", command), want: "stop"},
+		{path: filepath.Join(led, trigger), want: "none"},
+		{path: filepath.Join(led, brightness), want: "128"},
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, test.path))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", test.path, err)
+			continue
+		}
+		if got := strings.TrimSpace(string(b)); got != test.want {
+			t.Errorf("unexpected value for %s: got:%q want:%q", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDCMotorRampLimit(t *testing.T) {
+	const want = 10 * time.Second
+	for _, driver := range []string{"rcx-motor", "unknown-motor"} {
+		m := &DCMotor{id: 0, driver: driver}
+		if got := m.MaxRampSetpoint(); got != want {
+			t.Errorf("unexpected ramp limit for %s: got:%v want:%v", driver, got, want)
+		}
+		err := m.SetRampUpSetpoint(want + time.Millisecond).Err()
+		if err == nil || !strings.Contains(err.Error(), "must be in 0s-"+want.String()) {
+			t.Errorf("unexpected error for %s ramp up: %v", driver, err)
+		}
+		err = m.SetRampDownSetpoint(want + time.Millisecond).Err()
+		if err == nil || !strings.Contains(err.Error(), "must be in 0s-"+want.String()) {
+			t.Errorf("unexpected error for %s ramp down: %v", driver, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var readFileTests = []struct {
+	attr    string
+	size    int
+	content string
+
+	wantFallback bool
+}{
+	{attr: "short", content: "short content\n", wantFallback: false},
+	{attr: "long", content: strings.Repeat("x", 300) + "\n", wantFallback: true},
+	{attr: modes, content: strings.Repeat("MODE ", 80) + "\n", wantFallback: false},
+	{attr: trigger, content: strings.Repeat("[none] ", 500) + "\n", wantFallback: false},
+	{attr: "sized", size: 1024, content: strings.Repeat("y", 1000) + "\n", wantFallback: false},
+	{attr: "shrunk", size: 16, content: strings.Repeat("z", 32) + "\n", wantFallback: true},
+}
+
+func TestReadFileFastPath(t *testing.T) {
+	// Exercise the fast path using a real file system.
+	isTesting = false
+	defer func() { isTesting = true }()
+
+	var logBuf bytes.Buffer
+	log.SetOutput(&logBuf)
+	defer log.SetOutput(os.Stderr)
+
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, test := range readFileTests {
+		logBuf.Reset()
+		if test.size != 0 {
+			SetBufferSize(test.attr, test.size)
+		}
+
+		path := filepath.Join(dir, test.attr)
+		err := ioutil.WriteFile(path, []byte(test.content), 0644)
+		if err != nil {
+			t.Fatalf("failed to write attribute file: %v", err)
+		}
+
+		// Read twice to check that cached files are reused.
+		for i := 0; i < 2; i++ {
+			got, err := readFile(path)
+			if err != nil {
+				t.Errorf("unexpected error reading %s: %v", test.attr, err)
+			}
+			if string(got) != test.content {
+				t.Errorf("unexpected content for %s read %d: got:%q want:%q", test.attr, i, got, test.content)
+			}
+		}
+
+		fileRegLock.Lock()
+		f, ok := files[path]
+		if ok && f != nil {
+			f.Close()
+		}
+		delete(files, path)
+		fileRegLock.Unlock()
+		if !ok {
+			t.Errorf("expected file registry entry for %s", test.attr)
+		}
+		if gotFallback := f == nil; gotFallback != test.wantFallback {
+			t.Errorf("unexpected fallback for %s: got:%t want:%t", test.attr, gotFallback, test.wantFallback)
+		}
+		if gotLog := logBuf.Len() != 0; gotLog != test.wantFallback {
+			t.Errorf("unexpected fallback log for %s: %q", test.attr, logBuf.String())
+		}
+
+		if test.size != 0 {
+			SetBufferSize(test.attr, 0)
+		}
+	}
+}
+
+func TestBufferSize(t *testing.T) {
+	if got := BufferSize("unknown"); got != defaultBufferSize {
+		t.Errorf("unexpected default buffer size: got:%d want:%d", got, defaultBufferSize)
+	}
+	if got := BufferSize(trigger); got != 4096 {
+		t.Errorf("unexpected buffer size for %s: got:%d want:%d", trigger, got, 4096)
+	}
+
+	SetBufferSize("unknown", 1024)
+	if got := BufferSize("unknown"); got != 1024 {
+		t.Errorf("unexpected buffer size after set: got:%d want:%d", got, 1024)
+	}
+	SetBufferSize("unknown", 0)
+	if got := BufferSize("unknown"); got != defaultBufferSize {
+		t.Errorf("unexpected buffer size after reset: got:%d want:%d", got, defaultBufferSize)
+	}
+
+	// Resetting an attribute with a default
+	// size restores that size.
+	SetBufferSize(trigger, 512)
+	if got := BufferSize(trigger); got != 512 {
+		t.Errorf("unexpected buffer size for %s after set: got:%d want:%d", trigger, got, 512)
+	}
+	SetBufferSize(trigger, 0)
+	if got := BufferSize(trigger); got != 4096 {
+		t.Errorf("unexpected buffer size for %s after reset: got:%d want:%d", trigger, got, 4096)
+	}
+
+	for _, n := range []int{-1, 4097} {
+		err := SetBufferSize("unknown", n)
+		if err == nil {
+			t.Errorf("expected error for buffer size %d", n)
+		}
+	}
+	if got := BufferSize("unknown"); got != defaultBufferSize {
+		t.Errorf("unexpected buffer size after invalid set: got:%d want:%d", got, defaultBufferSize)
+	}
+}
+
+func TestReadStable(t *testing.T) {
+	for _, test := range []struct {
+		size      int
+		reads     []string
+		want      string
+		wantErr   error
+		wantReads int
+	}{
+		{size: 2, reads: []string{"a", "b"}, want: "a", wantReads: 1},
+		{size: 2, reads: []string{"a\nb\n", "a\nb\n"}, want: "a\nb\n", wantReads: 2},
+		{size: 2, reads: []string{"a\nb", "a\nb\nc", "a\nb\nc"}, want: "a\nb\nc", wantReads: 3},
+		{size: 1, reads: []string{"a", "b", "c", "d", "e", "f"}, want: "e", wantErr: ErrTornRead, wantReads: maxTornReads},
+	} {
+		var n int
+		got, err := readStable("path", test.size, func(string) ([]byte, error) {
+			b := []byte(test.reads[n])
+			n++
+			return b, nil
+		})
+		if err != test.wantErr {
+			t.Errorf("unexpected error for %q: got:%v want:%v", test.reads, err, test.wantErr)
+		}
+		if string(got) != test.want {
+			t.Errorf("unexpected result for %q: got:%q want:%q", test.reads, got, test.want)
+		}
+		if n != test.wantReads {
+			t.Errorf("unexpected number of reads for %q: got:%d want:%d", test.reads, n, test.wantReads)
+		}
+	}
+}
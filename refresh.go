@@ -0,0 +1,89 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+// Refresh re-reads the values cached by the TachoMotor when it was
+// created: its driver name, count per rotation, maximum speed, commands
+// and stop actions. Refresh should be used when the driver of the motor
+// may have been rebound. If any value cannot be read, the cached values
+// are left unchanged and the error state is set.
+//
+// Other state held by the handle, such as its default context, close
+// hooks and gear ratio, is retained. Like other configuration methods,
+// Refresh must not be called while the handle is shared.
+func (m *TachoMotor) Refresh() *TachoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	var t TachoMotor
+	err := t.setID(m.id)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	m.driver = t.driver
+	m.countPerRot = t.countPerRot
+	m.maxSpeed = t.maxSpeed
+	m.commands = t.commands
+	m.stopActions = t.stopActions
+	return m
+}
+
+// Refresh re-reads the values cached by the DCMotor when it was created:
+// its driver name, commands and stop actions. See TachoMotor.Refresh for
+// details.
+func (m *DCMotor) Refresh() *DCMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	var t DCMotor
+	err := t.setID(m.id)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	m.driver = t.driver
+	m.commands = t.commands
+	m.stopActions = t.stopActions
+	return m
+}
+
+// Refresh re-reads the driver name cached by the ServoMotor when it was
+// created. See TachoMotor.Refresh for details.
+func (m *ServoMotor) Refresh() *ServoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	var t ServoMotor
+	err := t.setID(m.id)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	m.driver = t.driver
+	return m
+}
+
+// Refresh re-reads the values cached by the LinearActuator when it was
+// created: its driver name, count per meter, full travel count, maximum
+// speed, commands and stop actions. See TachoMotor.Refresh for details.
+func (m *LinearActuator) Refresh() *LinearActuator {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	var t LinearActuator
+	err := t.setID(m.id)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	m.driver = t.driver
+	m.countPerMeter = t.countPerMeter
+	m.fullTravelCount = t.fullTravelCount
+	m.maxSpeed = t.maxSpeed
+	m.commands = t.commands
+	m.stopActions = t.stopActions
+	return m
+}
@@ -0,0 +1,120 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTachoMotorRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor"),
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	var m TachoMotor
+	err = m.setID(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	m.WithContext(ctx).SetGearRatio(3)
+
+	// Rebind the port to a different motor.
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {
+			driverName:  "lego-ev3-m-motor",
+			maxSpeed:    "1560",
+			commands:    "run-forever stop",
+			stopActions: "coast",
+		},
+	})
+	err = m.Refresh().Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := m.Driver(); got != "lego-ev3-m-motor" {
+		t.Errorf("unexpected driver: got:%q want:%q", got, "lego-ev3-m-motor")
+	}
+	if got := m.MaxSpeed(); got != 1560 {
+		t.Errorf("unexpected max speed: got:%d want:1560", got)
+	}
+	if got, want := m.Commands(), []string{"run-forever", "stop"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected commands: got:%q want:%q", got, want)
+	}
+	if got, want := m.StopActions(), []string{"coast"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected stop actions: got:%q want:%q", got, want)
+	}
+	if m.ctx != ctx || m.GearRatio() != 3 {
+		t.Error("handle state not retained by refresh")
+	}
+
+	err = os.Remove(filepath.Join(dir, TachoMotorPath, "motor0", maxSpeed))
+	if err != nil {
+		t.Fatalf("failed to remove attribute: %v", err)
+	}
+	err = m.Refresh().Err()
+	if err == nil {
+		t.Error("expected error for missing attribute")
+	}
+	if m.id != 0 || m.MaxSpeed() != 1560 || m.Driver() != "lego-ev3-m-motor" {
+		t.Errorf("cached values changed after failed refresh: %+v", m)
+	}
+}
+
+func TestLinearActuatorRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "act-l12-ev3-50")
+	attrs[countPerMeter] = "20000"
+	attrs[fullTravelCount] = "1000"
+	attrs[maxSpeed] = "24"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "linear0"): attrs,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	var m LinearActuator
+	err = m.setID(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.SetTravelLimits(0, 500)
+
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "linear0"): {
+			driverName:      "act-l12-ev3-100",
+			fullTravelCount: "2000",
+			maxSpeed:        "30",
+		},
+	})
+	err = m.Refresh().Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Driver() != "act-l12-ev3-100" || m.FullTravelCount() != 2000 || m.MaxSpeed() != 30 || m.CountPerMeter() != 20000 {
+		t.Errorf("unexpected cached values after refresh: %+v", m)
+	}
+	if _, _, ok := m.TravelLimits(); !ok {
+		t.Error("travel limits not retained by refresh")
+	}
+}
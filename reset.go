@@ -0,0 +1,61 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResetAndVerify issues the reset command to the TachoMotor and then
+// polls its position and duty cycle until both read zero. The driver
+// applies a reset asynchronously, so reads made immediately after the
+// reset command may return stale values. ResetAndVerify returns an error
+// if the values are not zero within timeout, or if the TachoMotor's
+// default context is done.
+func (m *TachoMotor) ResetAndVerify(timeout time.Duration) error {
+	err := m.Command(CommandReset).Err()
+	if err != nil {
+		return err
+	}
+	return verifyReset(m, timeout)
+}
+
+// ResetAndVerify issues the reset command to the LinearActuator and then
+// polls its position and duty cycle until both read zero. See
+// TachoMotor.ResetAndVerify for details.
+func (m *LinearActuator) ResetAndVerify(timeout time.Duration) error {
+	err := m.Command(CommandReset).Err()
+	if err != nil {
+		return err
+	}
+	return verifyReset(m, timeout)
+}
+
+// resetPoll is the polling interval used by ResetAndVerify.
+const resetPoll = 5 * time.Millisecond
+
+// verifyReset polls the position and duty cycle of d
+// until both are zero or timeout has elapsed.
+func verifyReset(d Device, timeout time.Duration) error {
+	end := time.Now().Add(timeout)
+	for {
+		pos, err := intFrom(attributeOf(d, position))
+		if err != nil {
+			return err
+		}
+		duty, err := intFrom(attributeOf(d, dutyCycle))
+		if err != nil {
+			return err
+		}
+		if pos == 0 && duty == 0 {
+			return nil
+		}
+		if time.Now().After(end) {
+			return fmt.Errorf("ev3dev: reset of %s not complete after %v: position=%d duty_cycle=%d", deviceName(d), timeout, pos, duty)
+		}
+		time.Sleep(resetPoll)
+	}
+}
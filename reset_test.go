@@ -0,0 +1,56 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResetAndVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	attrs := tachoMotorAttrs("ev3-ports:outA", "lego-ev3-l-motor")
+	attrs[position] = "0"
+	attrs[dutyCycle] = "0"
+	stale := tachoMotorAttrs("ev3-ports:outB", "lego-ev3-l-motor")
+	stale[position] = "120"
+	stale[dutyCycle] = "0"
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): attrs,
+		filepath.Join(TachoMotorPath, "motor1"): stale,
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	m.commands = strings.Fields(attrs[commands])
+	err = m.ResetAndVerify(time.Second)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, TachoMotorPath, "motor0", command))
+	if err != nil {
+		t.Fatalf("failed to read command: %v", err)
+	}
+	if got := strings.TrimSpace(string(b)); got != string(CommandReset) {
+		t.Errorf("unexpected command: got:%q want:%q", got, CommandReset)
+	}
+
+	m = &TachoMotor{id: 1}
+	m.commands = strings.Fields(stale[commands])
+	err = m.ResetAndVerify(20 * time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "position=120") {
+		t.Errorf("unexpected error for stale position: %v", err)
+	}
+}
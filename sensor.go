@@ -5,6 +5,8 @@
 package ev3dev
 
 import (
+	"context"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -25,6 +27,14 @@ type Sensor struct {
 	decimals, numValues        int
 	mode, units, binDataFormat string
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
 	err error
 }
 
@@ -52,6 +62,7 @@ func (s *Sensor) Err() error {
 // idInt and setID satisfy the idSetter interface.
 func (s *Sensor) setID(id int) error {
 	t := Sensor{id: id}
+	forgetName(&t)
 	var err error
 	t.firmwareVersion, err = stringFrom(attributeOf(&t, firmwareVersion))
 	if err != nil {
@@ -91,6 +102,8 @@ func (s *Sensor) idInt() int {
 // sensor driver does not match the driver string, a Sensor for the port
 // is returned with a DriverMismatch error.
 // If port is empty, the first sensor satisfying the driver name is returned.
+// Sensors attached to a sensor multiplexer may be found using the short
+// form of the multiplexer port address, as described for MuxPorts.
 func SensorFor(port, driver string) (*Sensor, error) {
 	id, err := deviceIDFor(port, driver, (*Sensor)(nil), -1)
 	if id == -1 {
@@ -101,6 +114,7 @@ func SensorFor(port, driver string) (*Sensor, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&s)
 	return &s, err
 }
 
@@ -115,17 +129,28 @@ func (s *Sensor) Next() (*Sensor, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &Sensor{id: id}, err
+	var n Sensor
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
-// BinData returns the unscaled raw values from the Sensor.
+// BinData returns the unscaled raw values from the Sensor. BinDataInts and
+// BinDataFloats return the values decoded according to BinDataFormat.
 func (s *Sensor) BinData() ([]byte, error) {
 	err := s.Err()
 	if err != nil {
 		return nil, err
 	}
+	err = ctxErr(s)
+	if err != nil {
+		return nil, newAttrOpError(s, binData, "", "read", err)
+	}
 	path := filepath.Join(s.Path(), s.String(), binData)
-	b, err := readFile(path)
+	b, err := readAttr(contextOf(s), path)
 	if err != nil {
 		return nil, newAttrOpError(s, binData, string(b), "read", err)
 	}
@@ -190,11 +215,16 @@ func (s *Sensor) Command(comm string) *Sensor {
 // with the sensor for using advanced features that are not otherwise
 // available through the lego-sensor class. It is the responsibility
 // of the user to provide the correct file operation flags, and to
-// close the file after use.
+// close the file after use. See DirectFile for a file that supports
+// deadlines and cancellation.
 func (s *Sensor) Direct(flag int) (*os.File, error) {
 	if s.err != nil {
 		return nil, s.Err()
 	}
+	err := ctxErr(s)
+	if err != nil {
+		return nil, newAttrOpError(s, direct, "", "open", err)
+	}
 	return os.OpenFile(filepath.Join(s.Path(), s.String(), direct), flag, 0)
 }
 
@@ -305,6 +335,22 @@ func (s *Sensor) Value(n int) (string, error) {
 	return stringFrom(attributeOf(s, value+strconv.Itoa(n)))
 }
 
+// FloatValue returns the nth value measured by the Sensor scaled by the
+// number of decimal places for the current mode, so that a raw value of
+// 253 with one decimal place is returned as 25.3. The number of decimal
+// places is cached by the Sensor and refreshed by SetMode.
+func (s *Sensor) FloatValue(n int) (float64, error) {
+	v, err := s.Value(n)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, newParseError(s, value+strconv.Itoa(n), err)
+	}
+	return f / math.Pow10(s.decimals), nil
+}
+
 // TextValues returns slice of strings string representing sensor-specific text values.
 func (s *Sensor) TextValues() ([]string, error) {
 	return stringSliceFrom(attributeOf(s, textValues))
@@ -0,0 +1,105 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// binDataSizes holds the size in bytes of a
+// value in each bin_data_format.
+var binDataSizes = map[string]int{
+	"u8":     1,
+	"s8":     1,
+	"u16":    2,
+	"s16":    2,
+	"s16_be": 2,
+	"s32":    4,
+	"s32_be": 4,
+	"float":  4,
+}
+
+// BinDataInts returns the NumValues values from BinData decoded according
+// to BinDataFormat. BinDataInts returns an error if the format is float;
+// BinDataFloats should be used for sensors with float values.
+func (s *Sensor) BinDataInts() ([]int, error) {
+	if s.binDataFormat == "float" {
+		return nil, fmt.Errorf("ev3dev: cannot decode float bin data from %s as integers", deviceName(s))
+	}
+	b, err := s.binDataValues()
+	if err != nil {
+		return nil, err
+	}
+	v := make([]int, s.numValues)
+	for i := range v {
+		v[i] = decodeBinInt(b, s.binDataFormat, i)
+	}
+	return v, nil
+}
+
+// BinDataFloats returns the NumValues values from BinData decoded according
+// to BinDataFormat. The values are not scaled by Decimals.
+func (s *Sensor) BinDataFloats() ([]float64, error) {
+	b, err := s.binDataValues()
+	if err != nil {
+		return nil, err
+	}
+	v := make([]float64, s.numValues)
+	for i := range v {
+		if s.binDataFormat == "float" {
+			v[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:])))
+		} else {
+			v[i] = float64(decodeBinInt(b, s.binDataFormat, i))
+		}
+	}
+	return v, nil
+}
+
+// binDataValues returns the bin data of the Sensor, checking that
+// its format is known and that it holds NumValues values.
+func (s *Sensor) binDataValues() ([]byte, error) {
+	size, ok := binDataSizes[s.binDataFormat]
+	if !ok {
+		valid := make([]string, 0, len(binDataSizes))
+		for f := range binDataSizes {
+			valid = append(valid, f)
+		}
+		sort.Strings(valid)
+		return nil, newInvalidValueError(s, binDataFormat, "unknown bin data format", s.binDataFormat, valid)
+	}
+	b, err := s.BinData()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < size*s.numValues {
+		return nil, fmt.Errorf("ev3dev: short bin data for %s: %d bytes for %d %s values", deviceName(s), len(b), s.numValues, s.binDataFormat)
+	}
+	return b, nil
+}
+
+// decodeBinInt returns the ith integer value of b in the given format.
+func decodeBinInt(b []byte, format string, i int) int {
+	switch format {
+	case "u8":
+		return int(b[i])
+	case "s8":
+		return int(int8(b[i]))
+	case "u16":
+		return int(binary.LittleEndian.Uint16(b[2*i:]))
+	case "s16":
+		return int(int16(binary.LittleEndian.Uint16(b[2*i:])))
+	case "s16_be":
+		return int(int16(binary.BigEndian.Uint16(b[2*i:])))
+	case "s32":
+		return int(int32(binary.LittleEndian.Uint32(b[4*i:])))
+	case "s32_be":
+		return int(int32(binary.BigEndian.Uint32(b[4*i:])))
+	default:
+		panic("ev3dev: invalid bin data format: " + format)
+	}
+}
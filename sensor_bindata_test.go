@@ -0,0 +1,106 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var binDataTests = []struct {
+	format    string
+	numValues int
+	data      []byte
+
+	wantInts   []int
+	wantFloats []float64
+	wantErr    bool
+}{
+	{
+		format: "u8", numValues: 3, data: []byte{0x01, 0xff, 0x80},
+		wantInts: []int{1, 255, 128}, wantFloats: []float64{1, 255, 128},
+	},
+	{
+		format: "s8", numValues: 3, data: []byte{0x01, 0xff, 0x80},
+		wantInts: []int{1, -1, -128}, wantFloats: []float64{1, -1, -128},
+	},
+	{
+		format: "u16", numValues: 2, data: []byte{0x34, 0x12, 0xff, 0xff},
+		wantInts: []int{0x1234, 0xffff}, wantFloats: []float64{0x1234, 0xffff},
+	},
+	{
+		format: "s16", numValues: 2, data: []byte{0x34, 0x12, 0xfe, 0xff},
+		wantInts: []int{0x1234, -2}, wantFloats: []float64{0x1234, -2},
+	},
+	{
+		format: "s16_be", numValues: 2, data: []byte{0x12, 0x34, 0xff, 0xfe},
+		wantInts: []int{0x1234, -2}, wantFloats: []float64{0x1234, -2},
+	},
+	{
+		format: "s32", numValues: 1, data: []byte{0xfe, 0xff, 0xff, 0xff},
+		wantInts: []int{-2}, wantFloats: []float64{-2},
+	},
+	{
+		format: "s32_be", numValues: 1, data: []byte{0x00, 0x01, 0x00, 0x00},
+		wantInts: []int{0x10000}, wantFloats: []float64{0x10000},
+	},
+	{
+		// 1.5 and -0.25 as little endian float32.
+		format: "float", numValues: 2, data: []byte{0x00, 0x00, 0xc0, 0x3f, 0x00, 0x00, 0x80, 0xbe},
+		wantFloats: []float64{1.5, -0.25},
+	},
+	{
+		format: "s16", numValues: 3, data: []byte{0x01, 0x00, 0x02, 0x00},
+		wantErr: true,
+	},
+	{
+		format: "u24", numValues: 1, data: []byte{0x01, 0x00, 0x00},
+		wantErr: true,
+	},
+}
+
+func TestSensorBinData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, SensorPath, "sensor0", binData)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	for _, test := range binDataTests {
+		err = ioutil.WriteFile(path, test.data, 0644)
+		if err != nil {
+			t.Fatalf("failed to write bin data: %v", err)
+		}
+		s := &Sensor{id: 0, binDataFormat: test.format, numValues: test.numValues}
+
+		floats, err := s.BinDataFloats()
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error for %s: %v", test.format, err)
+		}
+		if !reflect.DeepEqual(floats, test.wantFloats) {
+			t.Errorf("unexpected float values for %s: got:%v want:%v", test.format, floats, test.wantFloats)
+		}
+
+		ints, err := s.BinDataInts()
+		wantErr := test.wantErr || test.format == "float"
+		if (err != nil) != wantErr {
+			t.Errorf("unexpected error for %s: %v", test.format, err)
+		}
+		if !reflect.DeepEqual(ints, test.wantInts) {
+			t.Errorf("unexpected integer values for %s: got:%v want:%v", test.format, ints, test.wantInts)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSensorFloatValue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"): {
+			modes:         "TEMP RAW",
+			mode:          "TEMP",
+			decimals:      "1",
+			numValues:     "2",
+			units:         "C",
+			binDataFormat: "s16",
+			value + "0":   "253",
+			value + "1":   "-7",
+			value + "2":   "bad",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	s := &Sensor{id: 0, modes: []string{"TEMP", "RAW"}}
+	err = s.cacheModeAttrs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for n, want := range []float64{25.3, -0.7} {
+		got, err := s.FloatValue(n)
+		if err != nil {
+			t.Errorf("unexpected error for value%d: %v", n, err)
+		}
+		if got != want {
+			t.Errorf("unexpected value%d: got:%v want:%v", n, got, want)
+		}
+	}
+	_, err = s.FloatValue(2)
+	if err == nil {
+		t.Error("expected error for invalid value")
+	}
+
+	// Changing mode refreshes the decimals.
+	err = ioutil.WriteFile(filepath.Join(dir, SensorPath, "sensor0", decimals), []byte("0\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write decimals: %v", err)
+	}
+	err = s.SetMode("RAW").Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := s.FloatValue(0)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got != 253 {
+		t.Errorf("unexpected value after mode change: got:%v want:253", got)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Common NXT I2C sensor registers. Most third-party NXT I2C sensors
+// follow the LEGO convention of holding NUL-padded ASCII identification
+// strings in the first 24 registers.
+const (
+	I2CVersionRegister = 0x00 // Firmware version string.
+	I2CVendorRegister  = 0x08 // Vendor ID string.
+	I2CDeviceRegister  = 0x10 // Device ID string.
+
+	// I2CStringLength is the length of the
+	// identification string registers.
+	I2CStringLength = 8
+
+	// I2CRegisters is the number of registers
+	// addressable through the direct attribute.
+	I2CRegisters = 256
+)
+
+// ReadRegister reads n bytes from consecutive registers of an I2C sensor,
+// starting at register reg, through the sensor's direct attribute. The
+// addr parameter is the 7-bit I2C address of the sensor. It is checked
+// against the sensor's port address so that a script does not address
+// the wrong device.
+func (s *Sensor) ReadRegister(addr, reg, n int) ([]byte, error) {
+	err := s.checkRegisters(addr, reg, n)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.Direct(os.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b := make([]byte, n)
+	_, err = f.ReadAt(b, int64(reg))
+	if err != nil {
+		return nil, newAttrOpError(s, direct, "", "read", err)
+	}
+	return b, nil
+}
+
+// WriteRegister writes data to consecutive registers of an I2C sensor,
+// starting at register reg, through the sensor's direct attribute. The
+// addr parameter is checked as described for ReadRegister.
+func (s *Sensor) WriteRegister(addr, reg int, data []byte) error {
+	err := s.checkRegisters(addr, reg, len(data))
+	if err != nil {
+		return err
+	}
+	f, err := s.Direct(os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, int64(reg))
+	if err != nil {
+		f.Close()
+		return newAttrOpError(s, direct, fmt.Sprintf("% x", data), "write", err)
+	}
+	return f.Close()
+}
+
+// ReadRegisterString reads a NUL-padded ASCII string of length n from an
+// I2C sensor starting at register reg, as described for ReadRegister.
+// The returned string does not include the padding.
+func (s *Sensor) ReadRegisterString(addr, reg, n int) (string, error) {
+	b, err := s.ReadRegister(addr, reg, n)
+	if err != nil {
+		return "", err
+	}
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b), nil
+}
+
+// checkRegisters returns an error if the Sensor is in an error state,
+// if addr is not the I2C address of the Sensor, or if the n registers
+// starting at reg are not all addressable.
+func (s *Sensor) checkRegisters(addr, reg, n int) error {
+	if s.err != nil {
+		return s.Err()
+	}
+	if reg < 0 || reg >= I2CRegisters {
+		return newValueOutOfRangeError(s, direct, reg, 0, I2CRegisters-1)
+	}
+	if n < 0 || reg+n > I2CRegisters {
+		return newValueOutOfRangeError(s, direct, n, 0, I2CRegisters-reg)
+	}
+	port, err := AddressOf(s)
+	if err != nil {
+		return err
+	}
+	got, ok := i2cAddressFrom(port)
+	if !ok {
+		return fmt.Errorf("ev3dev: %s at %s is not an I2C sensor", s, port)
+	}
+	if got != addr {
+		return fmt.Errorf("ev3dev: I2C address %#02x does not match %s at %s", addr, s, port)
+	}
+	return nil
+}
+
+// i2cAddressFrom returns the I2C address held in the final element of
+// a port address such as "ev3-ports:in2:i2c1".
+func i2cAddressFrom(port string) (addr int, ok bool) {
+	i := strings.LastIndex(port, ":i2c")
+	if i < 0 {
+		return 0, false
+	}
+	addr, err := strconv.Atoi(port[i+len(":i2c"):])
+	if err != nil || addr < 0 || addr > 0x7f {
+		return 0, false
+	}
+	return addr, true
+}
@@ -0,0 +1,107 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSensorRegisters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"): {
+			address: "ev3-ports:in2:i2c1",
+		},
+		filepath.Join(SensorPath, "sensor1"): {
+			address: "ev3-ports:in1",
+		},
+	})
+	regs := make([]byte, I2CRegisters)
+	copy(regs[I2CVersionRegister:], "V1.0")
+	copy(regs[I2CVendorRegister:], "LEGO")
+	copy(regs[I2CDeviceRegister:], "Sonar")
+	err = ioutil.WriteFile(filepath.Join(dir, SensorPath, "sensor0", direct), regs, 0644)
+	if err != nil {
+		t.Fatalf("failed to write direct: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	s := &Sensor{id: 0}
+	for _, test := range []struct {
+		reg  int
+		want string
+	}{
+		{reg: I2CVersionRegister, want: "V1.0"},
+		{reg: I2CVendorRegister, want: "LEGO"},
+		{reg: I2CDeviceRegister, want: "Sonar"},
+	} {
+		got, err := s.ReadRegisterString(0x01, test.reg, I2CStringLength)
+		if err != nil {
+			t.Errorf("unexpected error reading register %#02x: %v", test.reg, err)
+		}
+		if got != test.want {
+			t.Errorf("unexpected string at register %#02x: got:%q want:%q", test.reg, got, test.want)
+		}
+	}
+
+	err = s.WriteRegister(0x01, 0x41, []byte{0x02, 0x03})
+	if err != nil {
+		t.Errorf("unexpected error writing registers: %v", err)
+	}
+	got, err := s.ReadRegister(0x01, 0x40, 4)
+	if err != nil {
+		t.Errorf("unexpected error reading registers: %v", err)
+	}
+	if want := []byte{0, 2, 3, 0}; !bytes.Equal(got, want) {
+		t.Errorf("unexpected registers: got:%v want:%v", got, want)
+	}
+
+	for _, test := range []struct {
+		name         string
+		s            *Sensor
+		addr, reg, n int
+	}{
+		{name: "wrong address", s: s, addr: 0x02, reg: 0, n: 1},
+		{name: "negative register", s: s, addr: 0x01, reg: -1, n: 1},
+		{name: "register out of range", s: s, addr: 0x01, reg: I2CRegisters, n: 1},
+		{name: "read past end", s: s, addr: 0x01, reg: I2CRegisters - 1, n: 2},
+		{name: "not I2C", s: &Sensor{id: 1}, addr: 0x01, reg: 0, n: 1},
+	} {
+		_, err := test.s.ReadRegister(test.addr, test.reg, test.n)
+		if err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+}
+
+func TestI2CAddressFrom(t *testing.T) {
+	for _, test := range []struct {
+		port   string
+		want   int
+		wantOK bool
+	}{
+		{port: "ev3-ports:in2:i2c1", want: 0x01, wantOK: true},
+		{port: "ev3-ports:in4:i2c80", want: 80, wantOK: true},
+		{port: "ev3-ports:in1", wantOK: false},
+		{port: "ev3-ports:in1:i2c", wantOK: false},
+		{port: "ev3-ports:in1:i2c200", wantOK: false},
+	} {
+		got, ok := i2cAddressFrom(test.port)
+		if ok != test.wantOK || got != test.want {
+			t.Errorf("unexpected result for %q: got:(%d, %t) want:(%d, %t)",
+				test.port, got, ok, test.want, test.wantOK)
+		}
+	}
+}
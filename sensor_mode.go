@@ -0,0 +1,121 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"strconv"
+	"time"
+)
+
+// settlePoll is the interval between value reads
+// while waiting for a sensor mode change to settle.
+const settlePoll = 10 * time.Millisecond
+
+// SetModeAndWait sets the mode of the Sensor and waits for the sensor's
+// values in the new mode to settle before returning. The cached values for
+// BinDataFormat, Decimals, Mode, NumValues and Units are cleared before the
+// mode is written and are refreshed once the values have settled.
+//
+// The values are considered settled when two consecutive reads of all the
+// values, made 10ms apart, agree. If the values have not settled within
+// the settle period, SetModeAndWait returns without error after settle
+// has passed; a settle period of zero returns as soon as the new mode has
+// been written. The wait is abandoned if the Sensor's default context is
+// done, and the Sensor's error state is set to the context's error.
+func (s *Sensor) SetModeAndWait(m string, settle time.Duration) *Sensor {
+	if s.err != nil {
+		return s
+	}
+	if settle < 0 {
+		s.err = newNegativeDurationError(s, "settle", settle)
+		return s
+	}
+	ok := false
+	for _, a := range s.modes {
+		if a == m {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		s.err = newInvalidValueError(s, mode, "", m, s.Modes())
+		return s
+	}
+	s.clearModeAttrs()
+	s.err = setAttributeOf(s, mode, m)
+	if s.err != nil {
+		return s
+	}
+	s.err = s.cacheModeAttrs()
+	if s.err != nil {
+		return s
+	}
+	s.err = s.settle(settle)
+	if s.err != nil {
+		return s
+	}
+	// The driver may have updated the mode
+	// attributes while the values settled.
+	s.err = s.cacheModeAttrs()
+	return s
+}
+
+// clearModeAttrs clears the cached mode values of the Sensor.
+func (s *Sensor) clearModeAttrs() {
+	s.decimals = 0
+	s.numValues = 0
+	s.mode = ""
+	s.units = ""
+	s.binDataFormat = ""
+}
+
+// settle waits until two consecutive reads of the Sensor's values
+// agree or until the timeout has passed.
+func (s *Sensor) settle(timeout time.Duration) error {
+	if timeout == 0 {
+		return nil
+	}
+	ctx := contextOf(s)
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(settlePoll)
+	defer ticker.Stop()
+
+	var last []string
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return nil
+		case <-ticker.C:
+		}
+		vals := make([]string, s.numValues)
+		for i := range vals {
+			v, err := stringFrom(attributeOf(s, value+strconv.Itoa(i)))
+			if err != nil {
+				return err
+			}
+			vals[i] = v
+		}
+		if last != nil && equalStrings(vals, last) {
+			return nil
+		}
+		last = vals
+	}
+}
+
+// equalStrings returns whether a and b hold the same strings.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSensorSetModeAndWait(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"): {
+			modes:         "TEMP RAW",
+			mode:          "TEMP",
+			decimals:      "1",
+			numValues:     "1",
+			units:         "C",
+			binDataFormat: "s16",
+			value + "0":   "253",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	s := &Sensor{id: 0, modes: []string{"TEMP", "RAW"}}
+	err = s.cacheModeAttrs()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = s.SetModeAndWait("INVALID", time.Second).Err()
+	if err == nil {
+		t.Error("expected error for invalid mode")
+	}
+	err = s.SetModeAndWait("RAW", -time.Second).Err()
+	if err == nil {
+		t.Error("expected error for negative settle period")
+	}
+
+	start := time.Now()
+	err = s.SetModeAndWait("RAW", time.Minute).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("stable values did not settle: waited %v", elapsed)
+	}
+	got, err := s.Mode()
+	if err != nil {
+		t.Errorf("unexpected error reading mode: %v", err)
+	}
+	if got != "RAW" {
+		t.Errorf("unexpected mode: got:%q want:%q", got, "RAW")
+	}
+	if s.Decimals() != 1 || s.NumValues() != 1 || s.Units() != "C" {
+		t.Errorf("unexpected cached mode attributes: decimals=%d num_values=%d units=%q",
+			s.Decimals(), s.NumValues(), s.Units())
+	}
+
+	// Values that do not settle are
+	// abandoned after the settle period.
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		path := filepath.Join(dir, SensorPath, "sensor0", value+"0")
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			f, err := os.OpenFile(path, os.O_WRONLY, 0)
+			if err != nil {
+				return
+			}
+			// Write same-length values that do not
+			// repeat within the settle period.
+			f.WriteAt([]byte(fmt.Sprintf("%03d", i%1000)), 0)
+			f.Close()
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	start = time.Now()
+	err = s.SetModeAndWait("TEMP", 50*time.Millisecond).Err()
+	close(stop)
+	<-done
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("returned before settle period with changing values: %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = s.WithContext(ctx).SetModeAndWait("RAW", time.Minute).Err()
+	if err == nil {
+		t.Error("expected error for done context")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// NXTI2CMode is the LegoPort mode for NXT I2C sensors.
+const NXTI2CMode = "nxt-i2c"
+
+// RegisterI2CSensor registers an I2C sensor that is not detected
+// automatically, returning a Sensor for the registered device. The port p
+// is placed in NXTI2CMode if it is not already, and the named sensor
+// driver is loaded for the 7-bit I2C address addr by writing to the port's
+// set_device attribute. RegisterI2CSensor then waits up to timeout for the
+// sensor to appear, as described for WithTimeout.
+//
+// For example, to register a sensor on input port 2 with the ht-nxt-compass
+// driver at I2C address 0x01:
+//
+//	p, err := ev3dev.LegoPortFor("ev3-ports:in2", "")
+//	...
+//	s, err := ev3dev.RegisterI2CSensor(p, "ht-nxt-compass", 0x01, time.Second)
+func RegisterI2CSensor(p *LegoPort, driver string, addr int, timeout time.Duration) (*Sensor, error) {
+	if addr < 0 || addr > 0x7f {
+		return nil, newValueOutOfRangeError(p, setDevice, addr, 0, 0x7f)
+	}
+	port, err := AddressOf(p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Mode() != NXTI2CMode {
+		err = p.SetMode(NXTI2CMode).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = p.SetDevice(fmt.Sprintf("%s %#02x", driver, addr)).Err()
+	if err != nil {
+		return nil, err
+	}
+	return NewSensor(
+		WithPort(port+":i2c"+strconv.Itoa(addr)),
+		WithDriver(driver),
+		WithTimeout(timeout),
+	)
+}
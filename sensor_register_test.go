@@ -0,0 +1,90 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegisterI2CSensor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(LegoPortPath, "port0"): {
+			address:    "ev3-ports:in2",
+			driverName: "legoev3-input-port",
+			modes:      "auto nxt-analog nxt-color nxt-i2c other-uart",
+			mode:       "auto",
+			setDevice:  "",
+		},
+		filepath.Join(LegoPortPath, "port1"): {
+			address:    "ev3-ports:in3",
+			driverName: "legoev3-input-port",
+			modes:      "auto nxt-analog nxt-color nxt-i2c other-uart",
+			mode:       "nxt-i2c",
+			setDevice:  "",
+		},
+		filepath.Join(SensorPath, "sensor0"): {
+			address:         "ev3-ports:in2:i2c1",
+			driverName:      "ht-nxt-compass",
+			firmwareVersion: "V1.0",
+			commands:        "",
+			modes:           "COMPASS",
+			mode:            "COMPASS",
+			decimals:        "0",
+			numValues:       "1",
+			units:           "deg",
+			binDataFormat:   "s16",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	p := &LegoPort{id: 0, modes: []string{"auto", "nxt-analog", "nxt-color", "nxt-i2c", "other-uart"}, mode: "auto"}
+	_, err = RegisterI2CSensor(p, "ht-nxt-compass", 0x80, time.Second)
+	if err == nil {
+		t.Error("expected error for invalid I2C address")
+	}
+
+	s, err := RegisterI2CSensor(p, "ht-nxt-compass", 0x01, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer s.Close()
+	if s.String() != "sensor0" {
+		t.Errorf("unexpected sensor: got:%s want:sensor0", s)
+	}
+	for attr, want := range map[string]string{
+		mode:      "nxt-i2c",
+		setDevice: "ht-nxt-compass 0x01",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, LegoPortPath, "port0", attr))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", attr, err)
+		}
+		if got := string(b); got != want {
+			t.Errorf("unexpected %s: got:%q want:%q", attr, got, want)
+		}
+	}
+
+	// A sensor that does not appear is not found.
+	p = &LegoPort{id: 1, modes: []string{"auto", "nxt-analog", "nxt-color", "nxt-i2c", "other-uart"}, mode: "nxt-i2c"}
+	start := time.Now()
+	_, err = RegisterI2CSensor(p, "ht-nxt-compass", 0x01, 200*time.Millisecond)
+	if err == nil {
+		t.Error("expected error for missing sensor")
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("did not wait for sensor to appear: waited %v", elapsed)
+	}
+}
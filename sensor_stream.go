@@ -0,0 +1,113 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// SensorSample is a set of values read from a Sensor by Stream.
+type SensorSample struct {
+	// Time is the time the
+	// values were read.
+	Time time.Time
+
+	// Values holds the values
+	// of the Sensor scaled as
+	// described for FloatValue.
+	Values []float64
+}
+
+// Stream returns a channel that receives a sample of all the values of
+// the Sensor every interval. The value attributes are opened once and
+// read by a goroutine that does not use the Sensor's error state, so the
+// Sensor may continue to be used while it is streamed. Samples are not
+// buffered; if the receiver does not keep up, samples are skipped.
+//
+// The number of values and their scaling are those of the mode of the
+// Sensor when Stream is called, so the mode should not be changed while
+// the Sensor is streamed. The channel is closed when ctx is done, when the
+// Sensor is closed or when the values can no longer be read.
+func (s *Sensor) Stream(ctx context.Context, interval time.Duration) (<-chan SensorSample, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("ev3dev: invalid stream interval for %s: %v (must be positive)", deviceName(s), interval)
+	}
+	files := make([]*os.File, s.numValues)
+	for i := range files {
+		f, err := os.Open(filepath.Join(s.Path(), s.String(), value+strconv.Itoa(i)))
+		if err != nil {
+			for _, f := range files[:i] {
+				f.Close()
+			}
+			return nil, newAttrOpError(s, value+strconv.Itoa(i), "", "open", err)
+		}
+		files[i] = f
+	}
+	scale := math.Pow10(s.decimals)
+
+	ctx, cancel := context.WithCancel(ctx)
+	unhook := addCloseHook(&s.onClose, cancel)
+	c := make(chan SensorSample)
+	go func() {
+		defer close(c)
+		defer cancel()
+		defer unhook()
+		defer func() {
+			for _, f := range files {
+				f.Close()
+			}
+		}()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		buf := make([]byte, 64)
+		for {
+			sample := SensorSample{Values: make([]float64, len(files))}
+			for i, f := range files {
+				v, err := readValueFile(ctx, f, buf)
+				if err != nil {
+					return
+				}
+				sample.Values[i] = v / scale
+			}
+			sample.Time = time.Now()
+			select {
+			case c <- sample:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// readValueFile reads and parses the value held in the open attribute
+// file f using buf, using the attribute IO scheduler if one is set.
+func readValueFile(ctx context.Context, f *os.File, buf []byte) (float64, error) {
+	var (
+		n   int
+		err error
+	)
+	cerr := do(ctx, readIO, func() { n, err = f.ReadAt(buf, 0) })
+	if cerr != nil {
+		return 0, cerr
+	}
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	return strconv.ParseFloat(string(bytes.TrimSpace(buf[:n])), 64)
+}
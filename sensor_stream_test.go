@@ -0,0 +1,99 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSensorStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"): {
+			value + "0": "253",
+			value + "1": "-7",
+			value + "2": "1000",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	s := &Sensor{id: 0, numValues: 3, decimals: 1}
+	_, err = s.Stream(context.Background(), 0)
+	if err == nil {
+		t.Error("expected error for zero interval")
+	}
+	_, err = (&Sensor{id: 0, numValues: 4}).Stream(context.Background(), time.Millisecond)
+	if err == nil {
+		t.Error("expected error for missing value")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := s.Stream(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var last time.Time
+	for i := 0; i < 3; i++ {
+		sample, ok := <-c
+		if !ok {
+			t.Fatal("stream closed early")
+		}
+		if want := []float64{25.3, -0.7, 100}; !reflect.DeepEqual(sample.Values, want) {
+			t.Errorf("unexpected values: got:%v want:%v", sample.Values, want)
+		}
+		if !sample.Time.After(last) {
+			t.Errorf("sample time not increasing: %v after %v", sample.Time, last)
+		}
+		last = sample.Time
+	}
+
+	// Values are reread from the open files. The value is
+	// overwritten in place without truncation so that a
+	// concurrent read cannot see an empty file.
+	f, err := os.OpenFile(filepath.Join(dir, SensorPath, "sensor0", value+"2"), os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open value: %v", err)
+	}
+	_, err = f.WriteAt([]byte("0012"), 0)
+	f.Close()
+	if err != nil {
+		t.Fatalf("failed to write value: %v", err)
+	}
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case sample, ok := <-c:
+			if !ok {
+				t.Fatal("stream closed early")
+			}
+			if sample.Values[2] != 1.2 {
+				continue
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for changed value")
+		}
+		break
+	}
+
+	err = s.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+	for range c {
+	}
+}
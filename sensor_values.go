@@ -0,0 +1,52 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"math"
+	"os"
+	"strconv"
+)
+
+// Values returns all the values measured by the Sensor in the current mode.
+// The values are read from the text_values attribute in a single read when
+// the sensor's driver provides it and it holds NumValues values. Otherwise
+// each value is read separately as it would be by Value.
+func (s *Sensor) Values() ([]string, error) {
+	vals, err := s.TextValues()
+	switch {
+	case err == nil && len(vals) == s.numValues:
+		return vals, nil
+	case err != nil && !os.IsNotExist(cause(err)):
+		return nil, err
+	}
+	vals = make([]string, s.numValues)
+	for i := range vals {
+		vals[i], err = s.Value(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// FloatValues returns all the values measured by the Sensor in the current
+// mode, read as described for Values and scaled as described for FloatValue.
+func (s *Sensor) FloatValues() ([]float64, error) {
+	vals, err := s.Values()
+	if err != nil {
+		return nil, err
+	}
+	scale := math.Pow10(s.decimals)
+	f := make([]float64, len(vals))
+	for i, v := range vals {
+		f[i], err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, newParseError(s, value+strconv.Itoa(i), err)
+		}
+		f[i] /= scale
+	}
+	return f, nil
+}
@@ -0,0 +1,102 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var sensorValuesTests = []struct {
+	name      string
+	attrs     map[string]string
+	wantText  []string
+	wantFloat []float64
+	wantErr   bool
+}{
+	{
+		name: "text_values",
+		attrs: map[string]string{
+			textValues:  "120 45 8",
+			value + "0": "1",
+			value + "1": "2",
+			value + "2": "3",
+		},
+		wantText:  []string{"120", "45", "8"},
+		wantFloat: []float64{12, 4.5, 0.8},
+	},
+	{
+		name: "no text_values",
+		attrs: map[string]string{
+			value + "0": "1",
+			value + "1": "2",
+			value + "2": "3",
+		},
+		wantText:  []string{"1", "2", "3"},
+		wantFloat: []float64{0.1, 0.2, 0.3},
+	},
+	{
+		name: "short text_values",
+		attrs: map[string]string{
+			textValues:  "",
+			value + "0": "1",
+			value + "1": "2",
+			value + "2": "3",
+		},
+		wantText:  []string{"1", "2", "3"},
+		wantFloat: []float64{0.1, 0.2, 0.3},
+	},
+	{
+		name: "missing value",
+		attrs: map[string]string{
+			value + "0": "1",
+			value + "1": "2",
+		},
+		wantErr: true,
+	},
+	{
+		name: "invalid value",
+		attrs: map[string]string{
+			textValues: "120 bad 8",
+		},
+		wantText: []string{"120", "bad", "8"},
+		wantErr:  true,
+	},
+}
+
+func TestSensorValues(t *testing.T) {
+	defer func(p string) { prefix = p }(prefix)
+
+	for _, test := range sensorValuesTests {
+		dir, err := ioutil.TempDir("", "ev3dev")
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %v", err)
+		}
+		makeTree(t, dir, map[string]map[string]string{
+			filepath.Join(SensorPath, "sensor0"): test.attrs,
+		})
+		prefix = dir
+
+		s := &Sensor{id: 0, numValues: 3, decimals: 1}
+		text, err := s.Values()
+		if err != nil && test.wantText != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(text, test.wantText) {
+			t.Errorf("unexpected values for %s: got:%q want:%q", test.name, text, test.wantText)
+		}
+		f, err := s.FloatValues()
+		if (err != nil) != test.wantErr {
+			t.Errorf("unexpected error state for %s: got:%v want error:%t", test.name, err, test.wantErr)
+		}
+		if !reflect.DeepEqual(f, test.wantFloat) {
+			t.Errorf("unexpected float values for %s: got:%v want:%v", test.name, f, test.wantFloat)
+		}
+		os.RemoveAll(dir)
+	}
+}
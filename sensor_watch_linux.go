@@ -0,0 +1,124 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Watch returns a channel that receives the nth value of the Sensor,
+// scaled as described for FloatValue, each time it changes, starting with
+// the current value. The value attribute is opened once and watched by a
+// goroutine that blocks in poll(2) on the attribute, so drivers that
+// notify changes to their values wake the goroutine only when the value
+// changes, without timed polling. If no notification is received within
+// interval, the value is read again, so values of drivers that do not
+// notify changes are polled every interval.
+//
+// The goroutine does not use the Sensor's error state, so the Sensor may
+// continue to be used while it is watched. The scaling is that of the
+// mode of the Sensor when Watch is called. The channel is closed when ctx
+// is done, when the Sensor is closed or when the value can no longer be
+// read.
+func (s *Sensor) Watch(ctx context.Context, n int, interval time.Duration) (<-chan float64, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("ev3dev: invalid watch interval for %s: %v (must be positive)", deviceName(s), interval)
+	}
+	attr := value + strconv.Itoa(n)
+	f, err := os.Open(filepath.Join(s.Path(), s.String(), attr))
+	if err != nil {
+		return nil, newAttrOpError(s, attr, "", "open", err)
+	}
+	scale := math.Pow10(s.decimals)
+
+	ctx, cancel := context.WithCancel(ctx)
+	unhook := addCloseHook(&s.onClose, cancel)
+	c := make(chan float64)
+	go func() {
+		defer close(c)
+		defer cancel()
+		defer unhook()
+		defer f.Close()
+		var fds []unix.PollFd
+		if canPoll {
+			fds = []unix.PollFd{{Fd: int32(f.Fd()), Events: unix.POLLIN}}
+		}
+		buf := make([]byte, 64)
+		first := true
+		var last float64
+		for {
+			// Reading the attribute also
+			// rearms the poll notification.
+			v, err := readValueFile(ctx, f, buf)
+			if err != nil {
+				return
+			}
+			v /= scale
+			if first || v != last {
+				select {
+				case c <- v:
+				case <-ctx.Done():
+					return
+				}
+				first = false
+				last = v
+			}
+			if !waitValue(ctx, fds, interval) {
+				return
+			}
+		}
+	}()
+	return c, nil
+}
+
+// waitValue waits until the attribute polled by fds is notified as
+// changed or interval has elapsed. If fds is empty, waitValue waits
+// for interval. It returns false if ctx is done or polling fails.
+func waitValue(ctx context.Context, fds []unix.PollFd, interval time.Duration) bool {
+	if len(fds) == 0 {
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	end := time.Now().Add(interval)
+	for {
+		remain := time.Until(end)
+		if remain <= 0 {
+			return ctx.Err() == nil
+		}
+		timeout := remain
+		if ctxPoll < timeout {
+			// Wake periodically to check
+			// for context cancellation.
+			timeout = ctxPoll
+		}
+		n, err := unix.Poll(fds, int(timeout/time.Millisecond))
+		if err != nil && err != unix.EINTR {
+			return false
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		if n != 0 {
+			return true
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package ev3dev
+
+import (
+	"context"
+	"time"
+)
+
+// Watch returns a channel that receives the nth value of the Sensor,
+// scaled as described for FloatValue, each time it changes, starting with
+// the current value.
+//
+// Watch is not implemented without a linux OS (needs unix.Poll).
+func (s *Sensor) Watch(ctx context.Context, n int, interval time.Duration) (<-chan float64, error) {
+	panic("ev3dev: needs GOOS=linux")
+}
@@ -0,0 +1,117 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestSensorWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(SensorPath, "sensor0"): {
+			value + "0": "253",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	s := &Sensor{id: 0, numValues: 1, decimals: 1}
+	_, err = s.Watch(context.Background(), 0, 0)
+	if err == nil {
+		t.Error("expected error for zero interval")
+	}
+	_, err = s.Watch(context.Background(), 1, time.Millisecond)
+	if err == nil {
+		t.Error("expected error for missing value")
+	}
+
+	c, err := s.Watch(context.Background(), 0, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := <-c; v != 25.3 {
+		t.Errorf("unexpected initial value: got:%v want:25.3", v)
+	}
+	// Overwrite the value in place without truncation
+	// so that a concurrent read cannot see an empty file.
+	f, err := os.OpenFile(filepath.Join(dir, SensorPath, "sensor0", value+"0"), os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open value: %v", err)
+	}
+	_, err = f.WriteAt([]byte("-12"), 0)
+	f.Close()
+	if err != nil {
+		t.Fatalf("failed to write value: %v", err)
+	}
+	select {
+	case v := <-c:
+		if v != -1.2 {
+			t.Errorf("unexpected changed value: got:%v want:-1.2", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for changed value")
+	}
+	err = s.Close()
+	if err != nil {
+		t.Errorf("unexpected error closing: %v", err)
+	}
+	for v := range c {
+		t.Errorf("unexpected value after close: %v", v)
+	}
+}
+
+func TestWaitValuePoll(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+	fds := []unix.PollFd{{Fd: int32(r.Fd()), Events: unix.POLLIN}}
+
+	start := time.Now()
+	if !waitValue(context.Background(), fds, 20*time.Millisecond) {
+		t.Error("unexpected failure waiting without notification")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("returned before interval without notification: %v", elapsed)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte{0})
+	}()
+	start = time.Now()
+	if !waitValue(context.Background(), fds, time.Minute) {
+		t.Error("unexpected failure waiting for notification")
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("notification not seen: waited %v", elapsed)
+	}
+
+	// Drain the pipe so that it is no longer ready.
+	r.Read(make([]byte, 1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if waitValue(ctx, fds, time.Minute) {
+		t.Error("expected failure after context done")
+	}
+}
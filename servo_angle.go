@@ -0,0 +1,106 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// servoCalibration is the calibration of a ServoMotor.
+type servoCalibration struct {
+	min, mid, max time.Duration
+	travel        float64
+}
+
+// Calibrate sets the min, mid and max pulse setpoints of the ServoMotor,
+// given in the same units as SetMinPulseSetpoint, SetMidPulseSetpoint and
+// SetMaxPulseSetpoint, and records that the servo turns through
+// travelDegrees between the min and max pulses, as given by its datasheet.
+// The servo's angle is taken to be proportional to the pulse width, with
+// zero degrees at the mid pulse. A ServoMotor must be calibrated before its
+// angle methods are used.
+func (m *ServoMotor) Calibrate(minPulse, midPulse, maxPulse time.Duration, travelDegrees float64) *ServoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if !(travelDegrees > 0) || math.IsInf(travelDegrees, 1) {
+		setErr(&m.err, fmt.Errorf("ev3dev: invalid servo travel for %s: %v", deviceName(m), travelDegrees))
+		return m
+	}
+	if minPulse >= midPulse || midPulse >= maxPulse {
+		setErr(&m.err, fmt.Errorf("ev3dev: invalid servo pulses for %s: %v, %v, %v (must be increasing)", deviceName(m), minPulse, midPulse, maxPulse))
+		return m
+	}
+	m.SetMinPulseSetpoint(minPulse).SetMidPulseSetpoint(midPulse).SetMaxPulseSetpoint(maxPulse)
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	m.calibration = &servoCalibration{min: minPulse, mid: midPulse, max: maxPulse, travel: travelDegrees}
+	return m
+}
+
+// SetAngle sets the position setpoint of the ServoMotor so that it turns
+// to deg degrees from its mid position. The setpoint is rounded to the
+// nearest whole position setpoint, with halves rounded away from zero.
+// SetAngle sets the ServoMotor's error state if the ServoMotor has not
+// been calibrated or deg is outside its travel.
+func (m *ServoMotor) SetAngle(deg float64) *ServoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	sp, err := m.angleSetpoint(deg)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	return m.SetPositionSetpoint(sp)
+}
+
+// angleSetpoint returns the position setpoint for deg degrees from
+// the mid position of the ServoMotor.
+func (m *ServoMotor) angleSetpoint(deg float64) (int, error) {
+	c := m.calibration
+	if c == nil {
+		return 0, fmt.Errorf("ev3dev: %s not calibrated", deviceName(m))
+	}
+	lo := float64(c.min-c.mid) * c.travel / float64(c.max-c.min)
+	hi := float64(c.max-c.mid) * c.travel / float64(c.max-c.min)
+	if !(lo <= deg && deg <= hi) {
+		return 0, fmt.Errorf("ev3dev: servo angle for %s out of range: %v (must be in %v-%v)", deviceName(m), deg, lo, hi)
+	}
+	// The pulse width offset from
+	// the mid pulse for deg.
+	pulse := deg * float64(c.max-c.min) / c.travel
+	var sp float64
+	if pulse >= 0 {
+		sp = 100 * pulse / float64(c.max-c.mid)
+	} else {
+		sp = 100 * pulse / float64(c.mid-c.min)
+	}
+	return int(math.Round(sp)), nil
+}
+
+// Angle returns the angle in degrees from its mid position that the
+// ServoMotor is set to by its position setpoint. Angle returns an error
+// if the ServoMotor has not been calibrated.
+func (m *ServoMotor) Angle() (float64, error) {
+	c := m.calibration
+	if c == nil {
+		return 0, fmt.Errorf("ev3dev: %s not calibrated", deviceName(m))
+	}
+	sp, err := m.PositionSetpoint()
+	if err != nil {
+		return 0, err
+	}
+	var pulse float64
+	if sp >= 0 {
+		pulse = float64(sp) / 100 * float64(c.max-c.mid)
+	} else {
+		pulse = float64(sp) / 100 * float64(c.mid-c.min)
+	}
+	return pulse * c.travel / float64(c.max-c.min), nil
+}
@@ -0,0 +1,114 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServoMotorAngle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(ServoMotorPath, "motor0"): {
+			minPulseSetpoint: "600",
+			midPulseSetpoint: "1500",
+			maxPulseSetpoint: "2400",
+			positionSetpoint: "0",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, ServoMotorPath, "motor0", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	m := &ServoMotor{id: 0}
+	err = m.SetAngle(0).Err()
+	if err == nil {
+		t.Error("expected error for uncalibrated servo")
+	}
+	_, err = m.Angle()
+	if err == nil {
+		t.Error("expected error for uncalibrated servo")
+	}
+
+	// An asymmetric servo turning 180° between pulses.
+	err = m.Calibrate(500*time.Millisecond, 1400*time.Millisecond, 2500*time.Millisecond, 180).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, want := range map[string]string{minPulseSetpoint: "500", midPulseSetpoint: "1400", maxPulseSetpoint: "2500"} {
+		if got := attr(name); got != want {
+			t.Errorf("unexpected %s: got:%s want:%s", name, got, want)
+		}
+	}
+	for _, test := range []struct {
+		deg    float64
+		wantSP int
+	}{
+		{deg: 0, wantSP: 0},
+		{deg: 99, wantSP: 100},
+		{deg: 49.5, wantSP: 50},
+		{deg: -81, wantSP: -100},
+		{deg: -40.5, wantSP: -50},
+	} {
+		err = m.SetAngle(test.deg).Err()
+		if err != nil {
+			t.Errorf("unexpected error for %v degrees: %v", test.deg, err)
+		}
+		if got := attr(positionSetpoint); got != strconv.Itoa(test.wantSP) {
+			t.Errorf("unexpected position setpoint for %v degrees: got:%s want:%d", test.deg, got, test.wantSP)
+		}
+		got, err := m.Angle()
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if math.Abs(got-test.deg) > 1e-9 {
+			t.Errorf("unexpected angle: got:%v want:%v", got, test.deg)
+		}
+	}
+	for _, deg := range []float64{100, -82, math.NaN()} {
+		err = m.SetAngle(deg).Err()
+		if err == nil {
+			t.Errorf("expected error for %v degrees", deg)
+		}
+	}
+
+	for _, test := range []struct {
+		min, mid, max time.Duration
+		travel        float64
+	}{
+		{min: 500 * time.Millisecond, mid: 1500 * time.Millisecond, max: 2500 * time.Millisecond, travel: 0},
+		{min: 500 * time.Millisecond, mid: 1500 * time.Millisecond, max: 2500 * time.Millisecond, travel: math.Inf(1)},
+		{min: 500 * time.Millisecond, mid: 500 * time.Millisecond, max: 2500 * time.Millisecond, travel: 180},
+		{min: 100 * time.Millisecond, mid: 1500 * time.Millisecond, max: 2500 * time.Millisecond, travel: 180},
+	} {
+		m := &ServoMotor{id: 0}
+		err = m.Calibrate(test.min, test.mid, test.max, test.travel).Err()
+		if err == nil {
+			t.Errorf("expected error for calibration %v", test)
+		}
+		if m.calibration != nil {
+			t.Errorf("calibration recorded after error for %v", test)
+		}
+	}
+}
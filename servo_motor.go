@@ -5,6 +5,7 @@
 package ev3dev
 
 import (
+	"context"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -19,6 +20,20 @@ type ServoMotor struct {
 	// Cached value:
 	driver string
 
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
+	// calibration holds the pulse widths
+	// and travel set by Calibrate. It is
+	// nil if the ServoMotor has not been
+	// calibrated.
+	calibration *servoCalibration
+
 	err error
 }
 
@@ -38,14 +53,13 @@ func (m *ServoMotor) String() string {
 
 // Err returns the error state of the ServoMotor and clears it.
 func (m *ServoMotor) Err() error {
-	err := m.err
-	m.err = nil
-	return err
+	return takeErr(&m.err)
 }
 
 // idInt and setID satisfy the idSetter interface.
 func (m *ServoMotor) setID(id int) error {
 	t := ServoMotor{id: id}
+	forgetName(&t)
 	var err error
 	t.driver, err = DriverFor(&t)
 	if err != nil {
@@ -76,6 +90,7 @@ func ServoMotorFor(port, driver string) (*ServoMotor, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&m)
 	return &m, err
 }
 
@@ -90,7 +105,13 @@ func (m *ServoMotor) Next() (*ServoMotor, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &ServoMotor{id: id}, err
+	var n ServoMotor
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
 // Driver returns the driver used by the ServoMotor.
@@ -106,24 +127,25 @@ func (m *ServoMotor) Commands() []string {
 	}
 }
 
-// Command issues a command to the ServoMotor.
-func (m *ServoMotor) Command(comm string) *ServoMotor {
-	if m.err != nil {
+// Command issues a command to the ServoMotor. The command must be one of the
+// commands listed by Commands.
+func (m *ServoMotor) Command(comm MotorCommand) *ServoMotor {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	avail := m.Commands()
 	ok := false
 	for _, c := range avail {
-		if c == comm {
+		if c == string(comm) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, command, "", comm, avail)
+		setErr(&m.err, newInvalidValueError(m, command, "", string(comm), avail))
 		return m
 	}
-	m.err = setAttributeOf(m, command, comm)
+	setErr(&m.err, setAttributeOf(m, command, string(comm)))
 	return m
 }
 
@@ -134,14 +156,14 @@ func (m *ServoMotor) MaxPulseSetpoint() (time.Duration, error) {
 
 // SetMaxPulseSetpoint sets the max pulse setpoint value for the ServoMotor
 func (m *ServoMotor) SetMaxPulseSetpoint(sp time.Duration) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 2300*time.Millisecond || 2700*time.Millisecond < sp {
-		m.err = newDurationOutOfRangeError(m, maxPulseSetpoint, sp, 2300*time.Millisecond, 2700*time.Millisecond)
+		setErr(&m.err, newDurationOutOfRangeError(m, maxPulseSetpoint, sp, 2300*time.Millisecond, 2700*time.Millisecond))
 		return m
 	}
-	m.err = setAttributeOf(m, maxPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, maxPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -152,14 +174,14 @@ func (m *ServoMotor) MidPulseSetpoint() (time.Duration, error) {
 
 // SetMidPulseSetpoint sets the mid pulse setpoint value for the ServoMotor
 func (m *ServoMotor) SetMidPulseSetpoint(sp time.Duration) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 1300*time.Millisecond || 1700*time.Millisecond < sp {
-		m.err = newDurationOutOfRangeError(m, midPulseSetpoint, sp, 1300*time.Millisecond, 1700*time.Millisecond)
+		setErr(&m.err, newDurationOutOfRangeError(m, midPulseSetpoint, sp, 1300*time.Millisecond, 1700*time.Millisecond))
 		return m
 	}
-	m.err = setAttributeOf(m, midPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, midPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -170,14 +192,14 @@ func (m *ServoMotor) MinPulseSetpoint() (time.Duration, error) {
 
 // SetMinPulseSetpoint sets the min pulse setpoint value for the ServoMotor
 func (m *ServoMotor) SetMinPulseSetpoint(sp time.Duration) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 300*time.Millisecond || 700*time.Millisecond < sp {
-		m.err = newDurationOutOfRangeError(m, minPulseSetpoint, sp, 300*time.Millisecond, 700*time.Millisecond)
+		setErr(&m.err, newDurationOutOfRangeError(m, minPulseSetpoint, sp, 300*time.Millisecond, 700*time.Millisecond))
 		return m
 	}
-	m.err = setAttributeOf(m, minPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, minPulseSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -189,14 +211,14 @@ func (m *ServoMotor) Polarity() (Polarity, error) {
 
 // SetPolarity sets the polarity of the ServoMotor
 func (m *ServoMotor) SetPolarity(p Polarity) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if p != Normal && p != Inversed {
-		m.err = newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)})
+		setErr(&m.err, newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)}))
 		return m
 	}
-	m.err = setAttributeOf(m, polarity, string(p))
+	setErr(&m.err, setAttributeOf(m, polarity, string(p)))
 	return m
 }
 
@@ -208,14 +230,14 @@ func (m *ServoMotor) PositionSetpoint() (int, error) {
 
 // SetPositionSetpoint sets the position value for the ServoMotor.
 func (m *ServoMotor) SetPositionSetpoint(sp int) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < -100 || 100 < sp {
-		m.err = newValueOutOfRangeError(m, positionSetpoint, sp, -100, 100)
+		setErr(&m.err, newValueOutOfRangeError(m, positionSetpoint, sp, -100, 100))
 		return m
 	}
-	m.err = setAttributeOf(m, positionSetpoint, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, positionSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -226,20 +248,20 @@ func (m *ServoMotor) RateSetpoint() (time.Duration, error) {
 
 // SetRateSetpoint sets the rate setpoint value for the ServoMotor.
 func (m *ServoMotor) SetRateSetpoint(sp time.Duration) *ServoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, rateSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, rateSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, rateSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rateSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
 // State returns the current state of the ServoMotor.
 func (m *ServoMotor) State() (MotorState, error) {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return 0, m.Err()
 	}
 	return stateFrom(attributeOf(m, state))
@@ -630,7 +630,7 @@ func TestServoMotor(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err)
 			}
 			for _, command := range m.Commands() {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err != nil {
 					t.Errorf("unexpected error for command %q: %v", command, err)
 				}
@@ -642,7 +642,7 @@ func TestServoMotor(t *testing.T) {
 				}
 			}
 			for _, command := range []string{"invalid", "another"} {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err == nil {
 					t.Errorf("expected error for command %q", command)
 				}
@@ -0,0 +1,63 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "time"
+
+// MoveTo moves the ServoMotor from its current position setpoint to sp
+// over the duration d. MoveTo sets the rate setpoint for the distance to
+// be travelled, sets the position setpoint to sp and issues the run
+// command. The servo is assumed to have reached its current position
+// setpoint. If sp is the current position setpoint, the rate setpoint is
+// left unchanged.
+func (m *ServoMotor) MoveTo(sp int, d time.Duration) *ServoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if sp < -100 || 100 < sp {
+		setErr(&m.err, newValueOutOfRangeError(m, positionSetpoint, sp, -100, 100))
+		return m
+	}
+	if d < 0 {
+		setErr(&m.err, newNegativeDurationError(m, rateSetpoint, d))
+		return m
+	}
+	from, err := m.PositionSetpoint()
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	if sp != from {
+		m.SetRateSetpoint(rateFor(from, sp, d))
+	}
+	return m.SetPositionSetpoint(sp).Command(CommandRun)
+}
+
+// MoveToAngle moves the ServoMotor to deg degrees from its mid position
+// over the duration d as described for MoveTo. The ServoMotor must have
+// been calibrated.
+func (m *ServoMotor) MoveToAngle(deg float64, d time.Duration) *ServoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	sp, err := m.angleSetpoint(deg)
+	if err != nil {
+		setErr(&m.err, err)
+		return m
+	}
+	return m.MoveTo(sp, d)
+}
+
+// rateFor returns the rate setpoint for a move between the position
+// setpoints from and to that takes the duration d. The rate setpoint is
+// the time taken to travel from the mid position to either end, a
+// distance of 100.
+func rateFor(from, to int, d time.Duration) time.Duration {
+	dist := to - from
+	if dist < 0 {
+		dist = -dist
+	}
+	return d * 100 / time.Duration(dist)
+}
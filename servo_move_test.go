@@ -0,0 +1,102 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServoMotorMoveTo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(ServoMotorPath, "motor0"): {
+			minPulseSetpoint: "600",
+			midPulseSetpoint: "1500",
+			maxPulseSetpoint: "2400",
+			positionSetpoint: "0",
+			rateSetpoint:     "0",
+			command:          "",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, ServoMotorPath, "motor0", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	m := &ServoMotor{id: 0}
+	for _, test := range []struct {
+		sp       int
+		d        time.Duration
+		wantRate string
+	}{
+		{sp: 50, d: 2 * time.Second, wantRate: "4000"},
+		{sp: -100, d: 3 * time.Second, wantRate: "2000"},
+		{sp: -100, d: time.Second, wantRate: "2000"},
+		{sp: -99, d: 0, wantRate: "0"},
+	} {
+		err = m.MoveTo(test.sp, test.d).Err()
+		if err != nil {
+			t.Errorf("unexpected error moving to %d: %v", test.sp, err)
+		}
+		if got := attr(rateSetpoint); got != test.wantRate {
+			t.Errorf("unexpected rate setpoint moving to %d over %v: got:%s want:%s", test.sp, test.d, got, test.wantRate)
+		}
+		if got, want := attr(positionSetpoint), strconv.Itoa(test.sp); got != want {
+			t.Errorf("unexpected position setpoint: got:%s want:%s", got, want)
+		}
+		if got := attr(command); got != string(CommandRun) {
+			t.Errorf("unexpected command: got:%q want:%q", got, CommandRun)
+		}
+	}
+	for _, test := range []struct {
+		sp int
+		d  time.Duration
+	}{
+		{sp: 101, d: time.Second},
+		{sp: 0, d: -time.Second},
+	} {
+		err = m.MoveTo(test.sp, test.d).Err()
+		if err == nil {
+			t.Errorf("expected error moving to %d over %v", test.sp, test.d)
+		}
+		if got := attr(positionSetpoint); got != "-99" {
+			t.Errorf("position setpoint changed after error: got:%s", got)
+		}
+	}
+
+	err = m.MoveToAngle(45, time.Second).Err()
+	if err == nil {
+		t.Error("expected error for uncalibrated servo")
+	}
+	err = m.Calibrate(600*time.Millisecond, 1500*time.Millisecond, 2400*time.Millisecond, 180).
+		MoveTo(0, 0).
+		MoveToAngle(45, time.Second).Err()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attr(positionSetpoint); got != "50" {
+		t.Errorf("unexpected position setpoint for 45°: got:%s want:50", got)
+	}
+	if got := attr(rateSetpoint); got != "2000" {
+		t.Errorf("unexpected rate setpoint for 45° over 1s: got:%s want:2000", got)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Easing maps the fraction of a sweep's duration that has elapsed, in
+// [0, 1], to the fraction of the sweep's distance that has been covered.
+// An Easing should map 0 to 0 and 1 to 1.
+type Easing func(t float64) float64
+
+// EaseLinear moves at a constant rate.
+func EaseLinear(t float64) float64 { return t }
+
+// EaseInOut accelerates from rest and decelerates to rest following a
+// cubic smoothstep.
+func EaseInOut(t float64) float64 { return t * t * (3 - 2*t) }
+
+// sweepTick is the interval between position setpoints written by
+// Sweep. It is the period of the servo control pulses.
+const sweepTick = 20 * time.Millisecond
+
+// Sweep sets the position setpoint of the ServoMotor to from, issues the
+// run command and then moves the position setpoint to to over the duration
+// d, following ease. If ease is nil, EaseLinear is used. Setpoints are
+// written every 20ms by a goroutine that does not use the ServoMotor's
+// error state.
+//
+// The returned channel receives the result of the sweep and is then
+// closed. The sweep ends early with the context's error if ctx is done,
+// or with context.Canceled if the ServoMotor is closed.
+func (m *ServoMotor) Sweep(ctx context.Context, from, to int, d time.Duration, ease Easing) <-chan error {
+	c := make(chan error, 1)
+	for _, sp := range []int{from, to} {
+		if sp < -100 || 100 < sp {
+			c <- newValueOutOfRangeError(m, positionSetpoint, sp, -100, 100)
+			close(c)
+			return c
+		}
+	}
+	if ease == nil {
+		ease = EaseLinear
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	unhook := addCloseHook(&m.onClose, cancel)
+	go func() {
+		defer close(c)
+		defer cancel()
+		defer unhook()
+		c <- sweep(ctx, m, from, to, d, ease)
+	}()
+	return c
+}
+
+// sweep writes the position setpoints of a sweep to d.
+func sweep(ctx context.Context, d Device, from, to int, dur time.Duration, ease Easing) error {
+	err := setAttributeOf(d, positionSetpoint, strconv.Itoa(from))
+	if err != nil {
+		return err
+	}
+	err = setAttributeOf(d, command, string(CommandRun))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	ticker := time.NewTicker(sweepTick)
+	defer ticker.Stop()
+	last := from
+	for {
+		f := 1.0
+		if dur > 0 {
+			f = math.Min(float64(time.Since(start))/float64(dur), 1)
+		}
+		sp := from + int(math.Round(float64(to-from)*ease(f)))
+		// Easings that overshoot are
+		// limited to the servo's range.
+		switch {
+		case sp < -100:
+			sp = -100
+		case sp > 100:
+			sp = 100
+		}
+		if sp != last {
+			err = setAttributeOf(d, positionSetpoint, strconv.Itoa(sp))
+			if err != nil {
+				return err
+			}
+			last = sp
+		}
+		if f == 1 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
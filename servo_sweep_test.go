@@ -0,0 +1,106 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasing(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ease Easing
+	}{
+		{name: "linear", ease: EaseLinear},
+		{name: "in-out", ease: EaseInOut},
+	} {
+		if got := test.ease(0); got != 0 {
+			t.Errorf("unexpected %s start: got:%v want:0", test.name, got)
+		}
+		if got := test.ease(1); got != 1 {
+			t.Errorf("unexpected %s end: got:%v want:1", test.name, got)
+		}
+		if got := test.ease(0.5); math.Abs(got-0.5) > 1e-12 {
+			t.Errorf("unexpected %s midpoint: got:%v want:0.5", test.name, got)
+		}
+		last := 0.0
+		for i := 1; i <= 100; i++ {
+			got := test.ease(float64(i) / 100)
+			if got < last {
+				t.Errorf("%s easing not monotonic at %v", test.name, float64(i)/100)
+			}
+			last = got
+		}
+	}
+	// Ease in-out starts and ends more
+	// slowly than a linear sweep.
+	if EaseInOut(0.1) >= 0.1 || EaseInOut(0.9) <= 0.9 {
+		t.Error("ease in-out does not ease")
+	}
+}
+
+func TestServoMotorSweep(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(ServoMotorPath, "motor0"): {
+			positionSetpoint: "0",
+			command:          "",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, ServoMotorPath, "motor0", name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	m := &ServoMotor{id: 0}
+	err = <-m.Sweep(context.Background(), -100, 101, time.Second, nil)
+	if err == nil {
+		t.Error("expected error for out of range sweep")
+	}
+
+	start := time.Now()
+	err = <-m.Sweep(context.Background(), -80, 60, 100*time.Millisecond, EaseInOut)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("sweep ended early: %v", elapsed)
+	}
+	if got := attr(positionSetpoint); got != "60" {
+		t.Errorf("unexpected final position setpoint: got:%s want:60", got)
+	}
+	if got := attr(command); got != string(CommandRun) {
+		t.Errorf("unexpected command: got:%q want:%q", got, CommandRun)
+	}
+
+	c := m.Sweep(context.Background(), 0, 100, time.Hour, nil)
+	m.Close()
+	select {
+	case err = <-c:
+		if err != context.Canceled {
+			t.Errorf("unexpected error after close: got:%v want:%v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("sweep not cancelled by close")
+	}
+}
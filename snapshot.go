@@ -0,0 +1,98 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"sync"
+	"time"
+)
+
+// Snapshot is the dynamic state of a TachoMotor read by a single call
+// to Snapshot.
+type Snapshot struct {
+	// Time is the time the
+	// reads were started.
+	Time time.Time
+
+	Position  int
+	Speed     int
+	DutyCycle int
+	State     MotorState
+
+	PositionSetpoint  int
+	SpeedSetpoint     int
+	DutyCycleSetpoint int
+	TimeSetpoint      time.Duration
+}
+
+// Snapshot returns the position, speed, duty cycle, state and setpoints
+// of the TachoMotor. The attributes are read concurrently so that the
+// returned values are close to consistent and the cost of the call is
+// close to that of a single attribute read. If more than one attribute
+// read fails, the error for the first field in the order of the Snapshot
+// struct is returned.
+func (m *TachoMotor) Snapshot() (Snapshot, error) {
+	err := m.Err()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	s := Snapshot{Time: time.Now()}
+	reads := []func(d *TachoMotor) error{
+		func(d *TachoMotor) (err error) {
+			s.Position, err = intFrom(attributeOf(d, position))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.Speed, err = intFrom(attributeOf(d, speed))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.DutyCycle, err = intFrom(attributeOf(d, dutyCycle))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.State, err = stateFrom(attributeOf(d, state))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.PositionSetpoint, err = intFrom(attributeOf(d, positionSetpoint))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.SpeedSetpoint, err = intFrom(attributeOf(d, speedSetpoint))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.DutyCycleSetpoint, err = intFrom(attributeOf(d, dutyCycleSetpoint))
+			return err
+		},
+		func(d *TachoMotor) (err error) {
+			s.TimeSetpoint, err = durationFrom(attributeOf(d, timeSetpoint))
+			return err
+		},
+	}
+
+	errs := make([]error, len(reads))
+	var wg sync.WaitGroup
+	for i, r := range reads {
+		// Each read is given its own copy of the
+		// handle since reading an attribute clears
+		// the handle's error state.
+		d := *m
+		wg.Add(1)
+		go func(i int, read func(*TachoMotor) error) {
+			defer wg.Done()
+			errs[i] = read(&d)
+		}(i, r)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return Snapshot{}, err
+		}
+	}
+	return s, nil
+}
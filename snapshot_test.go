@@ -0,0 +1,72 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {
+			position:          "-120",
+			speed:             "450",
+			dutyCycle:         "42",
+			state:             "running ramping",
+			positionSetpoint:  "360",
+			speedSetpoint:     "500",
+			dutyCycleSetpoint: "0",
+			timeSetpoint:      "1500",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	before := time.Now()
+	got, err := m.Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Time.Before(before) || got.Time.After(time.Now()) {
+		t.Errorf("unexpected snapshot time: %v", got.Time)
+	}
+	got.Time = time.Time{}
+	want := Snapshot{
+		Position:          -120,
+		Speed:             450,
+		DutyCycle:         42,
+		State:             Running | Ramping,
+		PositionSetpoint:  360,
+		SpeedSetpoint:     500,
+		DutyCycleSetpoint: 0,
+		TimeSetpoint:      1500 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("unexpected snapshot:\ngot: %+v\nwant:%+v", got, want)
+	}
+
+	err = os.Remove(filepath.Join(dir, TachoMotorPath, "motor0", speed))
+	if err != nil {
+		t.Fatalf("failed to remove speed: %v", err)
+	}
+	_, err = m.Snapshot()
+	if err == nil {
+		t.Error("expected error for missing attribute")
+	}
+	if err := m.Err(); err != nil {
+		t.Errorf("unexpected sticky error after snapshot: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "time"
+
+// StallEvent is a change in the stall condition of a motor. A motor is in
+// the stall condition when its state includes Stalled or Overloaded.
+type StallEvent struct {
+	// State is the motor state when
+	// the change was confirmed.
+	State MotorState
+
+	// Stalled is whether the motor
+	// is in the stall condition.
+	Stalled bool
+}
+
+// StallWatcher reports changes in the stall condition of a motor, read
+// from a channel of motor states such as that returned by TachoMotor.Watch.
+// For example, to stop a motor that has stalled for 200ms:
+//
+//	w := ev3dev.StallWatcher{
+//		Debounce: 200 * time.Millisecond,
+//		OnStall:  func(ev3dev.MotorState) { m.Command(ev3dev.CommandStop) },
+//	}
+//	go w.Run(m.Watch(ctx, 0))
+type StallWatcher struct {
+	// Debounce is the time that a change in
+	// the stall condition must persist before
+	// it is reported. Changes that revert
+	// within Debounce are not reported.
+	Debounce time.Duration
+
+	// OnStall and OnClear, if not nil, are
+	// called by Run with the motor state when
+	// the motor enters and leaves the stall
+	// condition respectively.
+	OnStall func(MotorState)
+	OnClear func(MotorState)
+}
+
+// stallMask is the set of motor state
+// flags indicating the stall condition.
+const stallMask = Stalled | Overloaded
+
+// Run reads motor states from states until it is closed, calling OnStall
+// and OnClear as the stall condition changes. The callbacks are called
+// sequentially on the goroutine calling Run.
+func (w *StallWatcher) Run(states <-chan MotorState) {
+	w.run(states, func(e StallEvent) {
+		switch {
+		case e.Stalled && w.OnStall != nil:
+			w.OnStall(e.State)
+		case !e.Stalled && w.OnClear != nil:
+			w.OnClear(e.State)
+		}
+	})
+}
+
+// Events returns a channel that receives the changes in the stall
+// condition of the motor states read from states. The channel is closed
+// when states is closed. OnStall and OnClear are not called.
+func (w *StallWatcher) Events(states <-chan MotorState) <-chan StallEvent {
+	c := make(chan StallEvent)
+	go func() {
+		defer close(c)
+		w.run(states, func(e StallEvent) { c <- e })
+	}()
+	return c
+}
+
+// run reads motor states from states until it is closed, calling emit
+// for each debounced change in the stall condition. The motor is initially
+// assumed not to be stalled.
+func (w *StallWatcher) run(states <-chan MotorState, emit func(StallEvent)) {
+	var (
+		stalled bool
+		last    MotorState
+
+		timer   *time.Timer
+		pending <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case s, ok := <-states:
+			if !ok {
+				return
+			}
+			last = s
+			if (s&stallMask != 0) == stalled {
+				// The change reverted before
+				// it was confirmed.
+				if timer != nil {
+					timer.Stop()
+				}
+				pending = nil
+				continue
+			}
+			if pending != nil {
+				// The change is already
+				// waiting to be confirmed.
+				continue
+			}
+			if w.Debounce <= 0 {
+				stalled = !stalled
+				emit(StallEvent{State: s, Stalled: stalled})
+				continue
+			}
+			timer = time.NewTimer(w.Debounce)
+			pending = timer.C
+		case <-pending:
+			pending = nil
+			stalled = !stalled
+			emit(StallEvent{State: last, Stalled: stalled})
+		}
+	}
+}
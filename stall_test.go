@@ -0,0 +1,108 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStallWatcherEvents(t *testing.T) {
+	states := make(chan MotorState)
+	w := StallWatcher{}
+	events := w.Events(states)
+
+	go func() {
+		for _, s := range []MotorState{
+			0,
+			Running,
+			Running | Stalled,
+			Running | Stalled | Overloaded,
+			Running | Overloaded,
+			Running,
+			Holding | Stalled,
+		} {
+			states <- s
+		}
+		close(states)
+	}()
+
+	var got []StallEvent
+	for e := range events {
+		got = append(got, e)
+	}
+	want := []StallEvent{
+		{State: Running | Stalled, Stalled: true},
+		{State: Running, Stalled: false},
+		{State: Holding | Stalled, Stalled: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events:\ngot: %+v\nwant:%+v", got, want)
+	}
+}
+
+func TestStallWatcherDebounce(t *testing.T) {
+	const debounce = 50 * time.Millisecond
+
+	states := make(chan MotorState)
+	stalls := make(chan MotorState, 10)
+	clears := make(chan MotorState, 10)
+	w := StallWatcher{
+		Debounce: debounce,
+		OnStall:  func(s MotorState) { stalls <- s },
+		OnClear:  func(s MotorState) { clears <- s },
+	}
+	done := make(chan struct{})
+	go func() {
+		w.Run(states)
+		close(done)
+	}()
+
+	// A short stall is not reported.
+	states <- Running | Stalled
+	time.Sleep(debounce / 5)
+	states <- Running
+	time.Sleep(2 * debounce)
+	select {
+	case s := <-stalls:
+		t.Errorf("unexpected stall report for glitch: %v", s)
+	default:
+	}
+
+	// A persistent stall is reported with the
+	// latest state, but only after the debounce.
+	start := time.Now()
+	states <- Running | Stalled
+	states <- Running | Stalled | Overloaded
+	select {
+	case s := <-stalls:
+		if elapsed := time.Since(start); elapsed < debounce {
+			t.Errorf("stall reported before debounce: %v", elapsed)
+		}
+		if s != Running|Stalled|Overloaded {
+			t.Errorf("unexpected stall state: got:%v want:%v", s, Running|Stalled|Overloaded)
+		}
+	case <-time.After(10 * debounce):
+		t.Fatal("timed out waiting for stall")
+	}
+
+	states <- 0
+	select {
+	case s := <-clears:
+		if s != 0 {
+			t.Errorf("unexpected clear state: got:%v want:0", s)
+		}
+	case <-time.After(10 * debounce):
+		t.Fatal("timed out waiting for clear")
+	}
+
+	close(states)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after states was closed")
+	}
+}
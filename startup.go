@@ -0,0 +1,217 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultStartupDuration is the time a StartupBanner is shown if its
+// Duration is zero.
+const DefaultStartupDuration = 3 * time.Second
+
+// StartupBanner is a summary of the brick's state shown on the LCD when a
+// program starts. The zero value shows the host name, the IPv4 addresses
+// of the network interfaces, the battery charge and the attached devices
+// for DefaultStartupDuration.
+type StartupBanner struct {
+	// Title is the first line of the
+	// banner. If Title is empty, the
+	// host name is used.
+	Title string
+
+	// Battery is the power supply
+	// reported by the banner.
+	Battery PowerSupply
+
+	// Lines holds additional lines
+	// shown after the summary.
+	Lines []string
+
+	// Duration is the time the banner
+	// is shown by Show. If Duration is
+	// zero, DefaultStartupDuration is
+	// used and if it is negative Show
+	// returns immediately.
+	Duration time.Duration
+}
+
+// StartupScreen shows the zero StartupBanner on dst, which is intended to
+// be the brick's LCD. It is equivalent to StartupBanner{}.Show(dst).
+func StartupScreen(dst draw.Image) error {
+	return StartupBanner{}.Show(dst)
+}
+
+// Show draws the banner on dst and waits for the banner's duration before
+// returning, leaving the banner on the display. Lines that do not fit on
+// dst are elided. If any part of the summary could not be read, it is
+// marked as unknown on the display and the first error is returned.
+func (b StartupBanner) Show(dst draw.Image) error {
+	lines, err := b.Summary()
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	rows := dst.Bounds().Dy() / glyphHeight
+	if len(lines) > rows && rows > 0 {
+		lines = append(lines[:rows-1:rows-1], "...")
+	}
+	pt := dst.Bounds().Min
+	for _, l := range lines {
+		drawText(dst, pt, l, color.Black)
+		pt.Y += glyphHeight
+	}
+	d := b.Duration
+	if d == 0 {
+		d = DefaultStartupDuration
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+	return err
+}
+
+var (
+	// hostname and interfaceAddrs return the host
+	// name and the network interface addresses.
+	// They are altered during testing.
+	hostname       = os.Hostname
+	interfaceAddrs = netInterfaceAddrs
+)
+
+// Summary returns the lines of text drawn by Show. If any part of the
+// summary could not be read, it is marked as unknown and the first error
+// is returned.
+func (b StartupBanner) Summary() ([]string, error) {
+	var (
+		lines    []string
+		firstErr error
+	)
+	note := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	title := b.Title
+	if title == "" {
+		h, err := hostname()
+		if err != nil {
+			note(err)
+			h = "unknown host"
+		}
+		title = h
+	}
+	lines = append(lines, title)
+
+	addrs, err := interfaceAddrs()
+	switch {
+	case err != nil:
+		note(err)
+		lines = append(lines, "network unknown")
+	case len(addrs) == 0:
+		lines = append(lines, "no network")
+	default:
+		lines = append(lines, addrs...)
+	}
+
+	pct, v, err := batteryCharge(b.Battery)
+	if err != nil {
+		note(err)
+		lines = append(lines, "battery unknown")
+	} else {
+		lines = append(lines, fmt.Sprintf("battery %d%% %.1fV", pct, v))
+	}
+
+	t, err := ReadDeviceTree()
+	if err != nil {
+		note(err)
+		lines = append(lines, "devices unknown")
+	} else {
+		var devices []*TreeNode
+		for _, p := range t.Ports {
+			devices = append(devices, p.Devices...)
+		}
+		devices = append(devices, t.Unattached...)
+		if len(devices) == 0 {
+			lines = append(lines, "no devices")
+		}
+		for _, d := range devices {
+			lines = append(lines, shortAddress(d.Address)+" "+d.Driver)
+		}
+	}
+
+	return append(lines, b.Lines...), firstErr
+}
+
+// netInterfaceAddrs returns the IPv4 addresses of the system's network
+// interfaces that are up and not loopback, each prefixed by the name of
+// its interface.
+func netInterfaceAddrs() ([]string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range addrs {
+			ip, ok := a.(*net.IPNet)
+			if !ok || ip.IP.To4() == nil {
+				continue
+			}
+			lines = append(lines, iface.Name+" "+ip.IP.String())
+		}
+	}
+	return lines, nil
+}
+
+// batteryCharge returns the charge of the battery p as a percentage of
+// its design voltage range, and its voltage.
+func batteryCharge(p PowerSupply) (pct int, v float64, err error) {
+	v, err = p.Voltage()
+	if err != nil {
+		return 0, 0, err
+	}
+	min, err := p.VoltageMin()
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := p.VoltageMax()
+	if err != nil {
+		return 0, 0, err
+	}
+	if max <= min {
+		return 0, 0, fmt.Errorf("ev3dev: invalid design voltage range for %s: %v-%vV", p, min, max)
+	}
+	pct = int(math.Round(100 * (v - min) / (max - min)))
+	if pct < 0 {
+		pct = 0
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	return pct, v, nil
+}
+
+// shortAddress returns the address with its leading
+// "ev3-ports:" or similar bus name removed.
+func shortAddress(addr string) string {
+	if i := strings.Index(addr, ":"); i >= 0 {
+		return addr[i+1:]
+	}
+	return addr
+}
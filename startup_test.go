@@ -0,0 +1,184 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStartupSummary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(PowerSupplyPath, "lego-ev3-battery"): {
+			voltageNow:       "7800000",
+			voltageMinDesign: "6000000",
+			voltageMaxDesign: "9000000",
+		},
+		filepath.Join(LegoPortPath, "port4"):    {address: "ev3-ports:outA", driverName: "legoev3-output-port", mode: "auto"},
+		filepath.Join(LegoPortPath, "port0"):    {address: "ev3-ports:in1", driverName: "legoev3-input-port", mode: "auto"},
+		filepath.Join(TachoMotorPath, "motor0"): {address: "ev3-ports:outA", driverName: "lego-ev3-l-motor"},
+		filepath.Join(SensorPath, "sensor0"):    {address: "ev3-ports:in1", driverName: "lego-ev3-touch", mode: "TOUCH"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+	defer func(h func() (string, error), a func() ([]string, error)) {
+		hostname = h
+		interfaceAddrs = a
+	}(hostname, interfaceAddrs)
+	hostname = func() (string, error) { return "ev3dev", nil }
+	interfaceAddrs = func() ([]string, error) { return []string{"wlan0 192.168.1.5"}, nil }
+
+	got, err := StartupBanner{Lines: []string{"ready"}}.Summary()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := []string{
+		"ev3dev",
+		"wlan0 192.168.1.5",
+		"battery 60% 7.8V",
+		"in1 lego-ev3-touch",
+		"outA lego-ev3-l-motor",
+		"ready",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected summary:\ngot: %q\nwant:%q", got, want)
+	}
+
+	errNet := errors.New("no interfaces")
+	interfaceAddrs = func() ([]string, error) { return nil, errNet }
+	err = os.Remove(filepath.Join(dir, PowerSupplyPath, "lego-ev3-battery", voltageNow))
+	if err != nil {
+		t.Fatalf("failed to remove voltage: %v", err)
+	}
+	got, err = StartupBanner{Title: "robot"}.Summary()
+	if err != errNet {
+		t.Errorf("unexpected error: got:%v want:%v", err, errNet)
+	}
+	want = []string{
+		"robot",
+		"network unknown",
+		"battery unknown",
+		"in1 lego-ev3-touch",
+		"outA lego-ev3-l-motor",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected summary after errors:\ngot: %q\nwant:%q", got, want)
+	}
+}
+
+func TestStartupShow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(PowerSupplyPath, "lego-ev3-battery"): {
+			voltageNow:       "9000000",
+			voltageMinDesign: "6000000",
+			voltageMaxDesign: "9000000",
+		},
+		filepath.Join(LegoPortPath, "port0"): {address: "ev3-ports:in1", driverName: "legoev3-input-port", mode: "auto"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+	defer func(h func() (string, error), a func() ([]string, error)) {
+		hostname = h
+		interfaceAddrs = a
+	}(hostname, interfaceAddrs)
+	hostname = func() (string, error) { return "ev3dev", nil }
+	interfaceAddrs = func() ([]string, error) { return nil, nil }
+
+	// The EV3 LCD is 178x128, which holds nine lines.
+	img := image.NewGray(image.Rect(0, 0, 178, 128))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	b := StartupBanner{Lines: make([]string, 10), Duration: -1}
+	for i := range b.Lines {
+		b.Lines[i] = "line"
+	}
+	err = b.Show(img)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	var black, white int
+	for _, p := range img.Pix {
+		switch p {
+		case 0:
+			black++
+		case 0xff:
+			white++
+		default:
+			t.Fatalf("unexpected pixel value: %#x", p)
+		}
+	}
+	if black == 0 || white == 0 {
+		t.Errorf("expected text on white background: black=%d white=%d", black, white)
+	}
+	// Nothing is drawn below the ninth line.
+	for y := 9 * glyphHeight; y < 128; y++ {
+		for x := 0; x < 178; x++ {
+			if img.GrayAt(x, y) != (color.Gray{Y: 0xff}) {
+				t.Fatalf("unexpected pixel drawn at (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 2*glyphAdvance, glyphHeight))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	end := drawText(img, image.Point{}, "A\x01", color.Black)
+	if end != (image.Point{X: 2 * glyphAdvance}) {
+		t.Errorf("unexpected end point: got:%v want:%v", end, image.Point{X: 2 * glyphAdvance})
+	}
+	var got []string
+	for y := 0; y < glyphHeight; y++ {
+		row := make([]byte, 2*glyphAdvance)
+		for x := range row {
+			row[x] = '.'
+			if img.GrayAt(x, y).Y == 0 {
+				row[x] = '#'
+			}
+		}
+		got = append(got, string(row))
+	}
+	want := []string{
+		"..............",
+		"..............",
+		"..##....####..",
+		".#..#..#....#.",
+		"#....#.#....#.",
+		"#....#......#.",
+		"#....#.....#..",
+		"######....#...",
+		"#....#....#...",
+		"#....#........",
+		"#....#....#...",
+		"..............",
+		"..............",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected glyphs:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// strict is non-zero when strict mode is enabled.
+var strict int32
+
+// SetStrict sets whether strict mode is enabled. Strict mode is off by default.
+//
+// In strict mode, device handles created by the XxxFor constructors, the
+// Next methods and LEDs are checked when they are garbage collected, and a
+// log message is written for any handle holding a sticky error that was set
+// by a fluent method call but never retrieved with Err. Strict mode is
+// intended for debugging and adds a finalizer to every handle created while
+// it is enabled.
+func SetStrict(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&strict, v)
+}
+
+// unreader is a device handle that can report its sticky error
+// without clearing it. LED handles are unreaders but are not Devices.
+type unreader interface {
+	String() string
+	unreadErr() error
+}
+
+func (m *TachoMotor) unreadErr() error     { return loadErr(&m.err) }
+func (m *DCMotor) unreadErr() error        { return loadErr(&m.err) }
+func (m *ServoMotor) unreadErr() error     { return loadErr(&m.err) }
+func (m *LinearActuator) unreadErr() error { return loadErr(&m.err) }
+func (s *Sensor) unreadErr() error         { return s.err }
+func (p *LegoPort) unreadErr() error       { return p.err }
+func (l *LED) unreadErr() error            { return l.err }
+
+// setStrictFinalizer adds a finalizer to d that logs an unread sticky
+// error if strict mode is enabled.
+func setStrictFinalizer(d unreader) {
+	if atomic.LoadInt32(&strict) == 0 {
+		return
+	}
+	runtime.SetFinalizer(d, func(d unreader) {
+		err := d.unreadErr()
+		if err != nil {
+			name := d.String()
+			if dev, ok := d.(Device); ok {
+				name = deviceName(dev)
+			}
+			log.Printf("ev3dev: %s finalized with unread error: %v", name, err)
+		}
+	})
+}
+
+// Check returns an error if any of the provided devices holds a sticky
+// error that has not been retrieved with Err. Check does not clear the
+// sticky errors. Devices that do not hold sticky errors are ignored.
+// Check operates independently of strict mode.
+func Check(devs ...Device) error {
+	var errs unreadErrors
+	for _, d := range devs {
+		u, ok := d.(unreader)
+		if !ok {
+			continue
+		}
+		err := u.unreadErr()
+		if err != nil {
+			errs = append(errs, unreadError{dev: d, name: deviceName(d), err: err})
+		}
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+type unreadError struct {
+	dev  Device
+	name string
+	err  error
+}
+
+func (e unreadError) Error() string {
+	return fmt.Sprintf("unread error for %s: %v", e.name, e.err)
+}
+
+func (e unreadError) Cause() error  { return e.err }
+func (e unreadError) Unwrap() error { return e.err }
+
+// unreadErrors is the error returned by Check.
+type unreadErrors []unreadError
+
+func (e unreadErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return "ev3dev: " + strings.Join(s, "; ")
+}
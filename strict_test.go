@@ -0,0 +1,112 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	err := Check()
+	if err != nil {
+		t.Errorf("unexpected error for empty check: %v", err)
+	}
+
+	errTest := errors.New("test error")
+	m := &TachoMotor{id: 1}
+	s := &Sensor{id: 2, err: errTest}
+	p := &LegoPort{id: 3, err: errTest}
+
+	err = Check(m, mockDevice{})
+	if err != nil {
+		t.Errorf("unexpected error for devices without errors: %v", err)
+	}
+
+	err = Check(m, s, mockDevice{}, p)
+	want := "ev3dev: unread error for sensor2: test error; unread error for port3: test error"
+	if err == nil || err.Error() != want {
+		t.Errorf("unexpected check error: got:%v want:%s", err, want)
+	}
+	if s.err != errTest || p.err != errTest {
+		t.Error("unexpected clearing of sticky error by Check")
+	}
+
+	if s.Err() != errTest {
+		t.Error("failed to retrieve sticky error")
+	}
+	err = Check(m, s)
+	if err != nil {
+		t.Errorf("unexpected error after retrieving sticky errors: %v", err)
+	}
+}
+
+func TestStrictFinalizer(t *testing.T) {
+	var buf lockedBuffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	SetStrict(true)
+	defer SetStrict(false)
+
+	errTest := errors.New("test error")
+	func() {
+		unread := &LED{Name: LEDName{name: "led0:red:brick-status"}, err: errTest}
+		setStrictFinalizer(unread)
+		read := &TachoMotor{id: 7, err: errTest}
+		setStrictFinalizer(read)
+		read.Err()
+	}()
+
+	want := "ev3dev: led0:red:brick-status finalized with unread error: test error"
+	for deadline := time.Now().Add(5 * time.Second); !strings.Contains(buf.String(), want); {
+		if time.Now().After(deadline) {
+			t.Fatalf("missing unread error log message: got:%q want:%q", buf.String(), want)
+		}
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if strings.Contains(buf.String(), "motor7") {
+		t.Errorf("unexpected log message for handle with retrieved error: %q", buf.String())
+	}
+
+	SetStrict(false)
+	func() {
+		d := &LED{Name: LEDName{name: "led1:green:brick-status"}, err: errTest}
+		setStrictFinalizer(d)
+	}()
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if strings.Contains(buf.String(), "led1") {
+		t.Errorf("unexpected log message with strict mode disabled: %q", buf.String())
+	}
+}
+
+// lockedBuffer is a bytes.Buffer that is safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
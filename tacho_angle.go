@@ -0,0 +1,96 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"math"
+)
+
+// GearRatio returns the gear ratio set by SetGearRatio. The
+// default gear ratio is one.
+func (m *TachoMotor) GearRatio() float64 {
+	if m.gearRatio == 0 {
+		return 1
+	}
+	return m.gearRatio
+}
+
+// SetGearRatio sets the gear ratio between the TachoMotor and its output
+// shaft, expressed as the number of motor rotations for each rotation of
+// the output shaft. A gear train with a 12 tooth gear on the motor driving
+// a 36 tooth gear on the output has a ratio of 3. The ratio need not be an
+// integer. The ratio is used by the angle and rotation methods and is not
+// sent to the device. SetGearRatio sets the TachoMotor's error state if r
+// is not a positive finite number.
+func (m *TachoMotor) SetGearRatio(r float64) *TachoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if !(r > 0) || math.IsInf(r, 1) {
+		setErr(&m.err, fmt.Errorf("ev3dev: invalid gear ratio for %s: %v", deviceName(m), r))
+		return m
+	}
+	m.gearRatio = r
+	return m
+}
+
+// PositionDegrees returns the current position of the TachoMotor's output
+// shaft in degrees, taking into account the gear ratio set by SetGearRatio.
+func (m *TachoMotor) PositionDegrees() (float64, error) {
+	rot, err := m.PositionRotations()
+	return rot * 360, err
+}
+
+// PositionRotations returns the current position of the TachoMotor's output
+// shaft in rotations, taking into account the gear ratio set by SetGearRatio.
+func (m *TachoMotor) PositionRotations() (float64, error) {
+	if m.countPerRot <= 0 {
+		return 0, fmt.Errorf("ev3dev: no count per rotation for %s", deviceName(m))
+	}
+	pos, err := m.Position()
+	if err != nil {
+		return 0, err
+	}
+	return float64(pos) / (float64(m.countPerRot) * m.GearRatio()), nil
+}
+
+// SetPositionSetpointDegrees sets the position setpoint of the TachoMotor
+// so that its output shaft is moved to deg degrees, taking into account the
+// gear ratio set by SetGearRatio. The setpoint is rounded to the nearest
+// tacho count, with halves rounded away from zero.
+func (m *TachoMotor) SetPositionSetpointDegrees(deg float64) *TachoMotor {
+	return m.setPositionSetpointCounts(deg, 360)
+}
+
+// SetPositionSetpointRotations sets the position setpoint of the TachoMotor
+// so that its output shaft is moved to rot rotations, taking into account
+// the gear ratio set by SetGearRatio. The setpoint is rounded to the nearest
+// tacho count, with halves rounded away from zero.
+func (m *TachoMotor) SetPositionSetpointRotations(rot float64) *TachoMotor {
+	return m.setPositionSetpointCounts(rot, 1)
+}
+
+// setPositionSetpointCounts sets the position setpoint to the
+// number of tacho counts corresponding to v output shaft units
+// when there are perRot units in each output shaft rotation.
+func (m *TachoMotor) setPositionSetpointCounts(v, perRot float64) *TachoMotor {
+	if loadErr(&m.err) != nil {
+		return m
+	}
+	if m.countPerRot <= 0 {
+		setErr(&m.err, fmt.Errorf("ev3dev: no count per rotation for %s", deviceName(m)))
+		return m
+	}
+	// The division is performed last and the result
+	// rounded once so that non-integer ratios do not
+	// introduce intermediate rounding error.
+	sp := math.Round(v * float64(m.countPerRot) * m.GearRatio() / perRot)
+	if math.IsNaN(sp) || sp < math.MinInt32 || math.MaxInt32 < sp {
+		setErr(&m.err, fmt.Errorf("ev3dev: position setpoint for %s out of range: %v", deviceName(m), v))
+		return m
+	}
+	return m.SetPositionSetpoint(int(sp))
+}
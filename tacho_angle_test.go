@@ -0,0 +1,125 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var tachoAngleTests = []struct {
+	countPerRot int
+	ratio       float64
+
+	position    int
+	wantDegrees float64
+
+	setDegrees float64
+	wantSP     int
+}{
+	{countPerRot: 360, ratio: 0, position: 90, wantDegrees: 90, setDegrees: 90, wantSP: 90},
+	{countPerRot: 360, ratio: 3, position: 270, wantDegrees: 90, setDegrees: 90, wantSP: 270},
+	{countPerRot: 360, ratio: 3, position: -270, wantDegrees: -90, setDegrees: -90, wantSP: -270},
+	{countPerRot: 360, ratio: 1.0 / 3, position: 30, wantDegrees: 90, setDegrees: 90, wantSP: 30},
+	{countPerRot: 360, ratio: 1.0 / 3, position: 30, wantDegrees: 90, setDegrees: 100, wantSP: 33},
+	{countPerRot: 360, ratio: 2.5, position: 900, wantDegrees: 360, setDegrees: 0.2, wantSP: 1},
+	{countPerRot: 360, ratio: 2.5, position: 900, wantDegrees: 360, setDegrees: -0.2, wantSP: -1},
+	{countPerRot: 360, ratio: 1.4, position: 504, wantDegrees: 360, setDegrees: 1.25, wantSP: 2},
+	{countPerRot: 3600, ratio: 24.0 / 40, position: 2160, wantDegrees: 360, setDegrees: 45, wantSP: 270},
+}
+
+func TestTachoMotorAngle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {
+			position:         "0",
+			positionSetpoint: "0",
+		},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	attr := func(name string) string {
+		return filepath.Join(dir, TachoMotorPath, "motor0", name)
+	}
+	for _, test := range tachoAngleTests {
+		m := &TachoMotor{id: 0, countPerRot: test.countPerRot}
+		if test.ratio != 0 {
+			m.SetGearRatio(test.ratio)
+		}
+		err = ioutil.WriteFile(attr(position), []byte(strconv.Itoa(test.position)+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write position: %v", err)
+		}
+		got, err := m.PositionDegrees()
+		if err != nil {
+			t.Errorf("unexpected error getting position for ratio %v: %v", test.ratio, err)
+		}
+		if math.Abs(got-test.wantDegrees) > 1e-9 {
+			t.Errorf("unexpected position for ratio %v: got:%v want:%v", test.ratio, got, test.wantDegrees)
+		}
+		rot, err := m.PositionRotations()
+		if err != nil {
+			t.Errorf("unexpected error getting rotations for ratio %v: %v", test.ratio, err)
+		}
+		if math.Abs(rot-test.wantDegrees/360) > 1e-9 {
+			t.Errorf("unexpected rotations for ratio %v: got:%v want:%v", test.ratio, rot, test.wantDegrees/360)
+		}
+
+		err = m.SetPositionSetpointDegrees(test.setDegrees).Err()
+		if err != nil {
+			t.Errorf("unexpected error setting position setpoint for ratio %v: %v", test.ratio, err)
+		}
+		b, err := ioutil.ReadFile(attr(positionSetpoint))
+		if err != nil {
+			t.Fatalf("failed to read position setpoint: %v", err)
+		}
+		if gotSP := strings.TrimSpace(string(b)); gotSP != strconv.Itoa(test.wantSP) {
+			t.Errorf("unexpected position setpoint for %v degrees with ratio %v: got:%s want:%d",
+				test.setDegrees, test.ratio, gotSP, test.wantSP)
+		}
+	}
+}
+
+func TestTachoMotorAngleErrors(t *testing.T) {
+	for _, r := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		m := &TachoMotor{id: 0, countPerRot: 360}
+		err := m.SetGearRatio(r).Err()
+		if err == nil {
+			t.Errorf("expected error for gear ratio %v", r)
+		}
+		if got := m.GearRatio(); got != 1 {
+			t.Errorf("unexpected gear ratio after invalid ratio %v: got:%v want:1", r, got)
+		}
+	}
+
+	m := &TachoMotor{id: 0, countPerRot: 360}
+	for _, deg := range []float64{math.NaN(), math.Inf(1), 1e12} {
+		err := m.SetPositionSetpointDegrees(deg).Err()
+		if err == nil {
+			t.Errorf("expected error for %v degrees", deg)
+		}
+	}
+
+	m = &TachoMotor{id: 0}
+	_, err := m.PositionDegrees()
+	if err == nil {
+		t.Error("expected error for position without count per rotation")
+	}
+	err = m.SetPositionSetpointDegrees(90).Err()
+	if err == nil {
+		t.Error("expected error for position setpoint without count per rotation")
+	}
+}
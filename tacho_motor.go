@@ -5,6 +5,7 @@
 package ev3dev
 
 import (
+	"context"
 	"math"
 	"path/filepath"
 	"strconv"
@@ -22,6 +23,24 @@ type TachoMotor struct {
 	countPerRot, maxSpeed int
 	commands, stopActions []string
 
+	// gearRatio is the number of motor
+	// rotations per output shaft rotation
+	// set by SetGearRatio. A zero value
+	// is treated as a ratio of one.
+	gearRatio float64
+
+	// ctx is the default context
+	// for attribute IO.
+	ctx context.Context
+
+	// onClose holds the functions
+	// registered by OnClose.
+	onClose []*closeHook
+
+	// notifier serves the thresholds
+	// registered by NotifyAtPosition.
+	notifier *positionNotifier
+
 	err error
 }
 
@@ -41,14 +60,13 @@ func (m *TachoMotor) String() string {
 
 // Err returns the error state of the TachoMotor and clears it.
 func (m *TachoMotor) Err() error {
-	err := m.err
-	m.err = nil
-	return err
+	return takeErr(&m.err)
 }
 
 // idInt and setID satisfy the idSetter interface.
 func (m *TachoMotor) setID(id int) error {
 	t := TachoMotor{id: id}
+	forgetName(&t)
 	var err error
 	t.countPerRot, err = intFrom(attributeOf(&t, countPerRot))
 	if err != nil {
@@ -98,6 +116,7 @@ func TachoMotorFor(port, driver string) (*TachoMotor, error) {
 	if _err != nil {
 		err = _err
 	}
+	setStrictFinalizer(&m)
 	return &m, err
 }
 
@@ -112,7 +131,13 @@ func (m *TachoMotor) Next() (*TachoMotor, error) {
 	if id == -1 {
 		return nil, err
 	}
-	return &TachoMotor{id: id}, err
+	var n TachoMotor
+	_err := n.setID(id)
+	if _err != nil {
+		err = _err
+	}
+	setStrictFinalizer(&n)
+	return &n, err
 }
 
 // Driver returns the driver used by the TachoMotor.
@@ -132,23 +157,24 @@ func (m *TachoMotor) Commands() []string {
 	return avail
 }
 
-// Command issues a command to the TachoMotor.
-func (m *TachoMotor) Command(comm string) *TachoMotor {
-	if m.err != nil {
+// Command issues a command to the TachoMotor. The command must be one of the
+// commands listed by Commands.
+func (m *TachoMotor) Command(comm MotorCommand) *TachoMotor {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, c := range m.commands {
-		if c == comm {
+		if c == string(comm) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, command, "", comm, m.Commands())
+		setErr(&m.err, newInvalidValueError(m, command, "", string(comm), m.Commands()))
 		return m
 	}
-	m.err = setAttributeOf(m, command, comm)
+	setErr(&m.err, setAttributeOf(m, command, string(comm)))
 	return m
 }
 
@@ -169,14 +195,14 @@ func (m *TachoMotor) DutyCycleSetpoint() (int, error) {
 
 // SetDutyCycleSetpoint sets the duty cycle setpoint value for the TachoMotor
 func (m *TachoMotor) SetDutyCycleSetpoint(sp int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < -100 || 100 < sp {
-		m.err = newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100)
+		setErr(&m.err, newValueOutOfRangeError(m, dutyCycleSetpoint, sp, -100, 100))
 		return m
 	}
-	m.err = setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, dutyCycleSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -188,14 +214,14 @@ func (m *TachoMotor) Polarity() (Polarity, error) {
 
 // SetPolarity sets the polarity of the TachoMotor
 func (m *TachoMotor) SetPolarity(p Polarity) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if p != Normal && p != Inversed {
-		m.err = newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)})
+		setErr(&m.err, newInvalidValueError(m, polarity, "", string(p), []string{string(Normal), string(Inversed)}))
 		return m
 	}
-	m.err = setAttributeOf(m, polarity, string(p))
+	setErr(&m.err, setAttributeOf(m, polarity, string(p)))
 	return m
 }
 
@@ -206,14 +232,14 @@ func (m *TachoMotor) Position() (int, error) {
 
 // SetPosition sets the position value for the TachoMotor.
 func (m *TachoMotor) SetPosition(pos int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if pos != int(int32(pos)) {
-		m.err = newValueOutOfRangeError(m, position, pos, math.MinInt32, math.MaxInt32)
+		setErr(&m.err, newValueOutOfRangeError(m, position, pos, math.MinInt32, math.MaxInt32))
 		return m
 	}
-	m.err = setAttributeOf(m, position, strconv.Itoa(pos))
+	setErr(&m.err, setAttributeOf(m, position, strconv.Itoa(pos)))
 	return m
 }
 
@@ -224,10 +250,10 @@ func (m *TachoMotor) HoldPIDKd() (int, error) {
 
 // SetHoldPIDKd sets the derivative constant for the position PID for the TachoMotor.
 func (m *TachoMotor) SetHoldPIDKd(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDkd, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDkd, strconv.Itoa(k)))
 	return m
 }
 
@@ -238,10 +264,10 @@ func (m *TachoMotor) HoldPIDKi() (int, error) {
 
 // SetHoldPIDKi sets the integral constant for the position PID for the TachoMotor.
 func (m *TachoMotor) SetHoldPIDKi(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDki, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDki, strconv.Itoa(k)))
 	return m
 }
 
@@ -252,13 +278,23 @@ func (m *TachoMotor) HoldPIDKp() (int, error) {
 
 // SetHoldPIDKp sets the proportional constant for the position PID for the TachoMotor.
 func (m *TachoMotor) SetHoldPIDKp(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, holdPIDkp, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, holdPIDkp, strconv.Itoa(k)))
 	return m
 }
 
+// HoldPID returns the constants of the position PID for the TachoMotor.
+func (m *TachoMotor) HoldPID() (PID, error) {
+	return pidOf(m, holdPIDkp, holdPIDki, holdPIDkd)
+}
+
+// SetHoldPID sets the constants of the position PID for the TachoMotor.
+func (m *TachoMotor) SetHoldPID(pid PID) *TachoMotor {
+	return m.SetHoldPIDKp(pid.Kp).SetHoldPIDKi(pid.Ki).SetHoldPIDKd(pid.Kd)
+}
+
 // MaxSpeed returns the maximum value that is accepted by SpeedSetpoint.
 func (m *TachoMotor) MaxSpeed() int {
 	return m.maxSpeed
@@ -271,14 +307,14 @@ func (m *TachoMotor) PositionSetpoint() (int, error) {
 
 // SetPositionSetpoint sets the position setpoint value for the TachoMotor.
 func (m *TachoMotor) SetPositionSetpoint(sp int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp != int(int32(sp)) {
-		m.err = newValueOutOfRangeError(m, positionSetpoint, sp, math.MinInt32, math.MaxInt32)
+		setErr(&m.err, newValueOutOfRangeError(m, positionSetpoint, sp, math.MinInt32, math.MaxInt32))
 		return m
 	}
-	m.err = setAttributeOf(m, positionSetpoint, strconv.Itoa(sp))
+	setErr(&m.err, setAttributeOf(m, positionSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -293,11 +329,16 @@ func (m *TachoMotor) SpeedSetpoint() (int, error) {
 }
 
 // SetSpeedSetpoint sets the speed setpoint value for the TachoMotor.
+// The magnitude of sp must not exceed MaxSpeed.
 func (m *TachoMotor) SetSpeedSetpoint(sp int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedSetpoint, strconv.Itoa(sp))
+	if sp < -m.maxSpeed || m.maxSpeed < sp {
+		setErr(&m.err, newValueOutOfRangeError(m, speedSetpoint, sp, -m.maxSpeed, m.maxSpeed))
+		return m
+	}
+	setErr(&m.err, setAttributeOf(m, speedSetpoint, strconv.Itoa(sp)))
 	return m
 }
 
@@ -308,14 +349,14 @@ func (m *TachoMotor) RampUpSetpoint() (time.Duration, error) {
 
 // SetRampUpSetpoint sets the ramp up setpoint value for the TachoMotor.
 func (m *TachoMotor) SetRampUpSetpoint(sp time.Duration) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, rampUpSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, rampUpSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampUpSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -326,14 +367,14 @@ func (m *TachoMotor) RampDownSetpoint() (time.Duration, error) {
 
 // SetRampDownSetpoint sets the ramp down setpoint value for the TachoMotor.
 func (m *TachoMotor) SetRampDownSetpoint(sp time.Duration) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, rampDownSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, rampDownSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, rampDownSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -344,10 +385,10 @@ func (m *TachoMotor) SpeedPIDKd() (int, error) {
 
 // SetSpeedPIDKd sets the derivative constant for the speed regulation PID for the TachoMotor.
 func (m *TachoMotor) SetSpeedPIDKd(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDkd, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, speedPIDkd, strconv.Itoa(k)))
 	return m
 }
 
@@ -358,10 +399,10 @@ func (m *TachoMotor) SpeedPIDKi() (int, error) {
 
 // SetSpeedPIDKi sets the integral constant for the speed regulation PID for the TachoMotor.
 func (m *TachoMotor) SetSpeedPIDKi(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDki, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, speedPIDki, strconv.Itoa(k)))
 	return m
 }
 
@@ -372,45 +413,64 @@ func (m *TachoMotor) SpeedPIDKp() (int, error) {
 
 // SetSpeedPIDKp sets the proportional constant for the speed regulation PID for the TachoMotor.
 func (m *TachoMotor) SetSpeedPIDKp(k int) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
-	m.err = setAttributeOf(m, speedPIDkp, strconv.Itoa(k))
+	setErr(&m.err, setAttributeOf(m, speedPIDkp, strconv.Itoa(k)))
 	return m
 }
 
+// SpeedPID returns the constants of the speed regulation PID for the TachoMotor.
+func (m *TachoMotor) SpeedPID() (PID, error) {
+	return pidOf(m, speedPIDkp, speedPIDki, speedPIDkd)
+}
+
+// SetSpeedPID sets the constants of the speed regulation PID for the TachoMotor.
+func (m *TachoMotor) SetSpeedPID(pid PID) *TachoMotor {
+	return m.SetSpeedPIDKp(pid.Kp).SetSpeedPIDKi(pid.Ki).SetSpeedPIDKd(pid.Kd)
+}
+
 // State returns the current state of the TachoMotor.
 func (m *TachoMotor) State() (MotorState, error) {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return 0, m.Err()
 	}
 	return stateFrom(attributeOf(m, state))
 }
 
+// WaitContext waits until the TachoMotor reaches the wanted motor state under
+// the motor state mask, or ctx is done. It is equivalent to
+// WaitContext(ctx, m, mask, want, not, any).
+func (m *TachoMotor) WaitContext(ctx context.Context, mask, want, not MotorState, any bool) (stat MotorState, ok bool, err error) {
+	return WaitContext(ctx, m, mask, want, not, any)
+}
+
 // StopAction returns the stop action used when a stop command is issued
 // to the TachoMotor.
-func (m *TachoMotor) StopAction() (string, error) {
-	return stringFrom(attributeOf(m, stopAction))
+func (m *TachoMotor) StopAction() (StopAction, error) {
+	action, err := stringFrom(attributeOf(m, stopAction))
+	return StopAction(action), err
 }
 
 // SetStopAction sets the stop action to be used when a stop command is
-// issued to the TachoMotor.
-func (m *TachoMotor) SetStopAction(action string) *TachoMotor {
-	if m.err != nil {
+// issued to the TachoMotor. The action must be one
+// of the stop actions listed by StopActions.
+func (m *TachoMotor) SetStopAction(action StopAction) *TachoMotor {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	ok := false
 	for _, a := range m.stopActions {
-		if a == action {
+		if a == string(action) {
 			ok = true
 			break
 		}
 	}
 	if !ok {
-		m.err = newInvalidValueError(m, stopAction, "", action, m.StopActions())
+		setErr(&m.err, newInvalidValueError(m, stopAction, "", string(action), m.StopActions()))
 		return m
 	}
-	m.err = setAttributeOf(m, stopAction, action)
+	setErr(&m.err, setAttributeOf(m, stopAction, string(action)))
 	return m
 }
 
@@ -433,14 +493,14 @@ func (m *TachoMotor) TimeSetpoint() (time.Duration, error) {
 
 // SetTimeSetpoint sets the time setpoint value for the TachoMotor.
 func (m *TachoMotor) SetTimeSetpoint(sp time.Duration) *TachoMotor {
-	if m.err != nil {
+	if loadErr(&m.err) != nil {
 		return m
 	}
 	if sp < 0 {
-		m.err = newNegativeDurationError(m, timeSetpoint, sp)
+		setErr(&m.err, newNegativeDurationError(m, timeSetpoint, sp))
 		return m
 	}
-	m.err = setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond)))
+	setErr(&m.err, setAttributeOf(m, timeSetpoint, strconv.Itoa(int(sp/time.Millisecond))))
 	return m
 }
 
@@ -1180,7 +1180,7 @@ func TestTachoMotor(t *testing.T) {
 				t.Errorf("unexpected commands value: got:%q want:%q", commands, want)
 			}
 			for _, command := range commands {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err != nil {
 					t.Errorf("unexpected error for command %q: %v", command, err)
 				}
@@ -1192,7 +1192,7 @@ func TestTachoMotor(t *testing.T) {
 				}
 			}
 			for _, command := range []string{"invalid", "another"} {
-				err := m.Command(command).Err()
+				err := m.Command(MotorCommand(command)).Err()
 				if err == nil {
 					t.Errorf("expected error for command %q", command)
 				}
@@ -1487,7 +1487,8 @@ func TestTachoMotor(t *testing.T) {
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			for _, v := range []int{-100, -50, 0, 50, 100} {
+			max := c.tachoMotor.maxSpeed()
+			for _, v := range []int{-max, -max / 2, 0, max / 2, max} {
 				err := m.SetSpeedSetpoint(v).Err()
 				if err != nil {
 					t.Errorf("unexpected error for speed setpoint %d: %v", v, err)
@@ -1502,6 +1503,12 @@ func TestTachoMotor(t *testing.T) {
 					t.Errorf("unexpected speed setpoint value: got:%d want:%d", got, want)
 				}
 			}
+			for _, v := range []int{-max - 1, max + 1} {
+				err := m.SetSpeedSetpoint(v).Err()
+				if err == nil {
+					t.Errorf("expected error for speed setpoint %d", v)
+				}
+			}
 		}
 	})
 
@@ -1677,7 +1684,7 @@ func TestTachoMotor(t *testing.T) {
 				t.Errorf("unexpected stop actions value: got:%q want:%q", stopActions, want)
 			}
 			for _, stopAction := range stopActions {
-				err := m.SetStopAction(stopAction).Err()
+				err := m.SetStopAction(StopAction(stopAction)).Err()
 				if err != nil {
 					t.Errorf("unexpected error for set stop action %q: %v", stopAction, err)
 				}
@@ -1688,16 +1695,16 @@ func TestTachoMotor(t *testing.T) {
 					t.Errorf("unexpected stop action value: got:%q want:%q", got, want)
 				}
 
-				got, err = m.StopAction()
+				gotAction, err := m.StopAction()
 				if err != nil {
 					t.Errorf("unexpected error for stop action %q: %v", stopAction, err)
 				}
-				if got != want {
+				if got := string(gotAction); got != want {
 					t.Errorf("unexpected stop action value: got:%q want:%q", got, want)
 				}
 			}
 			for _, stopAction := range []string{"invalid", "another"} {
-				err := m.SetStopAction(stopAction).Err()
+				err := m.SetStopAction(StopAction(stopAction)).Err()
 				if err == nil {
 					t.Errorf("expected error for set stop action %q", stopAction)
 				}
@@ -0,0 +1,59 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"time"
+)
+
+// RunToAbsPosition sets the position and speed setpoints of the TachoMotor
+// and issues the run-to-abs-pos command. If block is true, RunToAbsPosition
+// waits until the motor is no longer running, including when it is holding
+// its position with the hold stop action, and returns the final motor state.
+// Otherwise the returned motor state is zero.
+//
+// The wait is cancelled if the TachoMotor's default context is done.
+func (m *TachoMotor) RunToAbsPosition(pos, speed int, block bool) (MotorState, error) {
+	err := m.SetPositionSetpoint(pos).SetSpeedSetpoint(speed).Command(CommandRunToAbsPos).Err()
+	if err != nil || !block {
+		return 0, err
+	}
+	return m.waitNotRunning()
+}
+
+// RunToRelPosition sets the position and speed setpoints of the TachoMotor
+// and issues the run-to-rel-pos command, moving the motor by delta tacho
+// counts. The block parameter and the returned motor state are as described
+// for RunToAbsPosition.
+func (m *TachoMotor) RunToRelPosition(delta, speed int, block bool) (MotorState, error) {
+	err := m.SetPositionSetpoint(delta).SetSpeedSetpoint(speed).Command(CommandRunToRelPos).Err()
+	if err != nil || !block {
+		return 0, err
+	}
+	return m.waitNotRunning()
+}
+
+// waitNotRunning waits until the TachoMotor is not running
+// or its default context is done, returning its motor state.
+func (m *TachoMotor) waitNotRunning() (MotorState, error) {
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stat, _, err := WaitContext(ctx, m, Running, 0, 0, false)
+	return stat, err
+}
+
+// RunTimed sets the time and speed setpoints of the TachoMotor and issues
+// the run-timed command. The block parameter and the returned motor state
+// are as described for RunToAbsPosition.
+func (m *TachoMotor) RunTimed(d time.Duration, speed int, block bool) (MotorState, error) {
+	err := m.SetTimeSetpoint(d).SetSpeedSetpoint(speed).Command(CommandRunTimed).Err()
+	if err != nil || !block {
+		return 0, err
+	}
+	return m.waitNotRunning()
+}
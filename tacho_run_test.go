@@ -0,0 +1,138 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runMotor returns a running TachoMotor backed by a temporary sysfs
+// tree rooted at dir.
+func runMotor(t *testing.T, dir string) *TachoMotor {
+	base := filepath.Join(dir, TachoMotorPath, "motor0")
+	err := os.MkdirAll(base, 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	for _, attr := range []string{positionSetpoint, speedSetpoint, timeSetpoint, command} {
+		err = ioutil.WriteFile(filepath.Join(base, attr), nil, 0644)
+		if err != nil {
+			t.Fatalf("failed to create attribute: %v", err)
+		}
+	}
+	err = ioutil.WriteFile(filepath.Join(base, state), []byte(running+"\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to create state: %v", err)
+	}
+	return &TachoMotor{id: 0, maxSpeed: 1050, commands: []string{"run-timed", "run-to-abs-pos", "run-to-rel-pos", "stop"}}
+}
+
+func TestRunToPosition(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	read := func(attr string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, TachoMotorPath, "motor0", attr))
+		if err != nil {
+			t.Fatalf("failed to read attribute: %v", err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	for _, test := range []struct {
+		name    string
+		run     func(m *TachoMotor, block bool) (MotorState, error)
+		command string
+	}{
+		{
+			name:    "abs",
+			run:     func(m *TachoMotor, block bool) (MotorState, error) { return m.RunToAbsPosition(360, 500, block) },
+			command: "run-to-abs-pos",
+		},
+		{
+			name:    "rel",
+			run:     func(m *TachoMotor, block bool) (MotorState, error) { return m.RunToRelPosition(360, 500, block) },
+			command: "run-to-rel-pos",
+		},
+	} {
+		m := runMotor(t, dir)
+		stat, err := test.run(m, false)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.name, err)
+		}
+		if stat != 0 {
+			t.Errorf("unexpected state for non-blocking %s: got:%v want:0", test.name, stat)
+		}
+		for attr, want := range map[string]string{positionSetpoint: "360", speedSetpoint: "500", command: test.command} {
+			if got := read(attr); got != want {
+				t.Errorf("unexpected %s value for %s: got:%q want:%q", attr, test.name, got, want)
+			}
+		}
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			ioutil.WriteFile(filepath.Join(dir, TachoMotorPath, "motor0", state), []byte(holding+"\n"), 0644)
+		}()
+		stat, err = test.run(m, true)
+		if err != nil {
+			t.Errorf("unexpected error for blocking %s: %v", test.name, err)
+		}
+		if stat&Running != 0 {
+			t.Errorf("unexpected state for blocking %s: got:%v", test.name, stat)
+		}
+	}
+}
+
+func TestRunTimed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := runMotor(t, dir)
+	_, err = m.RunTimed(-time.Second, 500, false)
+	if _, ok := err.(negativeDurationError); !ok {
+		t.Errorf("unexpected error for negative duration: got:%v want:negativeDurationError", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ioutil.WriteFile(filepath.Join(dir, TachoMotorPath, "motor0", state), []byte("\n"), 0644)
+	}()
+	stat, err := m.RunTimed(1500*time.Millisecond, 500, true)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if stat != 0 {
+		t.Errorf("unexpected state: got:%v want:0", stat)
+	}
+	for attr, want := range map[string]string{timeSetpoint: "1500", speedSetpoint: "500", command: "run-timed"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, TachoMotorPath, "motor0", attr))
+		if err != nil {
+			t.Fatalf("failed to read attribute: %v", err)
+		}
+		if got := strings.TrimSpace(string(b)); got != want {
+			t.Errorf("unexpected %s value: got:%q want:%q", attr, got, want)
+		}
+	}
+}
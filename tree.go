@@ -0,0 +1,226 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TreeNode is a port or device in a DeviceTree.
+type TreeNode struct {
+	// Class is the sysfs class of the node,
+	// for example "lego-port" or "tacho-motor".
+	Class string `json:"class"`
+
+	// Name is the sysfs name of the node,
+	// for example "port0" or "motor0".
+	Name string `json:"name"`
+
+	// Address, Driver and Mode are the values
+	// of the node's address, driver_name and
+	// mode attributes. Mode is empty for motors.
+	Address string `json:"address"`
+	Driver  string `json:"driver"`
+	Mode    string `json:"mode,omitempty"`
+
+	// Devices holds the devices attached to a
+	// port, in address order.
+	Devices []*TreeNode `json:"devices,omitempty"`
+}
+
+// ID returns the node's identifier, its class and name joined by a slash,
+// for example "tacho-motor/motor0". Device names are only unique within a
+// class, so the class is needed to identify a node.
+func (n *TreeNode) ID() string { return n.Class + "/" + n.Name }
+
+// TreeGroup is a named group of devices that act together, for example
+// the motors of a drive base.
+type TreeGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+
+	// Members holds the IDs of the
+	// devices in the group as returned
+	// by the TreeNode ID method.
+	Members []string `json:"members"`
+}
+
+// DeviceTree is a snapshot of the ports and devices present on the system
+// and the relationships between them. A DeviceTree can be marshaled to JSON
+// with the encoding/json package or written in DOT format with WriteDOT.
+type DeviceTree struct {
+	// Ports holds the lego-ports,
+	// in address order.
+	Ports []*TreeNode `json:"ports"`
+
+	// Unattached holds the devices that
+	// are not attached to a lego-port, in
+	// address order.
+	Unattached []*TreeNode `json:"unattached,omitempty"`
+
+	// Groups holds the device groups
+	// added with AddGroup.
+	Groups []*TreeGroup `json:"groups,omitempty"`
+}
+
+// AddGroup adds a group with the given name and member devices to the
+// DeviceTree. Groups are not discovered by ReadDeviceTree since they are
+// defined by the program. The motors of a motorutil.Steering can be added
+// using its AddToTree method.
+func (t *DeviceTree) AddGroup(name string, members ...Device) {
+	g := &TreeGroup{Name: name, Members: make([]string, len(members))}
+	for i, d := range members {
+		g.Members[i] = filepath.Base(d.Path()) + "/" + d.String()
+	}
+	t.Groups = append(t.Groups, g)
+}
+
+// treeClasses lists the device classes included in a DeviceTree.
+var treeClasses = []struct {
+	class, path string
+}{
+	{class: "tacho-motor", path: TachoMotorPath},
+	{class: "dc-motor", path: DCMotorPath},
+	{class: "servo-motor", path: ServoMotorPath},
+	{class: "lego-sensor", path: SensorPath},
+}
+
+// ReadDeviceTree reads the ports and devices present on the system. Each
+// device is attached to the port with the longest address that is a prefix
+// of the device's address, so devices behind sensor multiplexers are
+// attached to the port the multiplexer is plugged into.
+func ReadDeviceTree() (*DeviceTree, error) {
+	ports, err := treeNodes("lego-port", LegoPortPath)
+	if err != nil {
+		return nil, err
+	}
+	var devices []*TreeNode
+	for _, c := range treeClasses {
+		nodes, err := treeNodes(c.class, c.path)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, nodes...)
+	}
+	sortNodes(devices)
+
+	var t DeviceTree
+	t.Ports = ports
+	for _, d := range devices {
+		var port *TreeNode
+		for _, p := range ports {
+			if isAddressPrefix(p.Address, d.Address) && (port == nil || len(port.Address) < len(p.Address)) {
+				port = p
+			}
+		}
+		if port == nil {
+			t.Unattached = append(t.Unattached, d)
+			continue
+		}
+		port.Devices = append(port.Devices, d)
+	}
+	return &t, nil
+}
+
+// isAddressPrefix returns whether the address
+// port is a path prefix of the address dev.
+func isAddressPrefix(port, dev string) bool {
+	return port != "" && strings.HasPrefix(dev, port) && (len(dev) == len(port) || dev[len(port)] == ':')
+}
+
+// treeNodes returns the nodes for the devices of the given
+// class, sorted by address. A missing class is not an error.
+func treeNodes(class, path string) ([]*TreeNode, error) {
+	names, err := devicesIn(filepath.Join(prefix, path))
+	if os.IsNotExist(err) {
+		// The class directory only exists
+		// when its driver is loaded.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*TreeNode
+	for _, n := range names {
+		d := namedDevice{path: path, name: n}
+		addr, err := stringFrom(attributeOf(d, address))
+		if os.IsNotExist(cause(err)) {
+			// The device was removed
+			// while reading the tree.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		node := &TreeNode{Class: class, Name: n, Address: addr}
+		node.Driver, err = stringFrom(attributeOf(d, driverName))
+		if err != nil {
+			return nil, err
+		}
+		if class == "lego-port" || class == "lego-sensor" {
+			node.Mode, err = stringFrom(attributeOf(d, mode))
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	sortNodes(nodes)
+	return nodes, nil
+}
+
+// sortNodes sorts nodes by address and then by name.
+func sortNodes(nodes []*TreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Address != nodes[j].Address {
+			return nodes[i].Address < nodes[j].Address
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+// WriteDOT writes the DeviceTree to w as a DOT directed graph with an
+// edge from each port to each of its attached devices. Nodes are identified
+// by their IDs and each group is written as a cluster subgraph.
+func (t *DeviceTree) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph ev3dev {")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+	for _, p := range t.Ports {
+		writeDOTNode(bw, p)
+		for _, d := range p.Devices {
+			writeDOTNode(bw, d)
+			fmt.Fprintf(bw, "\t%q -> %q;\n", p.ID(), d.ID())
+		}
+	}
+	for _, d := range t.Unattached {
+		writeDOTNode(bw, d)
+	}
+	for i, g := range t.Groups {
+		fmt.Fprintf(bw, "\tsubgraph \"cluster_%d\" {\n", i)
+		fmt.Fprintf(bw, "\t\tlabel=%q;\n", g.Name)
+		for _, m := range g.Members {
+			fmt.Fprintf(bw, "\t\t%q;\n", m)
+		}
+		fmt.Fprintln(bw, "\t}")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// writeDOTNode writes the DOT node statement for n.
+func writeDOTNode(w io.Writer, n *TreeNode) {
+	label := []string{n.Name, n.Address, n.Driver}
+	if n.Mode != "" {
+		label = append(label, n.Mode)
+	}
+	fmt.Fprintf(w, "\t%q [label=%q];\n", n.ID(), strings.Join(label, "\n"))
+}
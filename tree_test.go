@@ -0,0 +1,171 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeviceTree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for path, attrs := range map[string]map[string]string{
+		filepath.Join(LegoPortPath, "port0"):    {address: "ev3-ports:outA", driverName: "ev3-output-port", mode: "tacho-motor"},
+		filepath.Join(LegoPortPath, "port4"):    {address: "ev3-ports:in1", driverName: "ev3-input-port", mode: "auto"},
+		filepath.Join(TachoMotorPath, "motor0"): {address: "ev3-ports:outA", driverName: "lego-ev3-l-motor"},
+		filepath.Join(SensorPath, "sensor0"):    {address: "ev3-ports:in1:i2c80:mux1", driverName: "ms-nxt-touch-mux", mode: "TOUCH-MUX"},
+		filepath.Join(SensorPath, "sensor1"):    {address: "ev3-ports:in10", driverName: "lego-ev3-touch", mode: "TOUCH"},
+		filepath.Join(DCMotorPath, "motor0"):    {address: "wedo:1", driverName: "wedo-motor"},
+	} {
+		for attr, data := range attrs {
+			p := filepath.Join(dir, path, attr)
+			err = os.MkdirAll(filepath.Dir(p), 0755)
+			if err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+			err = ioutil.WriteFile(p, []byte(data+"\n"), 0644)
+			if err != nil {
+				t.Fatalf("failed to write attribute: %v", err)
+			}
+		}
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	tree, err := ReadDeviceTree()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tree.AddGroup("drive", &TachoMotor{id: 0}, &DCMotor{id: 0})
+
+	gotJSON, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		t.Fatalf("unexpected error marshaling JSON: %v", err)
+	}
+	const wantJSON = `{
+	"ports": [
+		{
+			"class": "lego-port",
+			"name": "port4",
+			"address": "ev3-ports:in1",
+			"driver": "ev3-input-port",
+			"mode": "auto",
+			"devices": [
+				{
+					"class": "lego-sensor",
+					"name": "sensor0",
+					"address": "ev3-ports:in1:i2c80:mux1",
+					"driver": "ms-nxt-touch-mux",
+					"mode": "TOUCH-MUX"
+				}
+			]
+		},
+		{
+			"class": "lego-port",
+			"name": "port0",
+			"address": "ev3-ports:outA",
+			"driver": "ev3-output-port",
+			"mode": "tacho-motor",
+			"devices": [
+				{
+					"class": "tacho-motor",
+					"name": "motor0",
+					"address": "ev3-ports:outA",
+					"driver": "lego-ev3-l-motor"
+				}
+			]
+		}
+	],
+	"unattached": [
+		{
+			"class": "lego-sensor",
+			"name": "sensor1",
+			"address": "ev3-ports:in10",
+			"driver": "lego-ev3-touch",
+			"mode": "TOUCH"
+		},
+		{
+			"class": "dc-motor",
+			"name": "motor0",
+			"address": "wedo:1",
+			"driver": "wedo-motor"
+		}
+	],
+	"groups": [
+		{
+			"name": "drive",
+			"members": [
+				"tacho-motor/motor0",
+				"dc-motor/motor0"
+			]
+		}
+	]
+}`
+	if string(gotJSON) != wantJSON {
+		t.Errorf("unexpected JSON:\ngot:\n%s\nwant:\n%s", gotJSON, wantJSON)
+	}
+
+	var buf bytes.Buffer
+	err = tree.WriteDOT(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error writing DOT: %v", err)
+	}
+	const wantDOT = `digraph ev3dev {
+	node [shape=box];
+	"lego-port/port4" [label="port4\nev3-ports:in1\nev3-input-port\nauto"];
+	"lego-sensor/sensor0" [label="sensor0\nev3-ports:in1:i2c80:mux1\nms-nxt-touch-mux\nTOUCH-MUX"];
+	"lego-port/port4" -> "lego-sensor/sensor0";
+	"lego-port/port0" [label="port0\nev3-ports:outA\nev3-output-port\ntacho-motor"];
+	"tacho-motor/motor0" [label="motor0\nev3-ports:outA\nlego-ev3-l-motor"];
+	"lego-port/port0" -> "tacho-motor/motor0";
+	"lego-sensor/sensor1" [label="sensor1\nev3-ports:in10\nlego-ev3-touch\nTOUCH"];
+	"dc-motor/motor0" [label="motor0\nwedo:1\nwedo-motor"];
+	subgraph "cluster_0" {
+		label="drive";
+		"tacho-motor/motor0";
+		"dc-motor/motor0";
+	}
+}
+`
+	if buf.String() != wantDOT {
+		t.Errorf("unexpected DOT:\ngot:\n%s\nwant:\n%s", buf.String(), wantDOT)
+	}
+}
+
+func TestDeviceTreeError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	// A class path that is not a directory
+	// is an error, unlike a missing class.
+	path := filepath.Join(dir, SensorPath)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	err = ioutil.WriteFile(path, nil, 0644)
+	if err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	_, err = ReadDeviceTree()
+	if err == nil {
+		t.Error("expected error for unreadable device class")
+	}
+}
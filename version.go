@@ -0,0 +1,87 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// modulePath is the module path of the ev3dev package.
+const modulePath = "github.com/ev3go/ev3dev"
+
+// develVersion is the version reported when the module
+// version is not recorded in the binary.
+const develVersion = "(devel)"
+
+// Version returns the version of the ev3dev module compiled into the running
+// binary, as recorded by the go command, or "(devel)" if the version is not
+// known, for example when the binary was built from a local checkout.
+func Version() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return develVersion
+	}
+	return moduleVersion(bi)
+}
+
+// moduleVersion returns the version of the ev3dev module in bi.
+func moduleVersion(bi *debug.BuildInfo) string {
+	mod := &bi.Main
+	if mod.Path != modulePath {
+		mod = nil
+		for _, d := range bi.Deps {
+			if d.Path == modulePath {
+				mod = d
+				break
+			}
+		}
+	}
+	if mod == nil {
+		return develVersion
+	}
+	if mod.Replace != nil {
+		mod = mod.Replace
+	}
+	if mod.Version == "" {
+		return develVersion
+	}
+	return mod.Version
+}
+
+var (
+	featureLock sync.Mutex
+	features    = map[string]bool{
+		// Provided by speaker.go.
+		"sound": true,
+		// Provided by lcd.go.
+		"display": true,
+	}
+)
+
+// RegisterFeature records that the named optional subsystem is compiled into
+// the binary. It is intended to be called from the init functions of packages
+// that extend ev3dev.
+func RegisterFeature(name string) {
+	featureLock.Lock()
+	features[name] = true
+	featureLock.Unlock()
+}
+
+// Features returns the sorted names of the optional subsystems compiled into
+// the binary. The sound and display subsystems are always present, others
+// are registered by the packages providing them, for example the httpdebug
+// package registers "debug-server".
+func Features() []string {
+	featureLock.Lock()
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	featureLock.Unlock()
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,65 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestModuleVersion(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		bi   debug.BuildInfo
+		want string
+	}{
+		{
+			name: "main",
+			bi:   debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: develVersion}},
+			want: develVersion,
+		},
+		{
+			name: "dependency",
+			bi: debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/robot"},
+				Deps: []*debug.Module{{Path: "golang.org/x/sys", Version: "v0.1.0"}, {Path: modulePath, Version: "v1.2.3"}},
+			},
+			want: "v1.2.3",
+		},
+		{
+			name: "replaced",
+			bi: debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/robot"},
+				Deps: []*debug.Module{{Path: modulePath, Version: "v1.2.3", Replace: &debug.Module{Path: "../ev3dev"}}},
+			},
+			want: develVersion,
+		},
+		{
+			name: "absent",
+			bi:   debug.BuildInfo{Main: debug.Module{Path: "example.com/robot"}},
+			want: develVersion,
+		},
+	} {
+		got := moduleVersion(&test.bi)
+		if got != test.want {
+			t.Errorf("unexpected version for %s: got:%q want:%q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	defer func() {
+		featureLock.Lock()
+		delete(features, "test")
+		featureLock.Unlock()
+	}()
+	RegisterFeature("test")
+	got := Features()
+	want := []string{"display", "sound", "test"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected features: got:%v want:%v", got, want)
+	}
+}
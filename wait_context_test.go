@@ -0,0 +1,126 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWaitContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, TachoMotorPath, "motor0", state)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	err = ioutil.WriteFile(path, []byte(running+"\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	stat, ok, err := m.WaitContext(ctx, Running, 0, 0, false)
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+	if ok || stat != Running {
+		t.Errorf("unexpected result: got:(%v, %t) want:(%v, false)", stat, ok, Running)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ioutil.WriteFile(path, []byte("\n"), 0644)
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stat, ok, err = m.WaitContext(ctx, Running, 0, 0, false)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !ok || stat != 0 {
+		t.Errorf("unexpected result: got:(%v, %t) want:(0, true)", stat, ok)
+	}
+}
+
+func TestWaitMulti(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	setState := func(name, s string) {
+		path := filepath.Join(dir, TachoMotorPath, name, state)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		err = ioutil.WriteFile(path, []byte(s+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write state: %v", err)
+		}
+	}
+	setState("motor0", running)
+	setState("motor1", running)
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		setState("motor1", "")
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	index, stat, err := WaitAny(ctx, Running, 0, 0, false, &TachoMotor{id: 0}, &TachoMotor{id: 1})
+	if err != nil {
+		t.Errorf("unexpected error from WaitAny: %v", err)
+	}
+	if index != 1 || stat != 0 {
+		t.Errorf("unexpected WaitAny result: got:(%d, %v) want:(1, 0)", index, stat)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	stats, err := WaitAll(ctx, Running, 0, 0, false, &TachoMotor{id: 0}, &TachoMotor{id: 1})
+	if err != context.DeadlineExceeded {
+		t.Errorf("unexpected error from WaitAll: got:%v want:%v", err, context.DeadlineExceeded)
+	}
+	if want := []MotorState{Running, 0}; !reflect.DeepEqual(stats, want) {
+		t.Errorf("unexpected WaitAll states: got:%v want:%v", stats, want)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		setState("motor0", "")
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stats, err = WaitAll(ctx, Running, 0, 0, false, &TachoMotor{id: 0}, &TachoMotor{id: 1})
+	if err != nil {
+		t.Errorf("unexpected error from WaitAll: %v", err)
+	}
+	if want := []MotorState{0, 0}; !reflect.DeepEqual(stats, want) {
+		t.Errorf("unexpected WaitAll states: got:%v want:%v", stats, want)
+	}
+}
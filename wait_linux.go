@@ -7,6 +7,7 @@
 package ev3dev
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"time"
@@ -28,6 +29,23 @@ import (
 // Wait will not set the error state of the StaterDevice, but will clear and
 // return it if it is not nil.
 func Wait(d StaterDevice, mask, want, not MotorState, any bool, timeout time.Duration) (stat MotorState, ok bool, err error) {
+	return wait(context.Background(), d, mask, want, not, any, timeout)
+}
+
+// WaitContext blocks until the wanted motor state under the motor state mask
+// is reached, or the context is done. If the context is done before the
+// wanted motor state is reached, WaitContext returns the last unmasked motor
+// state read and ctx.Err(). The mask, want, not and any parameters, and the
+// handling of the StaterDevice's error state, are as described for Wait.
+func WaitContext(ctx context.Context, d StaterDevice, mask, want, not MotorState, any bool) (stat MotorState, ok bool, err error) {
+	return wait(ctx, d, mask, want, not, any, -1)
+}
+
+// ctxPoll is the maximum time between checks
+// of a cancellable context while polling.
+const ctxPoll = 50 * time.Millisecond
+
+func wait(ctx context.Context, d StaterDevice, mask, want, not MotorState, any bool, timeout time.Duration) (stat MotorState, ok bool, err error) {
 	// We use a direct implementation of the State method here
 	// to ensure we are polling on the same file as we are reading
 	// from. Also, since we are potentially probing the state
@@ -42,6 +60,10 @@ func Wait(d StaterDevice, mask, want, not MotorState, any bool, timeout time.Dur
 	if err != nil {
 		return 0, false, err
 	}
+	err = ctx.Err()
+	if err != nil {
+		return 0, false, err
+	}
 
 	path := filepath.Join(d.Path(), d.String(), state)
 	f, err := os.Open(path)
@@ -76,8 +98,22 @@ func Wait(d StaterDevice, mask, want, not MotorState, any bool, timeout time.Dur
 					_timeout = remain
 				}
 			}
+			capped := false
+			if ctx.Done() != nil && (_timeout < 0 || ctxPoll < _timeout) {
+				// Wake periodically to check
+				// for context cancellation.
+				_timeout = ctxPoll
+				capped = true
+			}
 			n, err := unix.Poll(fds, int(_timeout/time.Millisecond))
 			if n == 0 {
+				if capped && err == nil {
+					err = ctx.Err()
+					if err != nil {
+						return stat, false, err
+					}
+					continue
+				}
 				return 0, false, err
 			}
 		}
@@ -93,7 +129,11 @@ func Wait(d StaterDevice, mask, want, not MotorState, any bool, timeout time.Dur
 		if remain := end.Sub(time.Now()); remain < relax {
 			relax = remain / 2
 		}
-		time.Sleep(relax)
+		select {
+		case <-time.After(relax):
+		case <-ctx.Done():
+			return stat, false, ctx.Err()
+		}
 	}
 
 	return stat, false, nil
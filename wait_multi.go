@@ -0,0 +1,86 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import "context"
+
+// waitResult is the result of waiting on
+// a single device in a group.
+type waitResult struct {
+	index int
+	stat  MotorState
+	err   error
+}
+
+// WaitAll blocks until every device has reached the wanted motor state
+// under the motor state mask, or the context is done. The mask, want, not
+// and any parameters are interpreted as described for Wait. WaitAll returns
+// the last motor state read from each device in the order the devices were
+// given. If waiting on any device fails, or the context is done, WaitAll
+// stops waiting on the remaining devices and returns the first error.
+//
+// Each device must be a distinct handle since devices are waited on
+// concurrently.
+func WaitAll(ctx context.Context, mask, want, not MotorState, any bool, devs ...StaterDevice) ([]MotorState, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := waitEach(ctx, mask, want, not, any, devs)
+	stats := make([]MotorState, len(devs))
+	var first error
+	for range devs {
+		r := <-results
+		stats[r.index] = r.stat
+		if r.err != nil && first == nil {
+			first = r.err
+			cancel()
+		}
+	}
+	return stats, first
+}
+
+// WaitAny blocks until any of the devices has reached the wanted motor
+// state under the motor state mask, or the context is done. The mask, want,
+// not and any parameters are interpreted as described for Wait. WaitAny
+// returns the index of the first device to reach the wanted state and its
+// motor state. If waiting on any device fails before then, or the context
+// is done, WaitAny returns an index of -1 and the first error. If no
+// devices are given, WaitAny returns an index of -1 and a nil error.
+//
+// Each device must be a distinct handle since devices are waited on
+// concurrently.
+func WaitAny(ctx context.Context, mask, want, not MotorState, any bool, devs ...StaterDevice) (index int, stat MotorState, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := waitEach(ctx, mask, want, not, any, devs)
+	index = -1
+	for range devs {
+		r := <-results
+		if index >= 0 || err != nil {
+			// Drain the remaining results.
+			continue
+		}
+		if r.err != nil {
+			err = r.err
+		} else {
+			index = r.index
+			stat = r.stat
+		}
+		cancel()
+	}
+	return index, stat, err
+}
+
+// waitEach waits on each device in a separate goroutine, sending
+// the result for each device on the returned channel.
+func waitEach(ctx context.Context, mask, want, not MotorState, any bool, devs []StaterDevice) <-chan waitResult {
+	results := make(chan waitResult, len(devs))
+	for i, d := range devs {
+		go func(i int, d StaterDevice) {
+			stat, _, err := WaitContext(ctx, d, mask, want, not, any)
+			results <- waitResult{index: i, stat: stat, err: err}
+		}(i, d)
+	}
+	return results
+}
@@ -7,6 +7,7 @@
 package ev3dev
 
 import (
+	"context"
 	"time"
 )
 
@@ -28,3 +29,14 @@ import (
 func Wait(d StaterDevice, mask, want, not MotorState, any bool, timeout time.Duration) (stat MotorState, ok bool, err error) {
 	panic("ev3dev: needs GOOS=linux")
 }
+
+// WaitContext blocks until the wanted motor state under the motor state mask
+// is reached, or the context is done. If the context is done before the
+// wanted motor state is reached, WaitContext returns the last unmasked motor
+// state read and ctx.Err(). The mask, want, not and any parameters, and the
+// handling of the StaterDevice's error state, are as described for Wait.
+//
+// WaitContext is not implemented without a linux OS (needs unix.Poll).
+func WaitContext(ctx context.Context, d StaterDevice, mask, want, not MotorState, any bool) (stat MotorState, ok bool, err error) {
+	panic("ev3dev: needs GOOS=linux")
+}
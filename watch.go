@@ -0,0 +1,92 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"time"
+)
+
+// Watch returns a channel that receives the motor state of the TachoMotor
+// each time it changes, starting with the current state. The state is
+// polled every interval by a goroutine that does not use the TachoMotor's
+// error state, so the TachoMotor may continue to be used while it is
+// watched. The channel is closed when ctx is done, when the TachoMotor is
+// closed or when the state can no longer be read, for example because the
+// motor has been unplugged. If interval is not positive, the state is
+// polled every 10ms.
+func (m *TachoMotor) Watch(ctx context.Context, interval time.Duration) <-chan MotorState {
+	ctx, cancel := context.WithCancel(ctx)
+	return watchState(ctx, m, interval, cancel, addCloseHook(&m.onClose, cancel))
+}
+
+// Watch returns a channel that receives the motor state of the
+// LinearActuator each time it changes, starting with the current state.
+// See TachoMotor.Watch for details.
+func (m *LinearActuator) Watch(ctx context.Context, interval time.Duration) <-chan MotorState {
+	ctx, cancel := context.WithCancel(ctx)
+	return watchState(ctx, m, interval, cancel, addCloseHook(&m.onClose, cancel))
+}
+
+// Watch returns a channel that receives the motor state of the DCMotor
+// each time it changes, starting with the current state. See
+// TachoMotor.Watch for details.
+func (m *DCMotor) Watch(ctx context.Context, interval time.Duration) <-chan MotorState {
+	ctx, cancel := context.WithCancel(ctx)
+	return watchState(ctx, m, interval, cancel, addCloseHook(&m.onClose, cancel))
+}
+
+// errlessDevice is a Device that does not use
+// the error state of the device it wraps.
+type errlessDevice struct {
+	Device
+}
+
+func (errlessDevice) Err() error { return nil }
+
+// defaultStatePoll is the state polling interval used by
+// Watch when the requested interval is not positive.
+const defaultStatePoll = 10 * time.Millisecond
+
+// watchState polls the state of d every interval, sending changes on the
+// returned channel. When the watch ends, cancel is called and the close
+// hook that stops the watch is removed by calling unhook.
+func watchState(ctx context.Context, d Device, interval time.Duration, cancel, unhook func()) <-chan MotorState {
+	if interval <= 0 {
+		interval = defaultStatePoll
+	}
+	c := make(chan MotorState)
+	d = errlessDevice{d}
+	go func() {
+		defer close(c)
+		defer cancel()
+		defer unhook()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		first := true
+		var last MotorState
+		for {
+			stat, err := stateFrom(attributeOf(d, state))
+			if err != nil {
+				return
+			}
+			if first || stat != last {
+				select {
+				case c <- stat:
+				case <-ctx.Done():
+					return
+				}
+				first = false
+				last = stat
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
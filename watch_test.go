@@ -0,0 +1,119 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, TachoMotorPath, "motor0", state)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	setState := func(s string) {
+		// Write atomically to avoid the watcher
+		// reading a partially written state.
+		tmp := path + ".tmp"
+		err := ioutil.WriteFile(tmp, []byte(s+"\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write state: %v", err)
+		}
+		err = os.Rename(tmp, path)
+		if err != nil {
+			t.Fatalf("failed to rename state: %v", err)
+		}
+	}
+	setState("")
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	// A sticky error on the handle must
+	// not affect the watcher.
+	m.err = ErrTornRead
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := m.Watch(ctx, time.Millisecond)
+
+	want := []MotorState{0, Running, Running | Stalled, 0}
+	for i, w := range want {
+		select {
+		case got, ok := <-c:
+			if !ok {
+				t.Fatalf("channel closed early")
+			}
+			if got != w {
+				t.Errorf("unexpected state %d: got:%v want:%v", i, got, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for state %d", i)
+		}
+		switch i {
+		case 0:
+			setState(running)
+		case 1:
+			setState(running + " " + stalled)
+		case 2:
+			setState("")
+		}
+	}
+	if m.err != ErrTornRead {
+		t.Errorf("handle error state altered: got:%v", m.err)
+	}
+
+	os.RemoveAll(filepath.Dir(path))
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Errorf("unexpected state after removal")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for channel close")
+	}
+}
+
+func TestWatchUnhook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {state: running},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	m := &TachoMotor{id: 0}
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		// A zero interval uses the default.
+		c := m.Watch(ctx, 0)
+		if got := <-c; got != Running {
+			t.Errorf("unexpected state: got:%v want:%v", got, Running)
+		}
+		cancel()
+		for range c {
+		}
+	}
+	if n := len(m.onClose); n != 0 {
+		t.Errorf("unexpected number of close hooks after watches ended: got:%d want:0", n)
+	}
+}
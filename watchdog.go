@@ -0,0 +1,143 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+// watchdog holds the stop actions registered with WatchMotor, keyed
+// by device path, and the state of signal handling started by
+// WatchSignals.
+var watchdog struct {
+	sync.Mutex
+	actions map[string]StopAction
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// WatchMotor registers a stop action for the motor m that is used when m
+// is stopped by StopAll. StopAll stops every attached motor whether or not
+// it is watched, so WatchMotor is only needed to stop a motor with an
+// action other than its current stop action. If action is empty, the
+// motor's current stop action is used. The motor m must be a *TachoMotor,
+// *DCMotor, *LinearActuator or *ServoMotor. Servo motors are floated and
+// action is ignored. Registering a motor again replaces its stop action.
+// The registration is removed by UnwatchMotor or when m is closed.
+//
+// StopAll is called by StopOnPanic when deferred and by the signal handler
+// started by WatchSignals. A typical program registers its motors' stop
+// actions after finding them and then does
+//
+//	stop := ev3dev.WatchSignals()
+//	defer stop()
+//	defer ev3dev.StopOnPanic()
+//
+// so that a program that is interrupted or crashes does not leave its
+// motors running.
+func WatchMotor(m Device, action StopAction) error {
+	switch m.(type) {
+	case *TachoMotor, *DCMotor, *LinearActuator, *ServoMotor:
+	default:
+		return fmt.Errorf("ev3dev: device type %T not supported by watchdog", m)
+	}
+	watchdog.Lock()
+	if watchdog.actions == nil {
+		watchdog.actions = make(map[string]StopAction)
+	}
+	watchdog.actions[devicePath(m)] = action
+	watchdog.Unlock()
+	return nil
+}
+
+// UnwatchMotor removes the stop action registered for the motor m.
+func UnwatchMotor(m Device) {
+	watchdog.Lock()
+	delete(watchdog.actions, devicePath(m))
+	watchdog.Unlock()
+}
+
+// watchedAction returns the stop action registered for m
+// with WatchMotor, or the empty string if there is none.
+func watchedAction(m Device) StopAction {
+	watchdog.Lock()
+	defer watchdog.Unlock()
+	return watchdog.actions[devicePath(m)]
+}
+
+// devicePath returns the sysfs directory of the device d.
+func devicePath(d Device) string {
+	return filepath.Join(d.Path(), d.String())
+}
+
+// StopOnPanic stops all attached motors using StopAll if the calling
+// goroutine is panicking, and then continues the panic. StopOnPanic must
+// be called directly by a deferred function call.
+//
+//	defer ev3dev.StopOnPanic()
+func StopOnPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	StopAll()
+	panic(r)
+}
+
+// WatchSignals starts a signal handler that stops all attached motors
+// using StopAll when the process receives SIGINT or SIGTERM. After the
+// motors are stopped, the default handling of the signal is restored and
+// the signal is raised again, so the process terminates as it would have
+// without the handler. The returned function stops the signal handler.
+// Calling WatchSignals while a handler is running has no effect other
+// than returning a function that stops the running handler.
+func WatchSignals() (stop func()) {
+	watchdog.Lock()
+	defer watchdog.Unlock()
+	if watchdog.signals == nil {
+		watchdog.signals = make(chan os.Signal, 1)
+		watchdog.done = make(chan struct{})
+		signal.Notify(watchdog.signals, os.Interrupt, syscall.SIGTERM)
+		go handleSignals(watchdog.signals, watchdog.done)
+	}
+	return stopSignals
+}
+
+// handleSignals waits for a signal on c and then stops all attached motors
+// and raises the signal again with its default handling restored. It
+// returns without action when done is closed.
+func handleSignals(c chan os.Signal, done chan struct{}) {
+	select {
+	case sig := <-c:
+		StopAll()
+		signal.Reset(sig)
+		p, err := os.FindProcess(os.Getpid())
+		if err == nil {
+			err = p.Signal(sig)
+		}
+		if err != nil {
+			os.Exit(1)
+		}
+	case <-done:
+	}
+}
+
+// stopSignals stops the signal handler started by WatchSignals.
+func stopSignals() {
+	watchdog.Lock()
+	defer watchdog.Unlock()
+	if watchdog.signals == nil {
+		return
+	}
+	signal.Stop(watchdog.signals)
+	close(watchdog.done)
+	watchdog.signals = nil
+	watchdog.done = nil
+}
@@ -0,0 +1,109 @@
+// Copyright ©2026 The ev3go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ev3dev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWatchdog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ev3dev")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	makeTree(t, dir, map[string]map[string]string{
+		filepath.Join(TachoMotorPath, "motor0"): {address: "ev3-ports:outA", command: "", stopAction: "coast"},
+		filepath.Join(TachoMotorPath, "motor1"): {address: "ev3-ports:outB", command: "", stopAction: "coast"},
+		filepath.Join(ServoMotorPath, "motor2"): {address: "ev3-ports:outC", command: ""},
+		filepath.Join(DCMotorPath, "motor3"):    {address: "ev3-ports:outD", command: "", stopAction: "brake"},
+		filepath.Join(TachoMotorPath, "motor4"): {address: "ev3-ports:outE", command: "", stopAction: "coast"},
+	})
+
+	defer func(p string) { prefix = p }(prefix)
+	prefix = dir
+
+	err = WatchMotor(&Sensor{id: 0}, "")
+	if err == nil {
+		t.Error("expected error registering sensor")
+	}
+
+	unwatched := &TachoMotor{id: 4}
+	closed := &TachoMotor{id: 1}
+	for _, m := range []struct {
+		dev    Device
+		action StopAction
+	}{
+		{dev: &TachoMotor{id: 0}, action: StopActionHold},
+		{dev: closed, action: StopActionBrake},
+		{dev: &ServoMotor{id: 2}, action: StopActionBrake},
+		{dev: &DCMotor{id: 3}},
+		// A registered motor that is no longer attached.
+		{dev: &TachoMotor{id: 5}, action: StopActionHold},
+		{dev: unwatched, action: StopActionHold},
+	} {
+		err := WatchMotor(m.dev, m.action)
+		if err != nil {
+			t.Errorf("unexpected error registering %v: %v", m.dev, err)
+		}
+		defer UnwatchMotor(m.dev)
+	}
+	UnwatchMotor(unwatched)
+	closed.Close()
+
+	func() {
+		defer func() {
+			r := recover()
+			if r != "test panic" {
+				t.Errorf("unexpected panic value: got:%v want:test panic", r)
+			}
+		}()
+		defer StopOnPanic()
+		panic("test panic")
+	}()
+
+	for _, test := range []struct {
+		path string
+		want string
+	}{
+		{path: filepath.Join(TachoMotorPath, "motor0", command), want: "stop"},
+		{path: filepath.Join(TachoMotorPath, "motor0", stopAction), want: "hold"},
+		// Motors without a registered stop action
+		// are stopped with their current action.
+		{path: filepath.Join(TachoMotorPath, "motor1", command), want: "stop"},
+		{path: filepath.Join(TachoMotorPath, "motor1", stopAction), want: "coast"},
+		{path: filepath.Join(ServoMotorPath, "motor2", command), want: "float"},
+		{path: filepath.Join(DCMotorPath, "motor3", command), want: "stop"},
+		{path: filepath.Join(DCMotorPath, "motor3", stopAction), want: "brake"},
+		{path: filepath.Join(TachoMotorPath, "motor4", command), want: "stop"},
+		{path: filepath.Join(TachoMotorPath, "motor4", stopAction), want: "coast"},
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, test.path))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", test.path, err)
+			continue
+		}
+		if got := strings.TrimSpace(string(b)); got != test.want {
+			t.Errorf("unexpected value for %s: got:%q want:%q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestWatchSignalsStop(t *testing.T) {
+	stop := WatchSignals()
+	again := WatchSignals()
+	stop()
+	again()
+	watchdog.Lock()
+	running := watchdog.signals != nil
+	watchdog.Unlock()
+	if running {
+		t.Error("signal handler still running after stop")
+	}
+}